@@ -0,0 +1,185 @@
+package whatsonchain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+)
+
+// AddressType is the kind of locking script a Base58Check address pays to
+type AddressType string
+
+const (
+	// AddressTypeP2PKH is a pay-to-public-key-hash address (1... on mainnet, m... or n... on testnet)
+	AddressTypeP2PKH AddressType = "p2pkh"
+
+	// AddressTypeP2SH is a pay-to-script-hash address (3... on mainnet, 2... on testnet)
+	AddressTypeP2SH AddressType = "p2sh"
+)
+
+const (
+	// base58Alphabet is the Bitcoin Base58 alphabet (no 0, O, I or l)
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+	// addressHashSize is the size of the HASH160 in an address
+	addressHashSize = 20
+
+	// maxAddressLength bounds the Base58 decoding work (addresses are 25 bytes, at most 35 characters)
+	maxAddressLength = 64
+)
+
+// addressVersions maps the Base58Check version byte to the network and address type (the same for BSV and BTC)
+var addressVersions = map[byte]struct { //nolint:gochecknoglobals // read-only lookup table
+	network     NetworkType
+	addressType AddressType
+}{
+	0x00: {NetworkMain, AddressTypeP2PKH},
+	0x05: {NetworkMain, AddressTypeP2SH},
+	0x6f: {NetworkTest, AddressTypeP2PKH},
+	0xc4: {NetworkTest, AddressTypeP2SH},
+}
+
+// DecodedAddress is a Base58Check address decoded offline (see DecodeAddress)
+type DecodedAddress struct {
+	Hash    []byte      `json:"hash"`    // HASH160 of the public key (P2PKH) or redeem script (P2SH)
+	Network NetworkType `json:"network"` // NetworkMain or NetworkTest (testnet addresses are also used on STN)
+	Type    AddressType `json:"type"`
+}
+
+// DecodeAddress decodes and checks a Base58Check address.
+// It returns ErrInvalidAddress if the characters, checksum, length or version byte are wrong.
+func DecodeAddress(address string) (*DecodedAddress, error) {
+	payload, err := base58CheckDecode(address)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) != 1+addressHashSize {
+		return nil, fmt.Errorf("%w: %q decodes to %d bytes", ErrInvalidAddress, address, len(payload))
+	}
+	version, ok := addressVersions[payload[0]]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q has unknown version 0x%02x", ErrInvalidAddress, address, payload[0])
+	}
+	return &DecodedAddress{Hash: payload[1:], Network: version.network, Type: version.addressType}, nil
+}
+
+// ValidateAddress checks an address is valid for the network (STN uses testnet addresses).
+// It returns ErrInvalidAddress if not.
+func ValidateAddress(address string, network NetworkType) error {
+	decoded, err := DecodeAddress(address)
+	if err != nil {
+		return err
+	}
+	if network == NetworkStn {
+		network = NetworkTest
+	}
+	if decoded.Network != network {
+		return fmt.Errorf("%w: %q is a %snet address, not %snet", ErrInvalidAddress, address, decoded.Network, network)
+	}
+	return nil
+}
+
+// AddressToScript returns the locking script paying to the address
+func AddressToScript(address string) ([]byte, error) {
+	decoded, err := DecodeAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	return decoded.Script(), nil
+}
+
+// AddressToScriptHash returns the script hash of the address's locking script, as used by the
+// script endpoints (e.g. GetScriptConfirmedHistory), without the AddressScripts request
+func AddressToScriptHash(address string) (string, error) {
+	decoded, err := DecodeAddress(address)
+	if err != nil {
+		return "", err
+	}
+	return decoded.ScriptHash(), nil
+}
+
+// ScriptHash returns the script hash of a locking script: its SHA-256, reversed, as hex
+func ScriptHash(script []byte) string {
+	hash := sha256.Sum256(script)
+	return hex.EncodeToString(reverseBytes(hash[:]))
+}
+
+// Script returns the locking script paying to the address:
+// OP_DUP OP_HASH160 <hash> OP_EQUALVERIFY OP_CHECKSIG (P2PKH) or OP_HASH160 <hash> OP_EQUAL (P2SH)
+func (a *DecodedAddress) Script() []byte {
+	if a.Type == AddressTypeP2SH {
+		return append(append([]byte{0xa9, addressHashSize}, a.Hash...), 0x87)
+	}
+	return append(append([]byte{0x76, 0xa9, addressHashSize}, a.Hash...), 0x88, 0xac)
+}
+
+// ScriptHash returns the script hash of the address's locking script (see ScriptHash)
+func (a *DecodedAddress) ScriptHash() string {
+	return ScriptHash(a.Script())
+}
+
+// String returns the address in Base58Check
+func (a *DecodedAddress) String() string {
+	for version, info := range addressVersions {
+		if info.network == a.Network && info.addressType == a.Type {
+			return base58CheckEncode(append([]byte{version}, a.Hash...))
+		}
+	}
+	return ""
+}
+
+// base58CheckDecode decodes Base58 and checks and strips the 4-byte double SHA-256 checksum
+func base58CheckDecode(encoded string) ([]byte, error) {
+	if encoded == "" || len(encoded) > maxAddressLength {
+		return nil, fmt.Errorf("%w: %q has an invalid length", ErrInvalidAddress, encoded)
+	}
+
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for i := 0; i < len(encoded); i++ {
+		digit := strings.IndexByte(base58Alphabet, encoded[i])
+		if digit < 0 {
+			return nil, fmt.Errorf("%w: %q has invalid character %q", ErrInvalidAddress, encoded, encoded[i])
+		}
+		n.Mul(n, radix).Add(n, big.NewInt(int64(digit)))
+	}
+
+	// Each leading "1" is a leading zero byte
+	zeros := len(encoded) - len(strings.TrimLeft(encoded, "1"))
+	decoded := append(make([]byte, zeros), n.Bytes()...)
+	if len(decoded) < 5 {
+		return nil, fmt.Errorf("%w: %q is too short", ErrInvalidAddress, encoded)
+	}
+
+	payload, checksum := decoded[:len(decoded)-4], decoded[len(decoded)-4:]
+	if !bytes.Equal(doubleSHA256(payload)[:4], checksum) {
+		return nil, fmt.Errorf("%w: %q has an invalid checksum", ErrInvalidAddress, encoded)
+	}
+	return payload, nil
+}
+
+// base58CheckEncode appends the 4-byte double SHA-256 checksum and encodes in Base58
+func base58CheckEncode(payload []byte) string {
+	data := append(append([]byte(nil), payload...), doubleSHA256(payload)[:4]...)
+
+	var encoded []byte
+	n := new(big.Int).SetBytes(data)
+	radix := big.NewInt(58)
+	digit := new(big.Int)
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, digit)
+		encoded = append(encoded, base58Alphabet[digit.Int64()])
+	}
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		encoded = append(encoded, base58Alphabet[0])
+	}
+	slices.Reverse(encoded)
+	return string(encoded)
+}
@@ -0,0 +1,129 @@
+package whatsonchain
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDecodeAddress tests decoding mainnet and testnet addresses into their locking scripts and script hashes
+func TestDecodeAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		address     string
+		network     NetworkType
+		addressType AddressType
+		hash        string
+		script      string
+		scriptHash  string
+	}{
+		{
+			"1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", NetworkMain, AddressTypeP2PKH,
+			"751e76e8199196d454941c45d1b3a323f1433bd6",
+			"76a914751e76e8199196d454941c45d1b3a323f1433bd688ac",
+			"8bd2c4f79944cd6a3cb1730cf92c513ae259eb271d81918457f3753eebe14a3f",
+		},
+		{
+			"1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", NetworkMain, AddressTypeP2PKH,
+			"62e907b15cbf27d5425399ebf6f0fb50ebb88f18",
+			"76a91462e907b15cbf27d5425399ebf6f0fb50ebb88f1888ac",
+			"8b01df4e368ea28f8dc0423bcf7a4923e3a12d307c875e47a0cfbf90b5c39161",
+		},
+		{
+			"1111111111111111111114oLvT2", NetworkMain, AddressTypeP2PKH,
+			"0000000000000000000000000000000000000000",
+			"76a914000000000000000000000000000000000000000088ac",
+			"acb87996319dca2c2e2afd6c0f7514b18e72e204069718976e1abdc8fcf5de75",
+		},
+		{
+			"mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn", NetworkTest, AddressTypeP2PKH,
+			"243f1394f44554f4ce3fd68649c19adc483ce924",
+			"76a914243f1394f44554f4ce3fd68649c19adc483ce92488ac",
+			"7bd6809f7b634c856912c8de25f39daf3b6f5692050d2160046ab4ddd5861aab",
+		},
+		{
+			"3P14159f73E4gFr7JterCCQh9QjiTjiZrG", NetworkMain, AddressTypeP2SH,
+			"e9c3dd0c07aac76179ebc76a6c78d4d67c6c160a",
+			"a914e9c3dd0c07aac76179ebc76a6c78d4d67c6c160a87",
+			"a893f75a9f1c7c7449e6a00041fd357fa578e8976144c761f704a98f7babf9da",
+		},
+		{
+			"2MzQwSSnBHWHqSAqtTVQ6v47XtaisrJa1Vc", NetworkTest, AddressTypeP2SH,
+			"4e9f39ca4688ff102128ea4ccda34105324305b0",
+			"a9144e9f39ca4688ff102128ea4ccda34105324305b087",
+			"48d15fe40e99308c5d95e77fc02968b84fd3f0d491de8a428f76939e7f287e9a",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.address, func(t *testing.T) {
+			t.Parallel()
+
+			decoded, err := DecodeAddress(test.address)
+			require.NoError(t, err)
+			assert.Equal(t, test.network, decoded.Network)
+			assert.Equal(t, test.addressType, decoded.Type)
+			assert.Equal(t, test.hash, hex.EncodeToString(decoded.Hash))
+			assert.Equal(t, test.address, decoded.String())
+
+			script, err := AddressToScript(test.address)
+			require.NoError(t, err)
+			assert.Equal(t, test.script, hex.EncodeToString(script))
+
+			scriptHash, err := AddressToScriptHash(test.address)
+			require.NoError(t, err)
+			assert.Equal(t, test.scriptHash, scriptHash)
+			assert.Equal(t, test.scriptHash, ScriptHash(script))
+		})
+	}
+}
+
+// TestDecodeAddress_Invalid tests malformed addresses are rejected
+func TestDecodeAddress_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for name, address := range map[string]string{
+		"empty":             "",
+		"invalid character": "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAM0",
+		"checksum":          "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMJ",
+		"too short":         "1111",
+		"too long":          strings.Repeat("1", maxAddressLength+1),
+		"wrong length":      base58CheckEncode(append([]byte{0x00}, make([]byte, 21)...)),
+		"unknown version":   base58CheckEncode(append([]byte{0x30}, make([]byte, addressHashSize)...)),
+		"whitespace":        " 1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH",
+	} {
+		_, err := DecodeAddress(address)
+		require.ErrorIs(t, err, ErrInvalidAddress, name)
+		_, err = AddressToScript(address)
+		require.ErrorIs(t, err, ErrInvalidAddress, name)
+		_, err = AddressToScriptHash(address)
+		require.ErrorIs(t, err, ErrInvalidAddress, name)
+	}
+}
+
+// TestValidateAddress tests addresses are checked against the network
+func TestValidateAddress(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ValidateAddress("1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", NetworkMain))
+	require.NoError(t, ValidateAddress("mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn", NetworkTest))
+	require.NoError(t, ValidateAddress("mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn", NetworkStn))
+	require.NoError(t, ValidateAddress("3P14159f73E4gFr7JterCCQh9QjiTjiZrG", NetworkMain))
+
+	err := ValidateAddress("1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", NetworkTest)
+	require.ErrorIs(t, err, ErrInvalidAddress)
+	assert.Contains(t, err.Error(), "mainnet address, not testnet")
+	require.ErrorIs(t, ValidateAddress("2MzQwSSnBHWHqSAqtTVQ6v47XtaisrJa1Vc", NetworkMain), ErrInvalidAddress)
+	require.ErrorIs(t, ValidateAddress("not-an-address", NetworkMain), ErrInvalidAddress)
+}
+
+// TestDecodedAddress_String tests an address without a known version encodes to an empty string
+func TestDecodedAddress_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, (&DecodedAddress{Network: NetworkStn, Type: AddressTypeP2PKH}).String())
+}
@@ -6,13 +6,16 @@ import (
 	"fmt"
 	"net/http"
 	netURL "net/url"
-	"time"
 )
 
 // AddressInfo this endpoint retrieves various address info.
 //
 // For more information: https://docs.whatsonchain.com/#address
 func (c *Client) AddressInfo(ctx context.Context, address string) (*AddressInfo, error) {
+	if err := c.validateAddress("address", address); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/address/%s/info", address)
 	return requestAndUnmarshal[AddressInfo](ctx, c, url, http.MethodGet, nil, ErrAddressNotFound)
 }
@@ -24,6 +27,10 @@ func (c *Client) AddressInfo(ctx context.Context, address string) (*AddressInfo,
 //
 // For more information: https://docs.whatsonchain.com/#get-balance
 func (c *Client) AddressBalance(ctx context.Context, address string) (*AddressBalance, error) {
+	if err := c.validateAddress("address", address); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/address/%s/balance", address)
 	return requestAndUnmarshal[AddressBalance](ctx, c, url, http.MethodGet, nil, ErrAddressNotFound)
 }
@@ -35,6 +42,10 @@ func (c *Client) AddressBalance(ctx context.Context, address string) (*AddressBa
 //
 // For more information: https://docs.whatsonchain.com/#get-history
 func (c *Client) AddressHistory(ctx context.Context, address string) (AddressHistory, error) {
+	if err := c.validateAddress("address", address); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/address/%s/history", address)
 	return requestAndUnmarshalSlice[*HistoryRecord](ctx, c, url, http.MethodGet, nil, ErrAddressNotFound)
 }
@@ -43,6 +54,10 @@ func (c *Client) AddressHistory(ctx context.Context, address string) (AddressHis
 //
 // For more information: https://docs.whatsonchain.com/#get-unspent-transactions
 func (c *Client) AddressUnspentTransactions(ctx context.Context, address string) (AddressHistory, error) {
+	if err := c.validateAddress("address", address); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/address/%s/unspent/all", address)
 	resp, err := requestAndUnmarshal[addressUnspentAllResponse](ctx, c, url, http.MethodGet, nil, ErrAddressNotFound)
 	if err != nil {
@@ -56,6 +71,7 @@ func (c *Client) AddressUnspentTransactions(ctx context.Context, address string)
 
 // AddressUnspentTransactionDetails this endpoint retrieves transaction details for a given address
 // Use max transactions to filter if there are more UTXOs returned than needed by the user
+// If some batches of details fail, every UTXO is still returned (with a nil Info) along with a *BulkError
 //
 // For more information: (custom request for this go package)
 func (c *Client) AddressUnspentTransactionDetails(ctx context.Context, address string, maxTransactions int) (history AddressHistory, err error) {
@@ -75,48 +91,24 @@ func (c *Client) AddressUnspentTransactionDetails(ctx context.Context, address s
 		}
 	}
 
-	// Break up the UTXOs into batches
-	batches := chunkSlice(utxos, MaxTransactionsUTXO)
-
-	// Loop Batches - and get each batch (multiple batches of MaxTransactionsUTXO)
-	txHashes := &TxHashes{}
-	for _, batch := range batches {
-		// Check for context cancellation before processing
-		select {
-		case <-ctx.Done():
-			return history, ctx.Err()
-		default:
-		}
-
-		// Reuse the TxHashes struct
-		txHashes.TxIDs = txHashes.TxIDs[:0]
-
-		// Loop the batch (max MaxTransactionsUTXO)
-		for _, utxo := range batch {
-			txHashes.TxIDs = append(txHashes.TxIDs, utxo.TxHash)
-			history = append(history, utxo)
-		}
-
-		// Get the tx details (max of MaxTransactionsUTXO)
-		var txList TxList
-		if txList, err = c.BulkTransactionDetails(ctx, txHashes); err != nil {
-			return history, err
-		}
+	// Get the tx details in batches of MaxTransactionsUTXO (fetched concurrently, see WithBulkConcurrency)
+	txHashes := &TxHashes{TxIDs: make([]string, 0, len(utxos))}
+	for _, utxo := range utxos {
+		txHashes.TxIDs = append(txHashes.TxIDs, utxo.TxHash)
+	}
+	txList, err := bulkTxIDs(ctx, c, "BulkTransactionDetails", txHashes, MaxTransactionsUTXO, c.BulkTransactionDetails)
 
-		// Build a map from tx hash to tx info for O(1) lookup
-		txInfoMap := make(map[string]*TxInfo, len(txList))
-		for _, tx := range txList {
-			txInfoMap[tx.TxID] = tx
-		}
-		// Attach tx info to all UTXOs in this batch with matching tx hash
-		for _, utxo := range batch {
-			if info, ok := txInfoMap[utxo.TxHash]; ok {
-				utxo.Info = info
-			}
+	// Attach the tx info to every UTXO with a matching tx hash (UTXOs in failed batches have none)
+	txInfoMap := make(map[string]*TxInfo, len(txList))
+	for _, tx := range txList {
+		txInfoMap[tx.TxID] = tx
+	}
+	for _, utxo := range utxos {
+		if info, ok := txInfoMap[utxo.TxHash]; ok {
+			utxo.Info = info
 		}
 	}
-
-	return history, err
+	return utxos, err
 }
 
 // DownloadStatement this endpoint downloads an address statement (PDF)
@@ -124,9 +116,13 @@ func (c *Client) AddressUnspentTransactionDetails(ctx context.Context, address s
 //
 // For more information: https://docs.whatsonchain.com/#download-statement
 func (c *Client) DownloadStatement(ctx context.Context, address string) (string, error) {
+	if err := c.validateAddress("address", address); err != nil {
+		return "", err
+	}
+
 	// This endpoint does not follow the convention of the WOC API v1
 	url := fmt.Sprintf("https://%s.whatsonchain.com/statement/%s", c.Network(), netURL.PathEscape(address))
-	return requestString(ctx, c, url)
+	return requestString(ctx, c, url, ErrAddressNotFound)
 }
 
 // bulkRequest is the common parts of the bulk requests
@@ -156,6 +152,9 @@ func (c *Client) BulkBalance(ctx context.Context, list *AddressList) (AddressBal
 	if err != nil {
 		return nil, err
 	}
+	if err = c.validateAddresses("addresses", list.Addresses); err != nil {
+		return nil, err
+	}
 
 	url := c.buildURL("/addresses/balance")
 	return requestAndUnmarshalSlice[*AddressBalanceRecord](ctx, c, url, http.MethodPost, postData, ErrAddressNotFound)
@@ -165,43 +164,11 @@ func (c *Client) BulkBalance(ctx context.Context, list *AddressList) (AddressBal
 // Max of 20 addresses at a time.
 //
 // Deprecated: BulkUnspentTransactionsProcessor wraps BulkUnspentTransactions which uses an endpoint
-// no longer in the API. Use BulkAddressConfirmedUTXOs and BulkAddressUnconfirmedUTXOs instead.
+// no longer in the API. Use BulkAddressConfirmedUTXOsProcessor and BulkAddressUnconfirmedUTXOsProcessor instead.
 //
 // For more information: https://docs.whatsonchain.com/#bulk-unspent-transactions
-func (c *Client) BulkUnspentTransactionsProcessor(ctx context.Context, list *AddressList) (responseList BulkUnspentResponse, err error) {
-	if list == nil {
-		return nil, ErrMissingRequest
-	}
-	batches := chunkSlice(list.Addresses, MaxTransactionsUTXO)
-	// Set up rate limiting with a ticker
-	ticker := time.NewTicker(time.Second / time.Duration(c.RateLimit()))
-	defer ticker.Stop()
-
-	addressList := &AddressList{}
-	for _, batch := range batches {
-		// Check for context cancellation before processing
-		select {
-		case <-ctx.Done():
-			return responseList, ctx.Err()
-		default:
-		}
-
-		// Wait for rate limit tick
-		select {
-		case <-ctx.Done():
-			return responseList, ctx.Err()
-		case <-ticker.C:
-		}
-
-		addressList.Addresses = addressList.Addresses[:0]
-		addressList.Addresses = append(addressList.Addresses, batch...)
-		var returnedList BulkUnspentResponse
-		if returnedList, err = c.BulkUnspentTransactions(ctx, addressList); err != nil {
-			return responseList, err
-		}
-		responseList = append(responseList, returnedList...)
-	}
-	return responseList, err
+func (c *Client) BulkUnspentTransactionsProcessor(ctx context.Context, list *AddressList) (BulkUnspentResponse, error) {
+	return bulkAddresses(ctx, c, "BulkUnspentTransactions", list, c.BulkUnspentTransactions)
 }
 
 // BulkUnspentTransactions retrieves unspent transactions for multiple addresses.
@@ -216,6 +183,9 @@ func (c *Client) BulkUnspentTransactions(ctx context.Context, list *AddressList)
 	if err != nil {
 		return nil, err
 	}
+	if err = c.validateAddresses("addresses", list.Addresses); err != nil {
+		return nil, err
+	}
 
 	url := c.buildURL("/addresses/unspent/all")
 	return requestAndUnmarshalSlice[*BulkResponseRecord](ctx, c, url, http.MethodPost, postData, ErrAddressNotFound)
@@ -225,6 +195,10 @@ func (c *Client) BulkUnspentTransactions(ctx context.Context, list *AddressList)
 //
 // For more information: https://docs.whatsonchain.com/#get-unconfirmed-utxos
 func (c *Client) AddressUnconfirmedUTXOs(ctx context.Context, address string) (AddressHistory, error) {
+	if err := c.validateAddress("address", address); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/address/%s/unconfirmed/unspent", address)
 	return requestAndUnmarshalSlice[*HistoryRecord](ctx, c, url, http.MethodGet, nil, ErrAddressNotFound)
 }
@@ -238,15 +212,28 @@ func (c *Client) BulkAddressUnconfirmedUTXOs(ctx context.Context, list *AddressL
 	if err != nil {
 		return nil, err
 	}
+	if err = c.validateAddresses("addresses", list.Addresses); err != nil {
+		return nil, err
+	}
 
 	url := c.buildURL("/addresses/unconfirmed/unspent")
 	return requestAndUnmarshalSlice[*BulkResponseRecord](ctx, c, url, http.MethodPost, postData, ErrAddressNotFound)
 }
 
+// BulkAddressUnconfirmedUTXOsProcessor retrieves unconfirmed UTXOs for any number of addresses in batches of 20
+// See: BulkAddressUnconfirmedUTXOs()
+func (c *Client) BulkAddressUnconfirmedUTXOsProcessor(ctx context.Context, list *AddressList) (BulkUnspentResponse, error) {
+	return bulkAddresses(ctx, c, "BulkAddressUnconfirmedUTXOs", list, c.BulkAddressUnconfirmedUTXOs)
+}
+
 // AddressConfirmedUTXOs retrieves confirmed UTXOs for an address
 //
 // For more information: https://docs.whatsonchain.com/#get-confirmed-utxos
 func (c *Client) AddressConfirmedUTXOs(ctx context.Context, address string) (AddressHistory, error) {
+	if err := c.validateAddress("address", address); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/address/%s/confirmed/unspent", address)
 	return requestAndUnmarshalSlice[*HistoryRecord](ctx, c, url, http.MethodGet, nil, ErrAddressNotFound)
 }
@@ -260,15 +247,28 @@ func (c *Client) BulkAddressConfirmedUTXOs(ctx context.Context, list *AddressLis
 	if err != nil {
 		return nil, err
 	}
+	if err = c.validateAddresses("addresses", list.Addresses); err != nil {
+		return nil, err
+	}
 
 	url := c.buildURL("/addresses/confirmed/unspent")
 	return requestAndUnmarshalSlice[*BulkResponseRecord](ctx, c, url, http.MethodPost, postData, ErrAddressNotFound)
 }
 
+// BulkAddressConfirmedUTXOsProcessor retrieves confirmed UTXOs for any number of addresses in batches of 20
+// See: BulkAddressConfirmedUTXOs()
+func (c *Client) BulkAddressConfirmedUTXOsProcessor(ctx context.Context, list *AddressList) (BulkUnspentResponse, error) {
+	return bulkAddresses(ctx, c, "BulkAddressConfirmedUTXOs", list, c.BulkAddressConfirmedUTXOs)
+}
+
 // AddressUsed retrieves whether an address has been used
 //
 // For more information: https://docs.whatsonchain.com/api/address#get-address-usage
 func (c *Client) AddressUsed(ctx context.Context, address string) (*AddressUsed, error) {
+	if err := c.validateAddress("address", address); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/address/%s/used", address)
 	return requestAndUnmarshal[AddressUsed](ctx, c, url, http.MethodGet, nil, ErrAddressNotFound)
 }
@@ -277,6 +277,10 @@ func (c *Client) AddressUsed(ctx context.Context, address string) (*AddressUsed,
 //
 // For more information: https://docs.whatsonchain.com/api/address#get-associated-scripthashes
 func (c *Client) AddressScripts(ctx context.Context, address string) (*AddressScripts, error) {
+	if err := c.validateAddress("address", address); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/address/%s/scripts", address)
 	return requestAndUnmarshal[AddressScripts](ctx, c, url, http.MethodGet, nil, ErrAddressNotFound)
 }
@@ -285,6 +289,10 @@ func (c *Client) AddressScripts(ctx context.Context, address string) (*AddressSc
 //
 // For more information: https://docs.whatsonchain.com/api/address#get-unconfirmed-balance
 func (c *Client) AddressUnconfirmedBalance(ctx context.Context, address string) (*AddressUnconfirmedBalance, error) {
+	if err := c.validateAddress("address", address); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/address/%s/unconfirmed/balance", address)
 	return requestAndUnmarshal[AddressUnconfirmedBalance](ctx, c, url, http.MethodGet, nil, ErrAddressNotFound)
 }
@@ -293,6 +301,10 @@ func (c *Client) AddressUnconfirmedBalance(ctx context.Context, address string)
 //
 // For more information: https://docs.whatsonchain.com/api/address#get-confirmed-balance
 func (c *Client) AddressConfirmedBalance(ctx context.Context, address string) (*AddressConfirmedBalance, error) {
+	if err := c.validateAddress("address", address); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/address/%s/confirmed/balance", address)
 	return requestAndUnmarshal[AddressConfirmedBalance](ctx, c, url, http.MethodGet, nil, ErrAddressNotFound)
 }
@@ -301,6 +313,10 @@ func (c *Client) AddressConfirmedBalance(ctx context.Context, address string) (*
 //
 // For more information: https://docs.whatsonchain.com/api/address#get-unconfirmed-history
 func (c *Client) AddressUnconfirmedHistory(ctx context.Context, address string) (AddressHistory, error) {
+	if err := c.validateAddress("address", address); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/address/%s/unconfirmed/history", address)
 	return requestAndUnmarshalSlice[*HistoryRecord](ctx, c, url, http.MethodGet, nil, ErrAddressNotFound)
 }
@@ -309,6 +325,10 @@ func (c *Client) AddressUnconfirmedHistory(ctx context.Context, address string)
 //
 // For more information: https://docs.whatsonchain.com/api/address#get-confirmed-history
 func (c *Client) AddressConfirmedHistory(ctx context.Context, address string) (AddressHistory, error) {
+	if err := c.validateAddress("address", address); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/address/%s/confirmed/history", address)
 	return requestAndUnmarshalSlice[*HistoryRecord](ctx, c, url, http.MethodGet, nil, ErrAddressNotFound)
 }
@@ -322,11 +342,20 @@ func (c *Client) BulkAddressUnconfirmedBalance(ctx context.Context, list *Addres
 	if err != nil {
 		return nil, err
 	}
+	if err = c.validateAddresses("addresses", list.Addresses); err != nil {
+		return nil, err
+	}
 
 	url := c.buildURL("/addresses/unconfirmed/balance")
 	return requestAndUnmarshalSlice[*AddressBalanceRecord](ctx, c, url, http.MethodPost, postData, ErrAddressNotFound)
 }
 
+// BulkAddressUnconfirmedBalanceProcessor retrieves unconfirmed balances for any number of addresses in batches of 20
+// See: BulkAddressUnconfirmedBalance()
+func (c *Client) BulkAddressUnconfirmedBalanceProcessor(ctx context.Context, list *AddressList) (AddressBalances, error) {
+	return bulkAddresses(ctx, c, "BulkAddressUnconfirmedBalance", list, c.BulkAddressUnconfirmedBalance)
+}
+
 // BulkAddressConfirmedBalance retrieves confirmed balances for multiple addresses
 // Max of 20 addresses at a time
 //
@@ -336,11 +365,20 @@ func (c *Client) BulkAddressConfirmedBalance(ctx context.Context, list *AddressL
 	if err != nil {
 		return nil, err
 	}
+	if err = c.validateAddresses("addresses", list.Addresses); err != nil {
+		return nil, err
+	}
 
 	url := c.buildURL("/addresses/confirmed/balance")
 	return requestAndUnmarshalSlice[*AddressBalanceRecord](ctx, c, url, http.MethodPost, postData, ErrAddressNotFound)
 }
 
+// BulkAddressConfirmedBalanceProcessor retrieves confirmed balances for any number of addresses in batches of 20
+// See: BulkAddressConfirmedBalance()
+func (c *Client) BulkAddressConfirmedBalanceProcessor(ctx context.Context, list *AddressList) (AddressBalances, error) {
+	return bulkAddresses(ctx, c, "BulkAddressConfirmedBalance", list, c.BulkAddressConfirmedBalance)
+}
+
 // BulkAddressUnconfirmedHistory retrieves unconfirmed transaction history for multiple addresses
 // Max of 20 addresses at a time
 //
@@ -350,11 +388,20 @@ func (c *Client) BulkAddressUnconfirmedHistory(ctx context.Context, list *Addres
 	if err != nil {
 		return nil, err
 	}
+	if err = c.validateAddresses("addresses", list.Addresses); err != nil {
+		return nil, err
+	}
 
 	url := c.buildURL("/addresses/unconfirmed/history")
 	return requestAndUnmarshalSlice[*BulkAddressHistoryRecord](ctx, c, url, http.MethodPost, postData, ErrAddressNotFound)
 }
 
+// BulkAddressUnconfirmedHistoryProcessor retrieves unconfirmed history for any number of addresses in batches of 20
+// See: BulkAddressUnconfirmedHistory()
+func (c *Client) BulkAddressUnconfirmedHistoryProcessor(ctx context.Context, list *AddressList) (BulkAddressHistoryResponse, error) {
+	return bulkAddresses(ctx, c, "BulkAddressUnconfirmedHistory", list, c.BulkAddressUnconfirmedHistory)
+}
+
 // BulkAddressConfirmedHistory retrieves confirmed transaction history for multiple addresses
 // Max of 20 addresses at a time
 //
@@ -364,11 +411,20 @@ func (c *Client) BulkAddressConfirmedHistory(ctx context.Context, list *AddressL
 	if err != nil {
 		return nil, err
 	}
+	if err = c.validateAddresses("addresses", list.Addresses); err != nil {
+		return nil, err
+	}
 
 	url := c.buildURL("/addresses/confirmed/history")
 	return requestAndUnmarshalSlice[*BulkAddressHistoryRecord](ctx, c, url, http.MethodPost, postData, ErrAddressNotFound)
 }
 
+// BulkAddressConfirmedHistoryProcessor retrieves confirmed history for any number of addresses in batches of 20
+// See: BulkAddressConfirmedHistory()
+func (c *Client) BulkAddressConfirmedHistoryProcessor(ctx context.Context, list *AddressList) (BulkAddressHistoryResponse, error) {
+	return bulkAddresses(ctx, c, "BulkAddressConfirmedHistory", list, c.BulkAddressConfirmedHistory)
+}
+
 // BulkAddressHistory retrieves all transaction history for multiple addresses
 // Max of 20 addresses at a time
 //
@@ -378,7 +434,16 @@ func (c *Client) BulkAddressHistory(ctx context.Context, list *AddressList) (Bul
 	if err != nil {
 		return nil, err
 	}
+	if err = c.validateAddresses("addresses", list.Addresses); err != nil {
+		return nil, err
+	}
 
 	url := c.buildURL("/addresses/history/all")
 	return requestAndUnmarshalSlice[*BulkAddressHistoryRecord](ctx, c, url, http.MethodPost, postData, ErrAddressNotFound)
 }
+
+// BulkAddressHistoryProcessor retrieves all transaction history for any number of addresses in batches of 20
+// See: BulkAddressHistory()
+func (c *Client) BulkAddressHistoryProcessor(ctx context.Context, list *AddressList) (BulkAddressHistoryResponse, error) {
+	return bulkAddresses(ctx, c, "BulkAddressHistory", list, c.BulkAddressHistory)
+}
@@ -44,20 +44,20 @@ func (m *mockHTTPAddresses) Do(req *http.Request) (*http.Response, error) {
 	}
 
 	// Invalid (info) return an error
-	if strings.Contains(req.URL.String(), "/error/info") {
+	if strings.Contains(req.URL.String(), "/"+testAddressError+"/info") {
 		resp.StatusCode = http.StatusInternalServerError
 		resp.Body = io.NopCloser(strings.NewReader(``))
 		return resp, errMissingRequest
 	}
 
 	// Valid (but invalid bsv address)
-	if strings.Contains(req.URL.String(), "/16ZqP5invalid/info") {
+	if strings.Contains(req.URL.String(), "/"+testAddressInvalid+"/info") {
 		resp.StatusCode = http.StatusOK
 		resp.Body = io.NopCloser(strings.NewReader(`{"isvalid": false,"address": "","scriptPubKey": "","ismine": false,"iswatchonly": false,"isscript": false}`))
 	}
 
 	// Not found
-	if strings.Contains(req.URL.String(), "/notFound/info") {
+	if strings.Contains(req.URL.String(), "/"+testAddressNotFound+"/info") {
 		resp.StatusCode = http.StatusNotFound
 		resp.Body = io.NopCloser(strings.NewReader(``))
 		return resp, nil
@@ -74,13 +74,13 @@ func (m *mockHTTPAddresses) Do(req *http.Request) (*http.Response, error) {
 	}
 
 	// Invalid (balance) return an error
-	if strings.Contains(req.URL.String(), "/16ZqP5invalid/balance") {
+	if strings.Contains(req.URL.String(), "/"+testAddressInvalid+"/balance") {
 		resp.Body = io.NopCloser(strings.NewReader(``))
 		return resp, errBadRequest
 	}
 
 	// Not found
-	if strings.Contains(req.URL.String(), "/16ZqP5notFound/balance") {
+	if strings.Contains(req.URL.String(), "/"+testAddressNotFound+"/balance") {
 		resp.StatusCode = http.StatusNotFound
 		resp.Body = io.NopCloser(strings.NewReader(``))
 		return resp, nil
@@ -103,13 +103,13 @@ func (m *mockHTTPAddresses) Do(req *http.Request) (*http.Response, error) {
 	}
 
 	// Invalid (history) return an error
-	if strings.Contains(req.URL.String(), "/16ZqP5invalid/history") {
+	if strings.Contains(req.URL.String(), "/"+testAddressInvalid+"/history") {
 		resp.Body = io.NopCloser(strings.NewReader(``))
 		return resp, errBadRequest
 	}
 
 	// Not found
-	if strings.Contains(req.URL.String(), "/16ZqP5notFound/history") {
+	if strings.Contains(req.URL.String(), "/"+testAddressNotFound+"/history") {
 		resp.StatusCode = http.StatusNotFound
 		resp.Body = io.NopCloser(strings.NewReader(``))
 		return resp, nil
@@ -132,13 +132,13 @@ func (m *mockHTTPAddresses) Do(req *http.Request) (*http.Response, error) {
 	}
 
 	// Invalid (unspent/all) return an error
-	if strings.Contains(req.URL.String(), "/16ZqP5invalid/unspent/all") {
+	if strings.Contains(req.URL.String(), "/"+testAddressInvalid+"/unspent/all") {
 		resp.Body = io.NopCloser(strings.NewReader(``))
 		return resp, errBadRequest
 	}
 
 	// Not found
-	if strings.Contains(req.URL.String(), "/16ZqP5notFound/unspent/all") {
+	if strings.Contains(req.URL.String(), "/"+testAddressNotFound+"/unspent/all") {
 		resp.StatusCode = http.StatusNotFound
 		resp.Body = io.NopCloser(strings.NewReader(``))
 		return resp, nil
@@ -187,7 +187,7 @@ endobj
 	}
 
 	// Valid (download statement) (invalid address)
-	if strings.Contains(req.URL.String(), "/statement/invalid") {
+	if strings.Contains(req.URL.String(), "/statement/"+testAddressInvalid) {
 		resp.StatusCode = http.StatusOK
 		resp.Body = io.NopCloser(strings.NewReader(`%PDF-1.4
 %Óëéá
@@ -210,7 +210,7 @@ invalid
 	//
 
 	var data TxHashes
-	if strings.Contains(req.URL.String(), "/test/txs") {
+	if strings.Contains(req.URL.String(), "/main/txs") {
 
 		decoder := json.NewDecoder(req.Body)
 		err := decoder.Decode(&data)
@@ -236,7 +236,7 @@ invalid
 	}
 
 	// Not found
-	if strings.Contains(req.URL.String(), "/16ZqP5notFound/used") {
+	if strings.Contains(req.URL.String(), "/"+testAddressNotFound+"/used") {
 		resp.StatusCode = http.StatusNotFound
 		resp.Body = io.NopCloser(strings.NewReader(``))
 		return resp, nil
@@ -253,7 +253,7 @@ invalid
 	}
 
 	// Not found
-	if strings.Contains(req.URL.String(), "/16ZqP5notFound/scripts") {
+	if strings.Contains(req.URL.String(), "/"+testAddressNotFound+"/scripts") {
 		resp.StatusCode = http.StatusNotFound
 		resp.Body = io.NopCloser(strings.NewReader(``))
 		return resp, nil
@@ -270,7 +270,7 @@ invalid
 	}
 
 	// Not found
-	if strings.Contains(req.URL.String(), "/16ZqP5notFound/unconfirmed/balance") {
+	if strings.Contains(req.URL.String(), "/"+testAddressNotFound+"/unconfirmed/balance") {
 		resp.StatusCode = http.StatusNotFound
 		resp.Body = io.NopCloser(strings.NewReader(``))
 		return resp, nil
@@ -287,7 +287,7 @@ invalid
 	}
 
 	// Not found
-	if strings.Contains(req.URL.String(), "/16ZqP5notFound/confirmed/balance") {
+	if strings.Contains(req.URL.String(), "/"+testAddressNotFound+"/confirmed/balance") {
 		resp.StatusCode = http.StatusNotFound
 		resp.Body = io.NopCloser(strings.NewReader(``))
 		return resp, nil
@@ -304,7 +304,7 @@ invalid
 	}
 
 	// Not found
-	if strings.Contains(req.URL.String(), "/16ZqP5notFound/unconfirmed/history") {
+	if strings.Contains(req.URL.String(), "/"+testAddressNotFound+"/unconfirmed/history") {
 		resp.StatusCode = http.StatusNotFound
 		resp.Body = io.NopCloser(strings.NewReader(``))
 		return resp, nil
@@ -321,7 +321,7 @@ invalid
 	}
 
 	// Not found
-	if strings.Contains(req.URL.String(), "/16ZqP5notFound/confirmed/history") {
+	if strings.Contains(req.URL.String(), "/"+testAddressNotFound+"/confirmed/history") {
 		resp.StatusCode = http.StatusNotFound
 		resp.Body = io.NopCloser(strings.NewReader(``))
 		return resp, nil
@@ -388,7 +388,7 @@ invalid
 	}
 
 	// Not found
-	if strings.Contains(req.URL.String(), "/16ZqP5notFound/unconfirmed/unspent") {
+	if strings.Contains(req.URL.String(), "/"+testAddressNotFound+"/unconfirmed/unspent") {
 		resp.StatusCode = http.StatusNotFound
 		resp.Body = io.NopCloser(strings.NewReader(``))
 		return resp, nil
@@ -405,7 +405,7 @@ invalid
 	}
 
 	// Not found
-	if strings.Contains(req.URL.String(), "/16ZqP5notFound/confirmed/unspent") {
+	if strings.Contains(req.URL.String(), "/"+testAddressNotFound+"/confirmed/unspent") {
 		resp.StatusCode = http.StatusNotFound
 		resp.Body = io.NopCloser(strings.NewReader(``))
 		return resp, nil
@@ -499,9 +499,9 @@ func TestClient_AddressInfo(t *testing.T) {
 		statusCode    int
 	}{
 		{testAddress1, testAddress1, false, http.StatusOK},
-		{"16ZqP5invalid", "", false, http.StatusOK},
-		{testMockError, "", true, http.StatusInternalServerError},
-		{testMockNotFound, "", true, http.StatusNotFound},
+		{testAddressInvalid, "", false, http.StatusOK},
+		{testAddressError, "", true, http.StatusInternalServerError},
+		{testAddressNotFound, "", true, http.StatusNotFound},
 	}
 
 	// Test all
@@ -536,8 +536,8 @@ func TestClient_AddressBalance(t *testing.T) {
 		statusCode    int
 	}{
 		{testAddress1, 10102050381, 123, false, http.StatusOK},
-		{"16ZqP5invalid", 0, 0, true, http.StatusBadRequest},
-		{"16ZqP5notFound", 0, 0, true, http.StatusNotFound},
+		{testAddressInvalid, 0, 0, true, http.StatusBadRequest},
+		{testAddressNotFound, 0, 0, true, http.StatusNotFound},
 	}
 
 	// Test all
@@ -589,8 +589,8 @@ func TestClient_AddressHistory(t *testing.T) {
 	}{
 		{testAddress1, "6b22c47e7956e5404e05c3dc87dc9f46e929acfd46c8dd7813a34e1218d2f9d1", 563052, false, http.StatusOK},
 		{"1NfHy82RqJVGEau9u5DwFRyGc6QKwDuQeT", "", 0, false, http.StatusOK},
-		{"16ZqP5invalid", "", 0, true, http.StatusBadRequest},
-		{"16ZqP5notFound", "", 0, true, http.StatusNotFound},
+		{testAddressInvalid, "", 0, true, http.StatusBadRequest},
+		{testAddressNotFound, "", 0, true, http.StatusNotFound},
 	}
 
 	// Test all
@@ -642,8 +642,8 @@ func TestClient_AddressUnspentTransactions(t *testing.T) {
 	}{
 		{testAddress1, "33b9432a0ea203bbb6ec00592622cf6e90223849e4c9a76447a19a3ed43907d3", 639302, 2451680, false, http.StatusOK},
 		{"1NfHy82RqJVGEau9u5DwFRyGc6QKwDuQeT", "", 0, 0, false, http.StatusOK},
-		{"16ZqP5invalid", "", 0, 0, true, http.StatusBadRequest},
-		{"16ZqP5notFound", "", 0, 0, true, http.StatusNotFound},
+		{testAddressInvalid, "", 0, 0, true, http.StatusBadRequest},
+		{testAddressNotFound, "", 0, 0, true, http.StatusNotFound},
 	}
 
 	// Test all
@@ -696,8 +696,8 @@ func TestClient_AddressUnspentTransactionDetails(t *testing.T) {
 		statusCode    int
 	}{
 		{testAddress1, "33b9432a0ea203bbb6ec00592622cf6e90223849e4c9a76447a19a3ed43907d3", 639302, false, http.StatusOK},
-		{"16ZqP5notFound", "", 0, true, http.StatusNotFound},
-		{"16ZqP5invalid", "", 0, true, http.StatusBadRequest},
+		{testAddressNotFound, "", 0, true, http.StatusNotFound},
+		{testAddressInvalid, "", 0, true, http.StatusBadRequest},
 	}
 
 	// Test all
@@ -746,7 +746,7 @@ func TestClient_DownloadStatement(t *testing.T) {
 		statusCode    int
 	}{
 		{testAddress1, "PDF", false, http.StatusOK},
-		{"invalid", "invalid", false, http.StatusOK},
+		{testAddressInvalid, "invalid", false, http.StatusOK},
 	}
 
 	// Test all
@@ -985,7 +985,7 @@ func TestClient_AddressUsed(t *testing.T) {
 	t.Run("not found", func(t *testing.T) {
 		client := newMockClient(&mockHTTPAddresses{})
 		ctx := context.Background()
-		used, err := client.AddressUsed(ctx, "16ZqP5notFound")
+		used, err := client.AddressUsed(ctx, testAddressNotFound)
 		require.Error(t, err)
 		assert.Nil(t, used)
 	})
@@ -1008,7 +1008,7 @@ func TestClient_AddressScripts(t *testing.T) {
 	t.Run("not found", func(t *testing.T) {
 		client := newMockClient(&mockHTTPAddresses{})
 		ctx := context.Background()
-		scripts, err := client.AddressScripts(ctx, "16ZqP5notFound")
+		scripts, err := client.AddressScripts(ctx, testAddressNotFound)
 		require.Error(t, err)
 		assert.Nil(t, scripts)
 	})
@@ -1030,7 +1030,7 @@ func TestClient_AddressUnconfirmedBalance(t *testing.T) {
 	t.Run("not found", func(t *testing.T) {
 		client := newMockClient(&mockHTTPAddresses{})
 		ctx := context.Background()
-		balance, err := client.AddressUnconfirmedBalance(ctx, "16ZqP5notFound")
+		balance, err := client.AddressUnconfirmedBalance(ctx, testAddressNotFound)
 		require.Error(t, err)
 		assert.Nil(t, balance)
 	})
@@ -1052,7 +1052,7 @@ func TestClient_AddressConfirmedBalance(t *testing.T) {
 	t.Run("not found", func(t *testing.T) {
 		client := newMockClient(&mockHTTPAddresses{})
 		ctx := context.Background()
-		balance, err := client.AddressConfirmedBalance(ctx, "16ZqP5notFound")
+		balance, err := client.AddressConfirmedBalance(ctx, testAddressNotFound)
 		require.Error(t, err)
 		assert.Nil(t, balance)
 	})
@@ -1076,7 +1076,7 @@ func TestClient_AddressUnconfirmedHistory(t *testing.T) {
 	t.Run("not found", func(t *testing.T) {
 		client := newMockClient(&mockHTTPAddresses{})
 		ctx := context.Background()
-		history, err := client.AddressUnconfirmedHistory(ctx, "16ZqP5notFound")
+		history, err := client.AddressUnconfirmedHistory(ctx, testAddressNotFound)
 		require.Error(t, err)
 		assert.Nil(t, history)
 	})
@@ -1100,7 +1100,7 @@ func TestClient_AddressConfirmedHistory(t *testing.T) {
 	t.Run("not found", func(t *testing.T) {
 		client := newMockClient(&mockHTTPAddresses{})
 		ctx := context.Background()
-		history, err := client.AddressConfirmedHistory(ctx, "16ZqP5notFound")
+		history, err := client.AddressConfirmedHistory(ctx, testAddressNotFound)
 		require.Error(t, err)
 		assert.Nil(t, history)
 	})
@@ -1263,7 +1263,7 @@ func TestClient_AddressUnconfirmedUTXOs(t *testing.T) {
 	t.Run("not found", func(t *testing.T) {
 		client := newMockClient(&mockHTTPAddresses{})
 		ctx := context.Background()
-		utxos, err := client.AddressUnconfirmedUTXOs(ctx, "16ZqP5notFound")
+		utxos, err := client.AddressUnconfirmedUTXOs(ctx, testAddressNotFound)
 		require.Error(t, err)
 		assert.Nil(t, utxos)
 	})
@@ -1289,7 +1289,7 @@ func TestClient_AddressConfirmedUTXOs(t *testing.T) {
 	t.Run("not found", func(t *testing.T) {
 		client := newMockClient(&mockHTTPAddresses{})
 		ctx := context.Background()
-		utxos, err := client.AddressConfirmedUTXOs(ctx, "16ZqP5notFound")
+		utxos, err := client.AddressConfirmedUTXOs(ctx, testAddressNotFound)
 		require.Error(t, err)
 		assert.Nil(t, utxos)
 	})
@@ -1361,7 +1361,7 @@ func TestClient_AddressUnconfirmedUTXOs_EmptyResponse(t *testing.T) {
 
 	client := newMockClient(&mockHTTPAddressesNotFound{})
 	ctx := context.Background()
-	history, err := client.AddressUnconfirmedUTXOs(ctx, testMockNotFound)
+	history, err := client.AddressUnconfirmedUTXOs(ctx, testAddressNotFound)
 	require.Error(t, err)
 	require.ErrorIs(t, err, ErrAddressNotFound)
 	assert.Nil(t, history)
@@ -1373,7 +1373,7 @@ func TestClient_AddressConfirmedUTXOs_EmptyResponse(t *testing.T) {
 
 	client := newMockClient(&mockHTTPAddressesNotFound{})
 	ctx := context.Background()
-	history, err := client.AddressConfirmedUTXOs(ctx, testMockNotFound)
+	history, err := client.AddressConfirmedUTXOs(ctx, testAddressNotFound)
 	require.Error(t, err)
 	require.ErrorIs(t, err, ErrAddressNotFound)
 	assert.Nil(t, history)
@@ -1385,7 +1385,7 @@ func TestClient_AddressUsed_EmptyResponse(t *testing.T) {
 
 	client := newMockClient(&mockHTTPAddressesNotFound{})
 	ctx := context.Background()
-	_, err := client.AddressUsed(ctx, testMockNotFound)
+	_, err := client.AddressUsed(ctx, testAddressNotFound)
 	require.Error(t, err)
 	require.ErrorIs(t, err, ErrAddressNotFound)
 }
@@ -1396,7 +1396,7 @@ func TestClient_AddressScripts_EmptyResponse(t *testing.T) {
 
 	client := newMockClient(&mockHTTPAddressesNotFound{})
 	ctx := context.Background()
-	scripts, err := client.AddressScripts(ctx, testMockNotFound)
+	scripts, err := client.AddressScripts(ctx, testAddressNotFound)
 	require.Error(t, err)
 	require.ErrorIs(t, err, ErrAddressNotFound)
 	assert.Nil(t, scripts)
@@ -1408,7 +1408,7 @@ func TestClient_BulkAddressUnconfirmedBalance_EmptyResponse(t *testing.T) {
 
 	client := newMockClient(&mockHTTPAddressesNotFound{})
 	ctx := context.Background()
-	balances, err := client.BulkAddressUnconfirmedBalance(ctx, &AddressList{Addresses: []string{testMockNotFound}})
+	balances, err := client.BulkAddressUnconfirmedBalance(ctx, &AddressList{Addresses: []string{testAddressNotFound}})
 	require.Error(t, err)
 	require.ErrorIs(t, err, ErrAddressNotFound)
 	assert.Nil(t, balances)
@@ -1420,7 +1420,7 @@ func TestClient_BulkAddressConfirmedBalance_EmptyResponse(t *testing.T) {
 
 	client := newMockClient(&mockHTTPAddressesNotFound{})
 	ctx := context.Background()
-	balances, err := client.BulkAddressConfirmedBalance(ctx, &AddressList{Addresses: []string{testMockNotFound}})
+	balances, err := client.BulkAddressConfirmedBalance(ctx, &AddressList{Addresses: []string{testAddressNotFound}})
 	require.Error(t, err)
 	require.ErrorIs(t, err, ErrAddressNotFound)
 	assert.Nil(t, balances)
@@ -1432,7 +1432,7 @@ func TestClient_BulkAddressUnconfirmedHistory_EmptyResponse(t *testing.T) {
 
 	client := newMockClient(&mockHTTPAddressesNotFound{})
 	ctx := context.Background()
-	history, err := client.BulkAddressUnconfirmedHistory(ctx, &AddressList{Addresses: []string{testMockNotFound}})
+	history, err := client.BulkAddressUnconfirmedHistory(ctx, &AddressList{Addresses: []string{testAddressNotFound}})
 	require.Error(t, err)
 	require.ErrorIs(t, err, ErrAddressNotFound)
 	assert.Nil(t, history)
@@ -1444,7 +1444,7 @@ func TestClient_BulkAddressConfirmedHistory_EmptyResponse(t *testing.T) {
 
 	client := newMockClient(&mockHTTPAddressesNotFound{})
 	ctx := context.Background()
-	history, err := client.BulkAddressConfirmedHistory(ctx, &AddressList{Addresses: []string{testMockNotFound}})
+	history, err := client.BulkAddressConfirmedHistory(ctx, &AddressList{Addresses: []string{testAddressNotFound}})
 	require.Error(t, err)
 	require.ErrorIs(t, err, ErrAddressNotFound)
 	assert.Nil(t, history)
@@ -1456,7 +1456,7 @@ func TestClient_BulkAddressHistory_EmptyResponse(t *testing.T) {
 
 	client := newMockClient(&mockHTTPAddressesNotFound{})
 	ctx := context.Background()
-	history, err := client.BulkAddressHistory(ctx, &AddressList{Addresses: []string{testMockNotFound}})
+	history, err := client.BulkAddressHistory(ctx, &AddressList{Addresses: []string{testAddressNotFound}})
 	require.Error(t, err)
 	require.ErrorIs(t, err, ErrAddressNotFound)
 	assert.Nil(t, history)
@@ -1468,7 +1468,7 @@ func TestClient_BulkAddressUnconfirmedUTXOs_EmptyResponse(t *testing.T) {
 
 	client := newMockClient(&mockHTTPAddressesNotFound{})
 	ctx := context.Background()
-	response, err := client.BulkAddressUnconfirmedUTXOs(ctx, &AddressList{Addresses: []string{testMockNotFound}})
+	response, err := client.BulkAddressUnconfirmedUTXOs(ctx, &AddressList{Addresses: []string{testAddressNotFound}})
 	require.Error(t, err)
 	require.ErrorIs(t, err, ErrAddressNotFound)
 	assert.Nil(t, response)
@@ -1480,7 +1480,7 @@ func TestClient_BulkAddressConfirmedUTXOs_EmptyResponse(t *testing.T) {
 
 	client := newMockClient(&mockHTTPAddressesNotFound{})
 	ctx := context.Background()
-	response, err := client.BulkAddressConfirmedUTXOs(ctx, &AddressList{Addresses: []string{testMockNotFound}})
+	response, err := client.BulkAddressConfirmedUTXOs(ctx, &AddressList{Addresses: []string{testAddressNotFound}})
 	require.Error(t, err)
 	require.ErrorIs(t, err, ErrAddressNotFound)
 	assert.Nil(t, response)
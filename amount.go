@@ -0,0 +1,260 @@
+package whatsonchain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Satoshis is an exact amount in satoshis (1 BSV/BTC = 100,000,000 satoshis).
+//
+// Unlike float64 BSV/BTC values, sums never drift. It unmarshals losslessly from the
+// API's decimal BSV/BTC values (numbers or strings) and marshals back to the same format.
+type Satoshis int64
+
+// AmountUnit is a denomination, expressed as the number of satoshis in one unit
+type AmountUnit int64
+
+const (
+	// UnitSatoshi is one satoshi
+	UnitSatoshi AmountUnit = 1
+
+	// UnitMilliBSV is one mBSV (0.001 BSV)
+	UnitMilliBSV AmountUnit = 100_000
+
+	// UnitBSV is one BSV
+	UnitBSV AmountUnit = 100_000_000
+
+	// UnitMilliBTC is one mBTC (0.001 BTC)
+	UnitMilliBTC = UnitMilliBSV
+
+	// UnitBTC is one BTC
+	UnitBTC = UnitBSV
+)
+
+// decimals returns the number of decimal places needed to show a satoshi in this unit (-1 if not a power of 10)
+func (u AmountUnit) decimals() int {
+	if u < 1 {
+		return -1
+	}
+	decimals := 0
+	for n := int64(u); n > 1; n /= 10 {
+		if n%10 != 0 {
+			return -1
+		}
+		decimals++
+	}
+	return decimals
+}
+
+// isDecimal returns true if amount only contains a sign, digits, a decimal point and an exponent
+// (big.Rat would also accept fractions like "1/3" and hexadecimal floats)
+func isDecimal(amount string) bool {
+	if amount == "" {
+		return false
+	}
+	for _, r := range amount {
+		if (r < '0' || r > '9') && !strings.ContainsRune("+-.eE", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseAmount parses a decimal amount in the given unit (e.g. "0.00012345" in UnitBSV) into satoshis.
+//
+// The decimal string is parsed exactly (exponents like "1e-08" are allowed). It returns
+// ErrInvalidAmount for malformed input or amounts finer than one satoshi, and
+// ErrAmountOverflow if the result does not fit in an int64.
+func ParseAmount(amount string, unit AmountUnit) (Satoshis, error) {
+	return parseAmount(amount, unit, false)
+}
+
+// parseAmount parses a decimal amount into satoshis, rounding to the nearest satoshi
+// (half away from zero) if round is true, or failing on sub-satoshi precision if not
+func parseAmount(amount string, unit AmountUnit, round bool) (Satoshis, error) {
+	if unit.decimals() < 0 {
+		return 0, fmt.Errorf("%w: unsupported unit %d", ErrInvalidAmount, unit)
+	}
+
+	amount = strings.TrimSpace(amount)
+	if !isDecimal(amount) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+	value, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+
+	value.Mul(value, new(big.Rat).SetInt64(int64(unit)))
+	if !value.IsInt() && !round {
+		return 0, fmt.Errorf("%w: %q is finer than one satoshi", ErrInvalidAmount, amount)
+	}
+
+	sats, rem := new(big.Int).QuoRem(value.Num(), value.Denom(), new(big.Int))
+	if twice := new(big.Int).Lsh(new(big.Int).Abs(rem), 1); twice.Cmp(value.Denom()) >= 0 {
+		sats.Add(sats, big.NewInt(int64(rem.Sign())))
+	}
+	if !sats.IsInt64() {
+		return 0, fmt.Errorf("%w: %q", ErrAmountOverflow, amount)
+	}
+	return Satoshis(sats.Int64()), nil
+}
+
+// ParseBSV parses a decimal BSV amount (e.g. "0.00012345") into satoshis
+func ParseBSV(amount string) (Satoshis, error) {
+	return ParseAmount(amount, UnitBSV)
+}
+
+// ParseBTC parses a decimal BTC amount (e.g. "0.00012345") into satoshis
+func ParseBTC(amount string) (Satoshis, error) {
+	return ParseAmount(amount, UnitBTC)
+}
+
+// Format returns the exact amount as a decimal string in the given unit,
+// with every decimal place shown (e.g. "1.00000000" for 100,000,000 satoshis in UnitBSV)
+func (s Satoshis) Format(unit AmountUnit) string {
+	decimals := unit.decimals()
+	if decimals < 0 {
+		return strconv.FormatInt(int64(s), 10)
+	}
+
+	sign := ""
+	abs := uint64(s) //nolint:gosec // two's complement gives the magnitude of math.MinInt64
+	if s < 0 {
+		sign = "-"
+		abs = -abs
+	}
+	if decimals == 0 {
+		return sign + strconv.FormatUint(abs, 10)
+	}
+
+	whole := abs / uint64(unit) //nolint:gosec // unit is positive (see decimals)
+	frac := abs % uint64(unit)  //nolint:gosec // unit is positive (see decimals)
+	return fmt.Sprintf("%s%d.%0*d", sign, whole, decimals, frac)
+}
+
+// BSV returns the amount in BSV with 8 decimal places (e.g. "0.00012345")
+func (s Satoshis) BSV() string {
+	return s.Format(UnitBSV)
+}
+
+// BTC returns the amount in BTC with 8 decimal places (e.g. "0.00012345")
+func (s Satoshis) BTC() string {
+	return s.Format(UnitBTC)
+}
+
+// MilliBSV returns the amount in mBSV with 5 decimal places (e.g. "0.12345")
+func (s Satoshis) MilliBSV() string {
+	return s.Format(UnitMilliBSV)
+}
+
+// Add returns s + other, or ErrAmountOverflow if the result does not fit in an int64
+func (s Satoshis) Add(other Satoshis) (Satoshis, error) {
+	if (other > 0 && s > math.MaxInt64-other) || (other < 0 && s < math.MinInt64-other) {
+		return 0, fmt.Errorf("%w: %d + %d", ErrAmountOverflow, s, other)
+	}
+	return s + other, nil
+}
+
+// Sub returns s - other, or ErrAmountOverflow if the result does not fit in an int64
+func (s Satoshis) Sub(other Satoshis) (Satoshis, error) {
+	if (other < 0 && s > math.MaxInt64+other) || (other > 0 && s < math.MinInt64+other) {
+		return 0, fmt.Errorf("%w: %d - %d", ErrAmountOverflow, s, other)
+	}
+	return s - other, nil
+}
+
+// Mul returns s * n, or ErrAmountOverflow if the result does not fit in an int64
+func (s Satoshis) Mul(n int64) (Satoshis, error) {
+	if s == 0 || n == 0 {
+		return 0, nil
+	}
+	result := int64(s) * n
+	if result/n != int64(s) || (s == -1 && n == math.MinInt64) || (n == -1 && s == math.MinInt64) {
+		return 0, fmt.Errorf("%w: %d * %d", ErrAmountOverflow, s, n)
+	}
+	return Satoshis(result), nil
+}
+
+// SumSatoshis adds up the amounts, or returns ErrAmountOverflow if the total does not fit in an int64
+func SumSatoshis(amounts ...Satoshis) (total Satoshis, err error) {
+	for _, amount := range amounts {
+		if total, err = total.Add(amount); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+// UnmarshalJSON parses a decimal BSV/BTC value (a JSON number or string) into satoshis.
+// The API sometimes sends floating-point noise past the 8th decimal (e.g. 0.013105480000000114),
+// so the exact decimal is rounded to the nearest satoshi.
+func (s *Satoshis) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+		data = []byte(str)
+	}
+
+	amount, err := parseAmount(string(data), UnitBSV, true)
+	if err != nil {
+		return err
+	}
+	*s = amount
+	return nil
+}
+
+// MarshalJSON returns the amount as a decimal BSV/BTC JSON number (e.g. 0.00012345)
+func (s Satoshis) MarshalJSON() ([]byte, error) {
+	return []byte(s.BSV()), nil
+}
+
+// UnmarshalJSON decodes a VoutInfo and sets ValueSatoshis from the exact "value"
+func (v *VoutInfo) UnmarshalJSON(data []byte) error {
+	type voutInfo VoutInfo // prevents recursion
+	aux := struct {
+		*voutInfo
+
+		Value json.RawMessage `json:"value"`
+	}{voutInfo: (*voutInfo)(v)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	return unmarshalAmount(aux.Value, &v.Value, &v.ValueSatoshis)
+}
+
+// UnmarshalJSON decodes a BlockInfo and sets TotalFeesSatoshis from the exact "totalFees"
+func (b *BlockInfo) UnmarshalJSON(data []byte) error {
+	type blockInfo BlockInfo // prevents recursion
+	aux := struct {
+		*blockInfo
+
+		TotalFees json.RawMessage `json:"totalFees"`
+	}{blockInfo: (*blockInfo)(b)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	return unmarshalAmount(aux.TotalFees, &b.TotalFees, &b.TotalFeesSatoshis)
+}
+
+// unmarshalAmount decodes a raw BSV/BTC JSON value into both its float64 and exact Satoshis fields
+func unmarshalAmount(raw json.RawMessage, value *float64, sats *Satoshis) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(raw, value); err != nil {
+		return err
+	}
+	return sats.UnmarshalJSON(raw)
+}
@@ -0,0 +1,209 @@
+package whatsonchain
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseAmount tests exact decimal parsing
+func TestParseAmount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		unit     AmountUnit
+		expected Satoshis
+		err      error
+	}{
+		{"0", UnitBSV, 0, nil},
+		{"1", UnitBSV, 100_000_000, nil},
+		{"0.00000001", UnitBSV, 1, nil},
+		{"1e-08", UnitBSV, 1, nil},
+		{" 12.34567891 ", UnitBSV, 1_234_567_891, nil},
+		{"-0.5", UnitBTC, -50_000_000, nil},
+		{"21000000", UnitBSV, 2_100_000_000_000_000, nil},
+		{"1.23456", UnitMilliBSV, 123_456, nil},
+		{"42", UnitSatoshi, 42, nil},
+		{"92233720368.54775807", UnitBSV, math.MaxInt64, nil},
+		{"0.000000001", UnitBSV, 0, ErrInvalidAmount},
+		{"1.5", UnitSatoshi, 0, ErrInvalidAmount},
+		{"", UnitBSV, 0, ErrInvalidAmount},
+		{"abc", UnitBSV, 0, ErrInvalidAmount},
+		{"1/2", UnitBSV, 0, ErrInvalidAmount},
+		{"0x10", UnitBSV, 0, ErrInvalidAmount},
+		{"1", AmountUnit(3), 0, ErrInvalidAmount},
+		{"92233720368.54775808", UnitBSV, 0, ErrAmountOverflow},
+	}
+
+	for _, test := range tests {
+		amount, err := ParseAmount(test.input, test.unit)
+		if test.err != nil {
+			require.ErrorIs(t, err, test.err, test.input)
+			continue
+		}
+		require.NoError(t, err, test.input)
+		assert.Equal(t, test.expected, amount, test.input)
+	}
+
+	amount, err := ParseBSV("0.1")
+	require.NoError(t, err)
+	assert.Equal(t, Satoshis(10_000_000), amount)
+	amount, err = ParseBTC("0.2")
+	require.NoError(t, err)
+	assert.Equal(t, Satoshis(20_000_000), amount)
+}
+
+// TestSatoshis_Format tests the unit formatting helpers
+func TestSatoshis_Format(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "0.00000000", Satoshis(0).BSV())
+	assert.Equal(t, "0.00000001", Satoshis(1).BSV())
+	assert.Equal(t, "1.23456789", Satoshis(123_456_789).BTC())
+	assert.Equal(t, "-0.50000000", Satoshis(-50_000_000).BSV())
+	assert.Equal(t, "1234.56789", Satoshis(123_456_789).MilliBSV())
+	assert.Equal(t, "42", Satoshis(42).Format(UnitSatoshi))
+	assert.Equal(t, "42", Satoshis(42).Format(AmountUnit(3)))
+	assert.Equal(t, "-92233720368.54775808", Satoshis(math.MinInt64).BSV())
+
+	// Formatting round-trips through parsing
+	for _, sats := range []Satoshis{0, 1, -1, 99_999_999, math.MaxInt64, math.MinInt64} {
+		parsed, err := ParseBSV(sats.BSV())
+		require.NoError(t, err)
+		assert.Equal(t, sats, parsed)
+	}
+}
+
+// TestSatoshis_Arithmetic tests the overflow-checked arithmetic
+func TestSatoshis_Arithmetic(t *testing.T) {
+	t.Parallel()
+
+	sum, err := Satoshis(1).Add(2)
+	require.NoError(t, err)
+	assert.Equal(t, Satoshis(3), sum)
+	_, err = Satoshis(math.MaxInt64).Add(1)
+	require.ErrorIs(t, err, ErrAmountOverflow)
+	_, err = Satoshis(math.MinInt64).Add(-1)
+	require.ErrorIs(t, err, ErrAmountOverflow)
+
+	diff, err := Satoshis(1).Sub(3)
+	require.NoError(t, err)
+	assert.Equal(t, Satoshis(-2), diff)
+	_, err = Satoshis(math.MinInt64).Sub(1)
+	require.ErrorIs(t, err, ErrAmountOverflow)
+	_, err = Satoshis(math.MaxInt64).Sub(-1)
+	require.ErrorIs(t, err, ErrAmountOverflow)
+
+	product, err := Satoshis(-3).Mul(4)
+	require.NoError(t, err)
+	assert.Equal(t, Satoshis(-12), product)
+	product, err = Satoshis(0).Mul(math.MaxInt64)
+	require.NoError(t, err)
+	assert.Equal(t, Satoshis(0), product)
+	_, err = Satoshis(math.MaxInt64 / 2).Mul(3)
+	require.ErrorIs(t, err, ErrAmountOverflow)
+	_, err = Satoshis(-1).Mul(math.MinInt64)
+	require.ErrorIs(t, err, ErrAmountOverflow)
+	_, err = Satoshis(math.MinInt64).Mul(-1)
+	require.ErrorIs(t, err, ErrAmountOverflow)
+
+	total, err := SumSatoshis(1, 2, 3)
+	require.NoError(t, err)
+	assert.Equal(t, Satoshis(6), total)
+	_, err = SumSatoshis(math.MaxInt64, 1, -5)
+	require.ErrorIs(t, err, ErrAmountOverflow)
+}
+
+// TestSatoshis_JSON tests lossless JSON decoding and encoding
+func TestSatoshis_JSON(t *testing.T) {
+	t.Parallel()
+
+	var amounts struct {
+		Number Satoshis  `json:"number"`
+		Noisy  Satoshis  `json:"noisy"`
+		String Satoshis  `json:"string"`
+		Null   Satoshis  `json:"null"`
+		Ptr    *Satoshis `json:"ptr"`
+	}
+	err := json.Unmarshal([]byte(`{"number":0.1,"noisy":0.013105480000000114,"string":"12.5","null":null,"ptr":1e-8}`), &amounts)
+	require.NoError(t, err)
+	assert.Equal(t, Satoshis(10_000_000), amounts.Number)
+	assert.Equal(t, Satoshis(1_310_548), amounts.Noisy)
+	assert.Equal(t, Satoshis(1_250_000_000), amounts.String)
+	assert.Equal(t, Satoshis(0), amounts.Null)
+	require.NotNil(t, amounts.Ptr)
+	assert.Equal(t, Satoshis(1), *amounts.Ptr)
+
+	data, err := json.Marshal(Satoshis(123_456_789))
+	require.NoError(t, err)
+	assert.JSONEq(t, `1.23456789`, string(data))
+
+	var bad Satoshis
+	require.ErrorIs(t, json.Unmarshal([]byte(`"abc"`), &bad), ErrInvalidAmount)
+	require.Error(t, json.Unmarshal([]byte(`"abc`), &bad))
+}
+
+// TestVoutInfo_ValueSatoshis tests that summing outputs in satoshis does not drift
+func TestVoutInfo_ValueSatoshis(t *testing.T) {
+	t.Parallel()
+
+	var tx TxInfo
+	err := json.Unmarshal([]byte(`{"txid":"abc","vout":[{"n":0,"value":0.1},{"n":1,"value":0.2},{"n":2,"value":0.00000001}]}`), &tx)
+	require.NoError(t, err)
+	require.Len(t, tx.Vout, 3)
+	assert.Equal(t, "abc", tx.TxID)
+	assert.Equal(t, int64(1), tx.Vout[1].N)
+	assert.InDelta(t, 0.2, tx.Vout[1].Value, 0)
+
+	var total Satoshis
+	var floatTotal float64
+	for _, vout := range tx.Vout {
+		total, err = total.Add(vout.ValueSatoshis)
+		require.NoError(t, err)
+		floatTotal += vout.Value
+	}
+	assert.Equal(t, Satoshis(30_000_001), total)
+	assert.NotEqual(t, 0.30000001, floatTotal) //nolint:testifylint // demonstrates the float64 drift
+
+	// Bad values are reported
+	require.Error(t, json.Unmarshal([]byte(`{"value":"abc"}`), &VoutInfo{}))
+	require.Error(t, json.Unmarshal([]byte(`{"n":"abc"}`), &VoutInfo{}))
+}
+
+// TestBlockInfo_TotalFeesSatoshis tests the exact block fees
+func TestBlockInfo_TotalFeesSatoshis(t *testing.T) {
+	t.Parallel()
+
+	var block BlockInfo
+	err := json.Unmarshal([]byte(`{"hash":"`+testBlockHash1+`","height":5,"totalFees":0.013105480000000114,"coinbaseTx":{"vout":[{"value":6.25}]}}`), &block)
+	require.NoError(t, err)
+	assert.Equal(t, testBlockHash1, block.Hash)
+	assert.Equal(t, int64(5), block.Height)
+	assert.Equal(t, Satoshis(1_310_548), block.TotalFeesSatoshis)
+	assert.InDelta(t, 0.013105480000000114, block.TotalFees, 0)
+	assert.Equal(t, Satoshis(625_000_000), block.CoinbaseTx.Vout[0].ValueSatoshis)
+
+	// Headers have no fees
+	block = BlockInfo{}
+	require.NoError(t, json.Unmarshal([]byte(`{"hash":"`+testBlockHash1+`"}`), &block))
+	assert.Equal(t, Satoshis(0), block.TotalFeesSatoshis)
+}
+
+// TestClient_GetCirculatingSupplySatoshis tests the GetCirculatingSupplySatoshis()
+func TestClient_GetCirculatingSupplySatoshis(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(&mockHTTPChainValid{})
+	supply, err := client.GetCirculatingSupplySatoshis(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Satoshis(1_844_065_000_000_000), supply)
+
+	client = newMockClient(&mockHTTPChainInvalid{})
+	_, err = client.GetCirculatingSupplySatoshis(context.Background())
+	require.Error(t, err)
+}
@@ -0,0 +1,176 @@
+package whatsonchain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// APIError is returned when the WhatsOnChain API responds with an unsuccessful HTTP status code.
+//
+// It satisfies errors.Is(err, ErrRequestFailed), so existing checks keep working, and
+// can be inspected with errors.As or the IsRateLimited/IsNotFound/IsServerError helpers.
+type APIError struct {
+	Attempts   int           `json:"attempts"`    // number of attempts made (including retries)
+	Body       []byte        `json:"body"`        // raw response body
+	Message    string        `json:"message"`     // parsed WhatsOnChain error message (if any)
+	Method     string        `json:"method"`      // HTTP method used
+	RetryAfter time.Duration `json:"retry_after"` // parsed Retry-After header (0 if not present)
+	StatusCode int           `json:"status_code"` // HTTP status code returned
+	URL        string        `json:"url"`         // URL requested
+}
+
+// Error returns the error message, e.g. "API request failed: HTTP 429: rate limit exceeded"
+func (e *APIError) Error() string {
+	if len(e.Body) == 0 {
+		return fmt.Sprintf("%s: HTTP %d", ErrRequestFailed, e.StatusCode)
+	}
+	return fmt.Sprintf("%s: HTTP %d: %s", ErrRequestFailed, e.StatusCode, string(e.Body))
+}
+
+// Unwrap returns ErrRequestFailed so errors.Is(err, ErrRequestFailed) is true
+func (e *APIError) Unwrap() error {
+	return ErrRequestFailed
+}
+
+// IsRateLimited returns true if err is an *APIError with HTTP 429 (Too Many Requests)
+func IsRateLimited(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests
+}
+
+// IsNotFound returns true if err is an *APIError with HTTP 404, or any of the
+// package's "not found" sentinel errors (ErrTransactionNotFound, ErrAddressNotFound, etc.)
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return true
+	}
+	for _, notFound := range notFoundErrors {
+		if errors.Is(err, notFound) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsServerError returns true if err is an *APIError with a 5xx status code
+func IsServerError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= http.StatusInternalServerError
+}
+
+// notFoundErrors are the sentinel errors that IsNotFound treats as "not found"
+var notFoundErrors = []error{ //nolint:gochecknoglobals // read-only lookup table
+	ErrAddressNotFound,
+	ErrBlockNotFound,
+	ErrChainInfoNotFound,
+	ErrChainTipsNotFound,
+	ErrExchangeRateNotFound,
+	ErrHeadersNotFound,
+	ErrMempoolInfoNotFound,
+	ErrPeerInfoNotFound,
+	ErrScriptNotFound,
+	ErrStatsNotFound,
+	ErrTokenNotFound,
+	ErrTransactionNotFound,
+}
+
+// newAPIError builds an *APIError from a completed request
+func newAPIError(method, url string, statusCode int, body []byte, header http.Header, attempts int) *APIError {
+	if attempts < 1 {
+		attempts = 1
+	}
+	return &APIError{
+		Attempts:   attempts,
+		Body:       body,
+		Message:    parseErrorMessage(body),
+		Method:     method,
+		RetryAfter: parseRetryAfter(header.Get("Retry-After"), time.Now()),
+		StatusCode: statusCode,
+		URL:        url,
+	}
+}
+
+// parseErrorMessage extracts the error message from a WhatsOnChain error body.
+// The API returns either JSON ({"error":"..."} or {"message":"..."}) or plain text.
+func parseErrorMessage(body []byte) string {
+	trimmed := strings.TrimSpace(string(body))
+	if trimmed == "" {
+		return ""
+	}
+
+	var resp struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(trimmed), &resp); err == nil {
+		if resp.Error != "" {
+			return resp.Error
+		}
+		if resp.Message != "" {
+			return resp.Message
+		}
+	}
+
+	// Quoted JSON string (e.g. "unknown-error")
+	var msg string
+	if err := json.Unmarshal([]byte(trimmed), &msg); err == nil {
+		return msg
+	}
+
+	return trimmed
+}
+
+// parseRetryAfter parses a Retry-After header value in either delay-seconds or HTTP-date form.
+// Returns 0 if the value is empty, invalid or in the past.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	// Delay in seconds
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	// HTTP-date
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := date.Sub(now); delay > 0 {
+			return delay
+		}
+	}
+
+	return 0
+}
+
+// requestTraceKey is the context key for the requestTrace
+type requestTraceKey struct{}
+
+// requestTrace collects response metadata for a single Client.request call while it
+// passes through the middleware chain and HTTP client (e.g. retry attempts and headers)
+type requestTrace struct {
+	attempts int          // number of attempts made by the HTTP client
+	header   http.Header  // headers from the final response
+	limiter  *rateLimiter // the client's rate limiter, waited on before every retry
+}
+
+// withRequestTrace returns a copy of ctx carrying trace
+func withRequestTrace(ctx context.Context, trace *requestTrace) context.Context {
+	return context.WithValue(ctx, requestTraceKey{}, trace)
+}
+
+// requestTraceFromContext returns the requestTrace carried by ctx (or nil)
+func requestTraceFromContext(ctx context.Context) *requestTrace {
+	trace, _ := ctx.Value(requestTraceKey{}).(*requestTrace)
+	return trace
+}
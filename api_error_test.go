@@ -0,0 +1,229 @@
+package whatsonchain
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockHTTPStatusHeader returns a fixed status code, body and header
+type mockHTTPStatusHeader struct {
+	body       string
+	header     http.Header
+	statusCode int
+}
+
+// Do is a mock http request
+func (m *mockHTTPStatusHeader) Do(_ *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: m.statusCode,
+		Header:     m.header,
+		Body:       io.NopCloser(strings.NewReader(m.body)),
+	}, nil
+}
+
+// TestAPIError_Error tests the error message
+func TestAPIError_Error(t *testing.T) {
+	t.Parallel()
+
+	err := &APIError{StatusCode: http.StatusUnauthorized, Body: []byte("Unauthorized")}
+	assert.Equal(t, "API request failed: HTTP 401: Unauthorized", err.Error())
+
+	err = &APIError{StatusCode: http.StatusForbidden}
+	assert.Equal(t, "API request failed: HTTP 403", err.Error())
+}
+
+// TestAPIError_Is tests that APIError still satisfies errors.Is(err, ErrRequestFailed)
+func TestAPIError_Is(t *testing.T) {
+	t.Parallel()
+
+	var err error = &APIError{StatusCode: http.StatusBadGateway}
+	require.ErrorIs(t, err, ErrRequestFailed)
+
+	wrapped := fmt.Errorf("%w: %w", ErrBroadcastFailed, err)
+	require.ErrorIs(t, wrapped, ErrRequestFailed)
+	require.ErrorIs(t, wrapped, ErrBroadcastFailed)
+
+	var apiErr *APIError
+	require.ErrorAs(t, wrapped, &apiErr)
+	assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+}
+
+// TestIsRateLimited tests the IsRateLimited helper
+func TestIsRateLimited(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsRateLimited(&APIError{StatusCode: http.StatusTooManyRequests}))
+	assert.True(t, IsRateLimited(fmt.Errorf("wrapped: %w", &APIError{StatusCode: http.StatusTooManyRequests})))
+	assert.False(t, IsRateLimited(&APIError{StatusCode: http.StatusInternalServerError}))
+	assert.False(t, IsRateLimited(ErrRequestFailed))
+	assert.False(t, IsRateLimited(nil))
+}
+
+// TestIsNotFound tests the IsNotFound helper
+func TestIsNotFound(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsNotFound(&APIError{StatusCode: http.StatusNotFound}))
+	assert.True(t, IsNotFound(ErrTransactionNotFound))
+	assert.True(t, IsNotFound(fmt.Errorf("wrapped: %w", ErrAddressNotFound)))
+	assert.True(t, IsNotFound(ErrBlockNotFound))
+	assert.False(t, IsNotFound(&APIError{StatusCode: http.StatusBadRequest}))
+	assert.False(t, IsNotFound(ErrMissingRequest))
+	assert.False(t, IsNotFound(nil))
+}
+
+// TestIsServerError tests the IsServerError helper
+func TestIsServerError(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsServerError(&APIError{StatusCode: http.StatusInternalServerError}))
+	assert.True(t, IsServerError(&APIError{StatusCode: http.StatusServiceUnavailable}))
+	assert.False(t, IsServerError(&APIError{StatusCode: http.StatusTooManyRequests}))
+	assert.False(t, IsServerError(errNetworkError))
+}
+
+// TestParseErrorMessage tests parsing error messages from WhatsOnChain error bodies
+func TestParseErrorMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"whitespace", "  \n", ""},
+		{"plain text", "Rate limit exceeded\n", "Rate limit exceeded"},
+		{"json error", `{"error":"invalid address"}`, "invalid address"},
+		{"json message", `{"message":"unknown"}`, "unknown"},
+		{"json error wins", `{"error":"e","message":"m"}`, "e"},
+		{"json string", `"unknown-error"`, "unknown-error"},
+		{"json without fields", `{"code":1}`, `{"code":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseErrorMessage([]byte(tt.body)))
+		})
+	}
+}
+
+// TestParseRetryAfter tests parsing the Retry-After header
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+	}{
+		{"empty", "", 0},
+		{"seconds", "120", 120 * time.Second},
+		{"seconds with spaces", " 5 ", 5 * time.Second},
+		{"zero seconds", "0", 0},
+		{"negative seconds", "-3", 0},
+		{"http date", now.Add(30 * time.Second).Format(http.TimeFormat), 30 * time.Second},
+		{"http date in past", now.Add(-time.Minute).Format(http.TimeFormat), 0},
+		{"garbage", "soon", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseRetryAfter(tt.value, now))
+		})
+	}
+}
+
+// TestClient_APIError tests that endpoints return a populated *APIError
+func TestClient_APIError(t *testing.T) {
+	t.Parallel()
+
+	header := http.Header{}
+	header.Set("Retry-After", "7")
+	client := newMockClient(&mockHTTPStatusHeader{
+		statusCode: http.StatusTooManyRequests,
+		body:       `{"error":"too many requests"}`,
+		header:     header,
+	})
+
+	_, err := client.GetMempoolInfo(context.Background())
+	require.Error(t, err)
+	require.ErrorIs(t, err, ErrRequestFailed)
+	assert.True(t, IsRateLimited(err))
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+	assert.Equal(t, http.MethodGet, apiErr.Method)
+	assert.Contains(t, apiErr.URL, "/mempool/info")
+	assert.JSONEq(t, `{"error":"too many requests"}`, string(apiErr.Body))
+	assert.Equal(t, "too many requests", apiErr.Message)
+	assert.Equal(t, 7*time.Second, apiErr.RetryAfter)
+	assert.Equal(t, 1, apiErr.Attempts)
+}
+
+// TestClient_APIError_Broadcast tests that broadcast errors wrap the *APIError
+func TestClient_APIError_Broadcast(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(&mockHTTPStatusHeader{statusCode: http.StatusBadRequest, body: `mandatory-script-verify-flag-failed`})
+
+	_, err := client.BroadcastTx(context.Background(), "0100")
+	require.ErrorIs(t, err, ErrBroadcastFailed)
+	require.ErrorIs(t, err, ErrRequestFailed)
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.MethodPost, apiErr.Method)
+	assert.Equal(t, "mandatory-script-verify-flag-failed", apiErr.Message)
+}
+
+// TestClient_APIError_Attempts tests that retry attempts are reported on the *APIError
+func TestClient_APIError_Attempts(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	transport := roundTripperFunc(func(_ *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: io.NopCloser(strings.NewReader("down"))}, nil
+	})
+	retryable := NewRetryableHTTPClient(
+		&http.Client{Transport: transport},
+		2,
+		NewExponentialBackoff(time.Millisecond, 2*time.Millisecond, 2, 0),
+	)
+
+	client, err := NewClient(context.Background(), WithHTTPClient(retryable))
+	require.NoError(t, err)
+
+	_, err = client.GetChainInfo(context.Background())
+	require.Error(t, err)
+	assert.True(t, IsServerError(err))
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, 3, apiErr.Attempts)
+	assert.Equal(t, int64(3), calls.Load())
+}
+
+// TestRequestTraceFromContext tests reading the request trace from a context
+func TestRequestTraceFromContext(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, requestTraceFromContext(context.Background()))
+
+	trace := &requestTrace{attempts: 2}
+	ctx := withRequestTrace(context.Background(), trace)
+	assert.Same(t, trace, requestTraceFromContext(ctx))
+}
@@ -0,0 +1,250 @@
+package whatsonchain
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// beefVersion is the BRC-62 version marker (serialized as 0100BEEF)
+const beefVersion uint32 = 0xEFBE0001
+
+// BEEF is a Background Evaluation Extended Format (BRC-62) transaction envelope: a transaction
+// with every unconfirmed ancestor and the BUMPs proving the confirmed ones, so the receiver
+// can verify it with block headers alone (SPV).
+//
+// Transactions are ordered parents before children; the last one is the subject transaction.
+type BEEF struct {
+	BUMPs        []*MerklePath      `json:"bumps"`
+	Transactions []*BEEFTransaction `json:"transactions"`
+}
+
+// BEEFTransaction is a transaction in a BEEF, with the index of the BUMP proving it (if HasBUMP)
+type BEEFTransaction struct {
+	BUMPIndex   int          `json:"bumpIndex"`
+	HasBUMP     bool         `json:"hasBump"`
+	Transaction *Transaction `json:"transaction"`
+}
+
+// ParseBEEF parses a BEEF from its binary format. It returns ErrInvalidBEEF if the bytes are
+// truncated, a BUMP index is out of range, a proven transaction is missing from its BUMP or an
+// unproven transaction spends an output of a transaction that is not included before it.
+func ParseBEEF(raw []byte) (*BEEF, error) {
+	r := &txReader{data: raw, invalid: ErrInvalidBEEF}
+	if version := r.uint32(); r.err == nil && version != beefVersion {
+		r.fail(fmt.Sprintf("unsupported version %x", binary.LittleEndian.AppendUint32(nil, version)))
+	}
+
+	beef := &BEEF{}
+	bumps := r.count(2) // block height and tree height
+	for i := uint64(0); i < bumps && r.err == nil; i++ {
+		beef.BUMPs = append(beef.BUMPs, readMerklePath(r))
+	}
+
+	txs := r.count(4 + 1 + 1 + 4 + 1) // an empty transaction and its BUMP flag
+	for i := uint64(0); i < txs && r.err == nil; i++ {
+		tx := &BEEFTransaction{Transaction: readTransaction(r)}
+		if hasBUMP := r.bytes(1); hasBUMP != nil {
+			switch hasBUMP[0] {
+			case 0x00:
+			case 0x01:
+				tx.HasBUMP = true
+				tx.BUMPIndex = int(r.varInt()) //nolint:gosec // checked by validate
+			default:
+				r.fail(fmt.Sprintf("invalid BUMP flag 0x%02x", hasBUMP[0]))
+			}
+		}
+		beef.Transactions = append(beef.Transactions, tx)
+	}
+
+	if r.err == nil && r.remaining() > 0 {
+		r.fail(fmt.Sprintf("%d trailing bytes", r.remaining()))
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	if err := beef.validate(); err != nil {
+		return nil, err
+	}
+	return beef, nil
+}
+
+// ParseBEEFHex parses a BEEF from hex
+func ParseBEEFHex(beefHex string) (*BEEF, error) {
+	raw, err := hex.DecodeString(strings.TrimSpace(beefHex))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBEEF, err)
+	}
+	return ParseBEEF(raw)
+}
+
+// Bytes returns the BEEF binary format, or ErrInvalidBEEF if the envelope is inconsistent
+func (b *BEEF) Bytes() ([]byte, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(binary.LittleEndian.AppendUint32(nil, beefVersion))
+	writeVarInt(&buf, uint64(len(b.BUMPs)))
+	for _, bump := range b.BUMPs {
+		raw, err := bump.Bytes()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidBEEF, err)
+		}
+		buf.Write(raw)
+	}
+	writeVarInt(&buf, uint64(len(b.Transactions)))
+	for _, tx := range b.Transactions {
+		buf.Write(tx.Transaction.Bytes())
+		if !tx.HasBUMP {
+			buf.WriteByte(0x00)
+			continue
+		}
+		buf.WriteByte(0x01)
+		writeVarInt(&buf, uint64(tx.BUMPIndex)) //nolint:gosec // checked by validate
+	}
+	return buf.Bytes(), nil
+}
+
+// Hex returns the BEEF binary format as hex
+func (b *BEEF) Hex() (string, error) {
+	raw, err := b.Bytes()
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
+
+// Subject returns the transaction the BEEF was built for (the last one), or nil if it is empty
+func (b *BEEF) Subject() *Transaction {
+	if len(b.Transactions) == 0 {
+		return nil
+	}
+	return b.Transactions[len(b.Transactions)-1].Transaction
+}
+
+// FindTransaction returns the transaction with the txid, or nil if it is not in the BEEF
+func (b *BEEF) FindTransaction(txid string) *BEEFTransaction {
+	for _, tx := range b.Transactions {
+		if strings.EqualFold(tx.Transaction.TxID(), txid) {
+			return tx
+		}
+	}
+	return nil
+}
+
+// validate checks the BUMP references and that every unproven transaction's parents come before it
+func (b *BEEF) validate() error {
+	if len(b.Transactions) == 0 {
+		return fmt.Errorf("%w: no transactions", ErrInvalidBEEF)
+	}
+	for i, bump := range b.BUMPs {
+		if err := bump.validate(); err != nil {
+			return fmt.Errorf("%w: BUMP %d: %w", ErrInvalidBEEF, i, err)
+		}
+	}
+
+	seen := make(map[string]bool, len(b.Transactions))
+	for i, tx := range b.Transactions {
+		if tx == nil || tx.Transaction == nil {
+			return fmt.Errorf("%w: missing transaction %d", ErrInvalidBEEF, i)
+		}
+		txid := tx.Transaction.TxID()
+		switch {
+		case tx.HasBUMP && (tx.BUMPIndex < 0 || tx.BUMPIndex >= len(b.BUMPs)):
+			return fmt.Errorf("%w: transaction %s has BUMP index %d of %d", ErrInvalidBEEF, txid, tx.BUMPIndex, len(b.BUMPs))
+		case tx.HasBUMP && b.BUMPs[tx.BUMPIndex].findLeaf(txid) == nil:
+			return fmt.Errorf("%w: transaction %s is not in BUMP %d", ErrInvalidBEEF, txid, tx.BUMPIndex)
+		case !tx.HasBUMP:
+			for _, input := range tx.Transaction.Inputs {
+				if !seen[input.PrevTxID] {
+					return fmt.Errorf("%w: transaction %s spends %s, which is not proven or included before it", ErrInvalidBEEF, txid, input.PrevTxID)
+				}
+			}
+		}
+		seen[txid] = true
+	}
+	return nil
+}
+
+// BuildBEEF assembles a BEEF for the transaction: its raw data (via GetRawTransactionData) and
+// BUMP (via GetMerklePath) if it is confirmed, otherwise the same for each of its parents,
+// recursively, until every branch ends in a confirmed transaction.
+// BUMPs of transactions in the same block are merged into one compound BUMP.
+func (c *Client) BuildBEEF(ctx context.Context, txid string) (*BEEF, error) {
+	builder := &beefBuilder{
+		beef:   &BEEF{},
+		bumps:  make(map[uint64]int),
+		client: c,
+		added:  make(map[string]bool),
+	}
+	if err := builder.add(ctx, strings.ToLower(txid)); err != nil {
+		return nil, err
+	}
+	return builder.beef, nil
+}
+
+// beefBuilder collects the transactions and BUMPs of a BEEF
+type beefBuilder struct {
+	added  map[string]bool // txids already added (or being added)
+	beef   *BEEF
+	bumps  map[uint64]int // block height -> index in beef.BUMPs
+	client *Client
+}
+
+// add adds the transaction after its unconfirmed ancestors
+func (b *beefBuilder) add(ctx context.Context, txid string) error {
+	if b.added[txid] {
+		return nil
+	}
+	b.added[txid] = true
+
+	rawHex, err := b.client.GetRawTransactionData(ctx, txid)
+	if err != nil {
+		return err
+	}
+	tx, err := ParseTransactionHex(rawHex)
+	if err != nil {
+		return err
+	}
+	if tx.TxID() != txid {
+		return fmt.Errorf("%w: raw data for %s has txid %s", ErrInvalidTransaction, txid, tx.TxID())
+	}
+
+	path, err := b.client.GetMerklePath(ctx, txid)
+	switch {
+	case err == nil:
+		index, mergeErr := b.addPath(path)
+		if mergeErr != nil {
+			return mergeErr
+		}
+		b.beef.Transactions = append(b.beef.Transactions, &BEEFTransaction{BUMPIndex: index, HasBUMP: true, Transaction: tx})
+		return nil
+	case !errors.Is(err, ErrTransactionNotFound):
+		return err
+	}
+
+	// Unconfirmed: the parents must come first
+	for _, input := range tx.Inputs {
+		if err = b.add(ctx, input.PrevTxID); err != nil {
+			return err
+		}
+	}
+	b.beef.Transactions = append(b.beef.Transactions, &BEEFTransaction{Transaction: tx})
+	return nil
+}
+
+// addPath adds a BUMP, merging it with an existing one for the same block
+func (b *beefBuilder) addPath(path *MerklePath) (int, error) {
+	if index, ok := b.bumps[path.BlockHeight]; ok {
+		return index, b.beef.BUMPs[index].Merge(path)
+	}
+	b.beef.BUMPs = append(b.beef.BUMPs, path)
+	b.bumps[path.BlockHeight] = len(b.beef.BUMPs) - 1
+	return len(b.beef.BUMPs) - 1, nil
+}
@@ -0,0 +1,217 @@
+package whatsonchain
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBEEFSpec is the example BEEF from the BRC-62 specification: a transaction (testTxIDBEEFSpecSubject)
+// and its parent (testTxIDBEEFSpecParent), which is proven by a BUMP for block 814435
+const (
+	testBEEFSpec            = "0100beef01fe636d0c0007021400fe507c0c7aa754cef1f7889d5fd395cf1f785dd7de98eed895dbedfe4e5bc70d1502ac4e164f5bc16746bb0868404292ac8318bbac3800e4aad13a014da427adce3e010b00bc4ff395efd11719b277694cface5aa50d085a0bb81f613f70313acd28cf4557010400574b2d9142b8d28b61d88e3b2c3f44d858411356b49a28a4643b6d1a6a092a5201030051a05fc84d531b5d250c23f4f886f6812f9fe3f402d61607f977b4ecd2701c19010000fd781529d58fc2523cf396a7f25440b409857e7e221766c57214b1d38c7b481f01010062f542f45ea3660f86c013ced80534cb5fd4c19d66c56e7e8c5d4bf2d40acc5e010100b121e91836fd7cd5102b654e9f72f3cf6fdbfd0b161c53a9c54b12c841126331020100000001cd4e4cac3c7b56920d1e7655e7e260d31f29d9a388d04910f1bbd72304a79029010000006b483045022100e75279a205a547c445719420aa3138bf14743e3f42618e5f86a19bde14bb95f7022064777d34776b05d816daf1699493fcdf2ef5a5ab1ad710d9c97bfb5b8f7cef3641210263e2dee22b1ddc5e11f6fab8bcd2378bdd19580d640501ea956ec0e786f93e76ffffffff013e660000000000001976a9146bfd5c7fbe21529d45803dbcf0c87dd3c71efbc288ac0000000001000100000001ac4e164f5bc16746bb0868404292ac8318bbac3800e4aad13a014da427adce3e000000006a47304402203a61a2e931612b4bda08d541cfb980885173b8dcf64a3471238ae7abcd368d6402204cbf24f04b9aa2256d8901f0ed97866603d2be8324c2bfb7a37bf8fc90edd5b441210263e2dee22b1ddc5e11f6fab8bcd2378bdd19580d640501ea956ec0e786f93e76ffffffff013c660000000000001976a9146bfd5c7fbe21529d45803dbcf0c87dd3c71efbc288ac0000000000"
+	testMerkleRootBEEFSpec  = "bb6f640cc4ee56bf38eb5a1969ac0c16caa2d3d202b22bf3735d10eec0ca6e00"
+	testTxIDBEEFSpecParent  = "3ecead27a44d013ad1aae40038acbb1883ac9242406808bb4667c15b4f164eac"
+	testTxIDBEEFSpecSubject = "157428aee67d11123203735e4c540fa1bdab3b36d5882c6f8c5ff79f07d20d1c"
+)
+
+// testBEEFChild returns an unconfirmed transaction spending the first output of testTxIDBlock170
+func testBEEFChild(t *testing.T) *Transaction {
+	t.Helper()
+
+	return &Transaction{
+		Inputs:  []*TransactionInput{{PrevTxID: testTxIDBlock170, Script: []byte{0x51}, Sequence: 0xffffffff}},
+		Outputs: []*TransactionOutput{{Script: []byte{0x6a}, Value: 1_000}},
+		Version: 1,
+	}
+}
+
+// newBEEFMock returns a mock serving block 170's transaction (confirmed) and the given unconfirmed ones
+func newBEEFMock(t *testing.T, unconfirmed ...*Transaction) *mockHTTPCacheable {
+	t.Helper()
+
+	proofJSON, err := json.Marshal(MerkleTSCResults{testProof170()})
+	require.NoError(t, err)
+	bodies := map[string]string{
+		"/tx/" + testTxIDBlock170 + "/hex":        testRawTxBlock170,
+		"/tx/" + testTxIDBlock170 + "/proof/tsc":  string(proofJSON),
+		"/block/" + testBlockHash170 + "/header":  testHeaderJSON170(t),
+		"/tx/" + testTxIDGenesisCoinbase + "/hex": testRawTxGenesisCoinbase, // no proof: unconfirmed
+	}
+	for _, tx := range unconfirmed {
+		bodies["/tx/"+tx.TxID()+"/hex"] = tx.Hex()
+	}
+	return &mockHTTPCacheable{bodies: bodies}
+}
+
+// TestClient_BuildBEEF tests assembling a BEEF from the API
+func TestClient_BuildBEEF(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unconfirmed with a confirmed parent", func(t *testing.T) {
+		t.Parallel()
+		child := testBEEFChild(t)
+		client := newCachedMockClient(newBEEFMock(t, child))
+
+		beef, err := client.BuildBEEF(context.Background(), child.TxID())
+		require.NoError(t, err)
+		require.Len(t, beef.BUMPs, 1)
+		assert.Equal(t, uint64(170), beef.BUMPs[0].BlockHeight)
+		require.Len(t, beef.Transactions, 2)
+		assert.True(t, beef.Transactions[0].HasBUMP)
+		assert.Equal(t, 0, beef.Transactions[0].BUMPIndex)
+		assert.Equal(t, testTxIDBlock170, beef.Transactions[0].Transaction.TxID())
+		assert.False(t, beef.Transactions[1].HasBUMP)
+		assert.Equal(t, child, beef.Subject())
+		assert.Equal(t, beef.Transactions[0], beef.FindTransaction(strings.ToUpper(testTxIDBlock170)))
+		assert.Nil(t, beef.FindTransaction(testTxID1))
+
+		// Round trip through the binary format
+		beefHex, err := beef.Hex()
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(beefHex, "0100beef"))
+		parsed, err := ParseBEEFHex(beefHex)
+		require.NoError(t, err)
+		assert.Equal(t, beef, parsed)
+
+		// The BUMP proves the parent against block 170
+		root, err := parsed.BUMPs[0].ComputeRoot(testTxIDBlock170)
+		require.NoError(t, err)
+		assert.Equal(t, testMerkleRoot170, root)
+	})
+
+	t.Run("unconfirmed chain", func(t *testing.T) {
+		t.Parallel()
+		child := testBEEFChild(t)
+		grandchild := &Transaction{
+			Inputs: []*TransactionInput{
+				{PrevTxID: child.TxID(), Sequence: 0xffffffff},
+				{PrevTxID: testTxIDBlock170, PrevVout: 1, Sequence: 0xffffffff},
+			},
+			Outputs: []*TransactionOutput{{Script: []byte{0x6a}}},
+			Version: 1,
+		}
+		client := newCachedMockClient(newBEEFMock(t, child, grandchild))
+
+		beef, err := client.BuildBEEF(context.Background(), grandchild.TxID())
+		require.NoError(t, err)
+		require.Len(t, beef.BUMPs, 1)
+		require.Len(t, beef.Transactions, 3)
+		assert.Equal(t, testTxIDBlock170, beef.Transactions[0].Transaction.TxID())
+		assert.Equal(t, child.TxID(), beef.Transactions[1].Transaction.TxID())
+		assert.Equal(t, grandchild.TxID(), beef.Subject().TxID())
+
+		beefHex, err := beef.Hex()
+		require.NoError(t, err)
+		_, err = ParseBEEFHex(beefHex)
+		require.NoError(t, err)
+	})
+
+	t.Run("confirmed", func(t *testing.T) {
+		t.Parallel()
+		client := newMockClient(newBEEFMock(t))
+		beef, err := client.BuildBEEF(context.Background(), testTxIDBlock170)
+		require.NoError(t, err)
+		require.Len(t, beef.Transactions, 1)
+		assert.True(t, beef.Transactions[0].HasBUMP)
+		assert.Equal(t, testRawTxBlock170, beef.Subject().Hex())
+	})
+
+	t.Run("missing ancestor", func(t *testing.T) {
+		t.Parallel()
+		client := newMockClient(newBEEFMock(t))
+		_, err := client.BuildBEEF(context.Background(), testTxIDGenesisCoinbase)
+		require.ErrorIs(t, err, ErrTransactionNotFound)
+	})
+
+	t.Run("raw data for another transaction", func(t *testing.T) {
+		t.Parallel()
+		client := newMockClient(&mockHTTPCacheable{bodies: map[string]string{
+			"/tx/" + testTxID1 + "/hex": testRawTxBlock170,
+		}})
+		_, err := client.BuildBEEF(context.Background(), testTxID1)
+		require.ErrorIs(t, err, ErrInvalidTransaction)
+	})
+}
+
+// TestParseBEEF_Spec tests the example from the BRC-62 specification
+func TestParseBEEF_Spec(t *testing.T) {
+	t.Parallel()
+
+	beef, err := ParseBEEFHex(testBEEFSpec)
+	require.NoError(t, err)
+
+	require.Len(t, beef.BUMPs, 1)
+	assert.Equal(t, uint64(814435), beef.BUMPs[0].BlockHeight)
+	assert.True(t, beef.BUMPs[0].Contains(testTxIDBEEFSpecParent))
+	root, err := beef.BUMPs[0].ComputeRoot(testTxIDBEEFSpecParent)
+	require.NoError(t, err)
+	assert.Equal(t, testMerkleRootBEEFSpec, root)
+
+	require.Len(t, beef.Transactions, 2)
+	parent, subject := beef.Transactions[0], beef.Transactions[1]
+	assert.Equal(t, testTxIDBEEFSpecParent, parent.Transaction.TxID())
+	assert.True(t, parent.HasBUMP)
+	assert.Equal(t, 0, parent.BUMPIndex)
+	assert.Equal(t, testTxIDBEEFSpecSubject, subject.Transaction.TxID())
+	assert.False(t, subject.HasBUMP)
+	assert.Equal(t, testTxIDBEEFSpecParent, subject.Transaction.Inputs[0].PrevTxID)
+	assert.Same(t, subject.Transaction, beef.Subject())
+
+	beefHex, err := beef.Hex()
+	require.NoError(t, err)
+	assert.Equal(t, testBEEFSpec, beefHex, "re-serializing reproduces the same bytes")
+}
+
+// TestParseBEEF_Invalid tests malformed and incomplete envelopes
+func TestParseBEEF_Invalid(t *testing.T) {
+	t.Parallel()
+
+	parent, err := ParseTransactionHex(testRawTxBlock170)
+	require.NoError(t, err)
+	child := testBEEFChild(t)
+	path, err := NewMerklePathFromTSC(170, testProof170())
+	require.NoError(t, err)
+	valid := func() *BEEF {
+		return &BEEF{
+			BUMPs: []*MerklePath{path},
+			Transactions: []*BEEFTransaction{
+				{HasBUMP: true, Transaction: parent},
+				{Transaction: child},
+			},
+		}
+	}
+	validHex, err := valid().Hex()
+	require.NoError(t, err)
+
+	for name, beefHex := range map[string]string{
+		"not hex":       "zz",
+		"empty":         "",
+		"version":       "0200beef" + validHex[8:],
+		"truncated":     validHex[:len(validHex)-2],
+		"trailing data": validHex + "00",
+		"BUMP flag":     validHex[:len(validHex)-2] + "02",
+	} {
+		_, err = ParseBEEFHex(beefHex)
+		require.ErrorIs(t, err, ErrInvalidBEEF, name)
+	}
+
+	for name, beef := range map[string]func(b *BEEF){
+		"no transactions": func(b *BEEF) { b.Transactions = nil },
+		"BUMP index":      func(b *BEEF) { b.Transactions[0].BUMPIndex = 1 },
+		"not in BUMP":     func(b *BEEF) { b.Transactions[1].HasBUMP = true },
+		"missing parent":  func(b *BEEF) { b.Transactions = b.Transactions[1:] },
+		"parent after":    func(b *BEEF) { b.Transactions[0], b.Transactions[1] = b.Transactions[1], b.Transactions[0] },
+		"invalid BUMP":    func(b *BEEF) { b.BUMPs = []*MerklePath{{}} },
+		"missing tx":      func(b *BEEF) { b.Transactions[1] = nil },
+	} {
+		invalid := valid()
+		beef(invalid)
+		_, err = invalid.Bytes()
+		require.ErrorIs(t, err, ErrInvalidBEEF, name)
+	}
+}
@@ -0,0 +1,220 @@
+package whatsonchain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// BlockHeaderSize is the size of a serialized block header in bytes
+const BlockHeaderSize = 80
+
+// difficultyOneBits is the compact target of difficulty 1 (the mainnet proof-of-work limit)
+const difficultyOneBits uint32 = 0x1d00ffff
+
+// zeroHash is the previous block hash of the genesis block
+var zeroHash = strings.Repeat("0", hashHexLength)
+
+// BlockHeader is an 80-byte block header parsed locally (see ParseBlockHeader),
+// e.g. from GetLatestHeaderBytes or the header files of GetHeaderBytesFileLinks
+type BlockHeader struct {
+	Bits       uint32 `json:"bits"`
+	MerkleRoot string `json:"merkleroot"`        // hex, in the usual reversed display order
+	Nonce      uint32 `json:"nonce"`             // chosen by the miner so the hash meets the target in Bits
+	PrevHash   string `json:"previousblockhash"` // hex, in the usual reversed display order
+	Time       uint32 `json:"time"`              // Unix timestamp
+	Version    int32  `json:"version"`
+}
+
+// ParseBlockHeader parses a single 80-byte block header.
+// It returns ErrInvalidBlockHeader if the data is not exactly 80 bytes.
+func ParseBlockHeader(raw []byte) (*BlockHeader, error) {
+	if len(raw) != BlockHeaderSize {
+		return nil, fmt.Errorf("%w: %d bytes, expected %d", ErrInvalidBlockHeader, len(raw), BlockHeaderSize)
+	}
+	return &BlockHeader{
+		Version:    int32(binary.LittleEndian.Uint32(raw[0:4])), //nolint:gosec // the wire format is a signed int32
+		PrevHash:   hex.EncodeToString(reverseBytes(raw[4:36])),
+		MerkleRoot: hex.EncodeToString(reverseBytes(raw[36:68])),
+		Time:       binary.LittleEndian.Uint32(raw[68:72]),
+		Bits:       binary.LittleEndian.Uint32(raw[72:76]),
+		Nonce:      binary.LittleEndian.Uint32(raw[76:80]),
+	}, nil
+}
+
+// ParseBlockHeaderHex parses a single block header from hex
+func ParseBlockHeaderHex(headerHex string) (*BlockHeader, error) {
+	raw, err := hex.DecodeString(strings.TrimSpace(headerHex))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBlockHeader, err)
+	}
+	return ParseBlockHeader(raw)
+}
+
+// ParseBlockHeaders parses concatenated 80-byte block headers, in the order given.
+// It returns ErrInvalidBlockHeader if the length is not a multiple of 80 bytes.
+func ParseBlockHeaders(raw []byte) ([]*BlockHeader, error) {
+	if len(raw)%BlockHeaderSize != 0 {
+		return nil, fmt.Errorf("%w: %d bytes is not a multiple of %d", ErrInvalidBlockHeader, len(raw), BlockHeaderSize)
+	}
+	headers := make([]*BlockHeader, 0, len(raw)/BlockHeaderSize)
+	for offset := 0; offset < len(raw); offset += BlockHeaderSize {
+		header, err := ParseBlockHeader(raw[offset : offset+BlockHeaderSize])
+		if err != nil {
+			return nil, err
+		}
+		headers = append(headers, header)
+	}
+	return headers, nil
+}
+
+// ParseBlockHeadersHex parses concatenated block headers from hex (e.g. from GetLatestHeaderBytes)
+func ParseBlockHeadersHex(headersHex string) ([]*BlockHeader, error) {
+	raw, err := hex.DecodeString(strings.TrimSpace(headersHex))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBlockHeader, err)
+	}
+	return ParseBlockHeaders(raw)
+}
+
+// blockHeaderFromInfo rebuilds the 80-byte header from the fields of a block header returned by the API.
+// Hashing it shows whether the fields really are the block's (see VerifyMerkleProofTSC).
+func blockHeaderFromInfo(info *BlockInfo) (*BlockHeader, error) {
+	bits, err := strconv.ParseUint(info.Bits, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("%w: bits %q of block %s", ErrInvalidBlockHeader, info.Bits, info.Hash)
+	}
+	return &BlockHeader{
+		Bits:       uint32(bits),
+		MerkleRoot: info.MerkleRoot,
+		Nonce:      uint32(info.Nonce),     //nolint:gosec // the wire format is a uint32
+		PrevHash:   info.PreviousBlockHash, // empty for the genesis block
+		Time:       uint32(info.Time),      //nolint:gosec // the wire format is a uint32
+		Version:    int32(info.Version),    //nolint:gosec // the wire format is a signed int32
+	}, nil
+}
+
+// Bytes returns the 80-byte serialized header
+func (h *BlockHeader) Bytes() []byte {
+	var buf bytes.Buffer
+	buf.Grow(BlockHeaderSize)
+	buf.Write(binary.LittleEndian.AppendUint32(nil, uint32(h.Version))) //nolint:gosec // the wire format is a signed int32
+	buf.Write(internalHash(h.PrevHash))
+	buf.Write(internalHash(h.MerkleRoot))
+	buf.Write(binary.LittleEndian.AppendUint32(nil, h.Time))
+	buf.Write(binary.LittleEndian.AppendUint32(nil, h.Bits))
+	buf.Write(binary.LittleEndian.AppendUint32(nil, h.Nonce))
+	return buf.Bytes()
+}
+
+// Hex returns the serialized header as hex
+func (h *BlockHeader) Hex() string {
+	return hex.EncodeToString(h.Bytes())
+}
+
+// Hash returns the block hash (hex, in the usual reversed display order)
+func (h *BlockHeader) Hash() string {
+	return hashToID(h.Bytes())
+}
+
+// CheckProofOfWork checks that the block hash meets the target encoded in Bits.
+// It returns ErrInvalidBlockHeader if the target is invalid or the hash is above it.
+//
+// It does not check that Bits follows the network's difficulty adjustment rules.
+func (h *BlockHeader) CheckProofOfWork() error {
+	target := h.Target()
+	if target.Sign() <= 0 || target.BitLen() > 256 {
+		return fmt.Errorf("%w: invalid target bits %08x", ErrInvalidBlockHeader, h.Bits)
+	}
+	hash := new(big.Int).SetBytes(reverseBytes(doubleSHA256(h.Bytes())))
+	if hash.Cmp(target) > 0 {
+		return fmt.Errorf("%w: hash %s is above the target of bits %08x", ErrInvalidBlockHeader, h.Hash(), h.Bits)
+	}
+	return nil
+}
+
+// Target returns the proof-of-work target encoded in Bits (the hash must not be above it).
+// An invalid Bits can give a zero or negative target.
+func (h *BlockHeader) Target() *big.Int {
+	return compactToBig(h.Bits)
+}
+
+// Difficulty returns how many times harder the target is than the difficulty 1 target
+// (the same measure as BlockInfo.Difficulty), or 0 if the target is invalid
+func (h *BlockHeader) Difficulty() float64 {
+	target := h.Target()
+	if target.Sign() <= 0 {
+		return 0
+	}
+	one := new(big.Float).SetInt(compactToBig(difficultyOneBits))
+	difficulty, _ := one.Quo(one, new(big.Float).SetInt(target)).Float64()
+	return difficulty
+}
+
+// Work returns the expected number of hashes to meet the target (2^256 / (target + 1)),
+// or 0 if the target is invalid. Summed over a chain it gives the chainwork (see ChainWork).
+func (h *BlockHeader) Work() *big.Int {
+	target := h.Target()
+	if target.Sign() <= 0 {
+		return new(big.Int)
+	}
+	return new(big.Int).Quo(new(big.Int).Lsh(big.NewInt(1), 256), target.Add(target, big.NewInt(1)))
+}
+
+// ChainWork returns the total work of the headers, added to the chainwork before them if
+// given (e.g. from ParseChainWork), so it can be compared with BlockInfo.ChainWork and ChainInfo.ChainWork
+func ChainWork(prior *big.Int, headers ...*BlockHeader) *big.Int {
+	total := new(big.Int)
+	if prior != nil {
+		total.Set(prior)
+	}
+	for _, header := range headers {
+		total.Add(total, header.Work())
+	}
+	return total
+}
+
+// ParseChainWork parses a chainwork as returned by the API (hex, e.g. BlockInfo.ChainWork).
+// It returns ErrInvalidChainWork if it is not hex.
+func ParseChainWork(chainWork string) (*big.Int, error) {
+	work, ok := new(big.Int).SetString(strings.TrimSpace(chainWork), 16)
+	if !ok || work.Sign() < 0 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidChainWork, chainWork)
+	}
+	return work, nil
+}
+
+// FormatChainWork formats a chainwork as the API does (64 hex characters, zero-padded)
+func FormatChainWork(chainWork *big.Int) string {
+	return fmt.Sprintf("%064x", chainWork)
+}
+
+// compactToBig converts the compact "bits" representation of a target into a number
+// (a base-256 float: 1 byte exponent, 1 sign bit and a 23-bit mantissa)
+func compactToBig(bits uint32) *big.Int {
+	mantissa := int64(bits & 0x007fffff)
+	exponent := uint(bits >> 24)
+
+	var target *big.Int
+	if exponent <= 3 {
+		target = big.NewInt(mantissa >> (8 * (3 - exponent)))
+	} else {
+		target = new(big.Int).Lsh(big.NewInt(mantissa), 8*(exponent-3))
+	}
+	if bits&0x00800000 != 0 {
+		target.Neg(target)
+	}
+	return target
+}
+
+// internalHash decodes a hex hash in display order into 32 bytes in internal (reversed) order,
+// padding or truncating malformed input
+func internalHash(hash string) []byte {
+	b, _ := hex.DecodeString(hash)
+	b = append(b, make([]byte, max(0, 32-len(b)))...)
+	return reverseBytes(b[:32])
+}
@@ -0,0 +1,266 @@
+package whatsonchain
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// testHeaderGenesis is the mainnet genesis block header
+	testHeaderGenesis = "0100000000000000000000000000000000000000000000000000000000000000000000003ba3edfd7a7b12b27ac72c3e67768f617fc81bc3888a51323a9fb8aa4b1e5e4a29ab5f49ffff001d1dac2b7c"
+
+	// testHeaderBlock1 is the mainnet block 1 header
+	testHeaderBlock1 = "010000006fe28c0ab6f1b372c1a6a246ae63f74f931e8365e15a089c68d6190000000000982051fd1e4ba744bbbe680e1fee14677ba1a3c3540bf7b1cdb606e857233e0e61bc6649ffff001d01e36299"
+
+	// testBlockHash1 is the mainnet block 1 hash
+	testBlockHash1 = "00000000839a8e6886ab5951d76f411475428afc90947ee320161bbf18eb6048"
+
+	// testHeaderBits is the regtest proof-of-work limit, so test headers are mined in a few tries
+	testHeaderBits uint32 = 0x207fffff
+)
+
+// testMineHeaders mines n low-difficulty headers on top of parent (nil for a new genesis).
+// The seed makes the merkle roots, and so the hashes, of different branches differ.
+func testMineHeaders(t *testing.T, parent *BlockHeader, n int, seed byte) []*BlockHeader {
+	t.Helper()
+
+	headers := make([]*BlockHeader, 0, n)
+	for i := 0; i < n; i++ {
+		header := &BlockHeader{
+			Bits:       testHeaderBits,
+			MerkleRoot: hashToID([]byte{seed, byte(i), byte(i >> 8), byte(i >> 16)}),
+			PrevHash:   zeroHash,
+			Time:       1_700_000_000 + uint32(i)*600, //nolint:gosec // test heights are small
+			Version:    1,
+		}
+		if parent != nil {
+			header.PrevHash = parent.Hash()
+		}
+		for header.CheckProofOfWork() != nil {
+			header.Nonce++
+		}
+		headers = append(headers, header)
+		parent = header
+	}
+	return headers
+}
+
+// TestParseBlockHeader tests parsing real mainnet headers
+func TestParseBlockHeader(t *testing.T) {
+	t.Parallel()
+
+	t.Run("genesis", func(t *testing.T) {
+		t.Parallel()
+		header, err := ParseBlockHeaderHex(testHeaderGenesis)
+		require.NoError(t, err)
+		assert.Equal(t, &BlockHeader{
+			Bits:       0x1d00ffff,
+			MerkleRoot: testMerkleRootGenesis,
+			Nonce:      2083236893,
+			PrevHash:   zeroHash,
+			Time:       1231006505,
+			Version:    1,
+		}, header)
+		assert.Equal(t, testBlockHashGenesis, header.Hash())
+		assert.Equal(t, testHeaderGenesis, header.Hex())
+		require.NoError(t, header.CheckProofOfWork())
+	})
+
+	t.Run("block 1", func(t *testing.T) {
+		t.Parallel()
+		header, err := ParseBlockHeaderHex(strings.ToUpper(testHeaderBlock1))
+		require.NoError(t, err)
+		assert.Equal(t, testBlockHashGenesis, header.PrevHash)
+		assert.Equal(t, testBlockHash1, header.Hash())
+		require.NoError(t, header.CheckProofOfWork())
+	})
+
+	t.Run("concatenated", func(t *testing.T) {
+		t.Parallel()
+		headers, err := ParseBlockHeadersHex(testHeaderGenesis + testHeaderBlock1)
+		require.NoError(t, err)
+		require.Len(t, headers, 2)
+		assert.Equal(t, headers[0].Hash(), headers[1].PrevHash)
+
+		headers, err = ParseBlockHeaders(nil)
+		require.NoError(t, err)
+		assert.Empty(t, headers)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		for name, headerHex := range map[string]string{
+			"not hex":   "zz",
+			"short":     testHeaderGenesis[:158],
+			"long":      testHeaderGenesis + "00",
+			"truncated": testHeaderGenesis[:len(testHeaderGenesis)-2],
+		} {
+			_, err := ParseBlockHeaderHex(headerHex)
+			require.ErrorIs(t, err, ErrInvalidBlockHeader, name)
+		}
+		_, err := ParseBlockHeadersHex(testHeaderGenesis + "00")
+		require.ErrorIs(t, err, ErrInvalidBlockHeader)
+		_, err = ParseBlockHeadersHex("zz")
+		require.ErrorIs(t, err, ErrInvalidBlockHeader)
+	})
+}
+
+// TestBlockHeader_CheckProofOfWork tests the hash is checked against the target in Bits
+func TestBlockHeader_CheckProofOfWork(t *testing.T) {
+	t.Parallel()
+
+	header, err := ParseBlockHeaderHex(testHeaderGenesis)
+	require.NoError(t, err)
+
+	t.Run("wrong nonce", func(t *testing.T) {
+		t.Parallel()
+		invalid := *header
+		invalid.Nonce++
+		require.ErrorIs(t, invalid.CheckProofOfWork(), ErrInvalidBlockHeader)
+	})
+
+	t.Run("harder target", func(t *testing.T) {
+		t.Parallel()
+		invalid := *header
+		invalid.Bits = 0x1c00ffff
+		require.ErrorIs(t, invalid.CheckProofOfWork(), ErrInvalidBlockHeader)
+	})
+
+	t.Run("invalid targets", func(t *testing.T) {
+		t.Parallel()
+		for _, bits := range []uint32{0, 0x1d800001, 0x01003456, 0x23000001} {
+			invalid := *header
+			invalid.Bits = bits
+			require.ErrorIs(t, invalid.CheckProofOfWork(), ErrInvalidBlockHeader, "%08x", bits)
+		}
+	})
+
+	t.Run("mined test headers", func(t *testing.T) {
+		t.Parallel()
+		for _, mined := range testMineHeaders(t, header, 3, 1) {
+			require.NoError(t, mined.CheckProofOfWork())
+		}
+	})
+}
+
+// TestCompactToBig tests decoding compact targets
+func TestCompactToBig(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "ffff0000000000000000000000000000000000000000000000000000", compactToBig(0x1d00ffff).Text(16))
+	assert.Equal(t, "12345600", compactToBig(0x04123456).Text(16))
+	assert.Equal(t, "12", compactToBig(0x01123456).Text(16))
+	assert.Equal(t, "-12345600", compactToBig(0x04923456).Text(16))
+	assert.Equal(t, "0", compactToBig(0).Text(16))
+}
+
+// TestBlockHeader_Bytes tests malformed hashes are padded rather than failing
+func TestBlockHeader_Bytes(t *testing.T) {
+	t.Parallel()
+
+	raw := (&BlockHeader{MerkleRoot: "zz", PrevHash: "01"}).Bytes()
+	require.Len(t, raw, BlockHeaderSize)
+	header, err := ParseBlockHeader(raw)
+	require.NoError(t, err)
+	assert.Equal(t, "01"+strings.Repeat("0", 62), header.PrevHash)
+	assert.Equal(t, zeroHash, header.MerkleRoot)
+}
+
+// TestBlockHeaderFromInfo tests rebuilding a header from the API's header fields
+func TestBlockHeaderFromInfo(t *testing.T) {
+	t.Parallel()
+
+	// The API leaves out the previous block hash of the genesis block
+	genesis := &BlockInfo{
+		Bits:       "1d00ffff",
+		Hash:       testBlockHashGenesis,
+		MerkleRoot: testMerkleRootGenesis,
+		Nonce:      2083236893,
+		Time:       1231006505,
+		Version:    1,
+	}
+	header, err := blockHeaderFromInfo(genesis)
+	require.NoError(t, err)
+	assert.Equal(t, testHeaderGenesis, header.Hex())
+	assert.Equal(t, testBlockHashGenesis, header.Hash())
+
+	genesis.Bits = "zz"
+	_, err = blockHeaderFromInfo(genesis)
+	require.ErrorIs(t, err, ErrInvalidBlockHeader)
+}
+
+// TestBlockHeader_Work tests the target, difficulty and work derived from Bits
+func TestBlockHeader_Work(t *testing.T) {
+	t.Parallel()
+
+	genesis, err := ParseBlockHeaderHex(testHeaderGenesis)
+	require.NoError(t, err)
+	block1, err := ParseBlockHeaderHex(testHeaderBlock1)
+	require.NoError(t, err)
+
+	assert.Equal(t, "00000000ffff0000000000000000000000000000000000000000000000000000", FormatChainWork(genesis.Target()))
+	assert.InDelta(t, 1.0, genesis.Difficulty(), 0)
+	assert.Equal(t, big.NewInt(0x100010001), genesis.Work())
+
+	// Difficulty and work scale with a harder target
+	harder := &BlockHeader{Bits: 0x1b0404cb}
+	assert.InDelta(t, 16307.42, harder.Difficulty(), 0.01)
+	assert.Equal(t, "3fb3ab764c00", harder.Work().Text(16))
+
+	// Regtest headers are about half as hard as a single hash
+	easy := &BlockHeader{Bits: testHeaderBits}
+	assert.InDelta(t, 4.66e-10, easy.Difficulty(), 0.01e-10)
+	assert.Equal(t, big.NewInt(2), easy.Work())
+
+	// Invalid targets have no work
+	for _, bits := range []uint32{0, 0x1d800001} {
+		invalid := &BlockHeader{Bits: bits}
+		assert.Zero(t, invalid.Difficulty())
+		assert.Zero(t, invalid.Work().Sign())
+	}
+
+	t.Run("chainwork", func(t *testing.T) {
+		t.Parallel()
+
+		// The chainwork reported by the API for block 1
+		reported, err := ParseChainWork("0000000000000000000000000000000000000000000000000000000200020002")
+		require.NoError(t, err)
+		assert.Zero(t, ChainWork(nil, genesis, block1).Cmp(reported))
+
+		prior, err := ParseChainWork(FormatChainWork(ChainWork(nil, genesis)))
+		require.NoError(t, err)
+		assert.Equal(t, "0000000000000000000000000000000000000000000000000000000200020002", FormatChainWork(ChainWork(prior, block1)))
+		assert.Equal(t, "0000000000000000000000000000000000000000000000000000000100010001", FormatChainWork(prior))
+		assert.Zero(t, ChainWork(nil).Sign())
+
+		for _, chainWork := range []string{"", "zz", "-01", "0x01"} {
+			_, err = ParseChainWork(chainWork)
+			require.ErrorIs(t, err, ErrInvalidChainWork, chainWork)
+		}
+	})
+}
+
+// TestClient_GetLatestBlockHeaders tests fetching and parsing the latest headers
+func TestClient_GetLatestBlockHeaders(t *testing.T) {
+	t.Parallel()
+
+	client := newCachedMockClient(&mockHTTPCacheable{bodies: map[string]string{
+		"/block/headers/latest": testHeaderGenesis + testHeaderBlock1,
+	}})
+	headers, err := client.GetLatestBlockHeaders(context.Background(), 2)
+	require.NoError(t, err)
+	require.Len(t, headers, 2)
+	assert.Equal(t, testBlockHashGenesis, headers[0].Hash())
+	assert.Equal(t, testBlockHash1, headers[1].Hash())
+
+	client = newCachedMockClient(&mockHTTPCacheable{bodies: map[string]string{
+		"/block/headers/latest": testHeaderGenesis[:100],
+	}})
+	_, err = client.GetLatestBlockHeaders(context.Background(), 2)
+	require.ErrorIs(t, err, ErrInvalidBlockHeader)
+}
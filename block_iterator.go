@@ -0,0 +1,205 @@
+package whatsonchain
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// BlockTransactions returns an iterator over every transaction ID in the block with the given hash.
+//
+// It yields the IDs in BlockInfo.Tx first, then walks each page from GetBlockPages (blocks with more
+// than 1000 transactions), fetching one page at a time so large blocks are never held in memory.
+// Every request goes through the client's rate limiter. Iteration stops after the first error
+// (including a canceled context), which is yielded with an empty transaction ID.
+func (c *Client) BlockTransactions(ctx context.Context, hash string) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		block, err := c.GetBlockByHash(ctx, hash)
+		if err != nil {
+			yield("", err)
+			return
+		}
+
+		for _, txID := range block.Tx {
+			if !yield(txID, nil) {
+				return
+			}
+		}
+
+		// Pages are numbered from 1 (the first 1000 transactions are in the block itself)
+		for page := 1; page <= len(block.Pages.URI); page++ {
+			if err = ctx.Err(); err != nil {
+				yield("", err)
+				return
+			}
+
+			var txIDs BlockPagesInfo
+			if txIDs, err = c.GetBlockPages(ctx, hash, page); err != nil {
+				yield("", err)
+				return
+			}
+			for _, txID := range txIDs {
+				if !yield(txID, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// defaultBlockTxDetailsConcurrency is the default number of BulkTransactionDetails batches in flight
+const defaultBlockTxDetailsConcurrency = 3
+
+// BlockTxDetailsOptions configures BlockTransactionDetails
+type BlockTxDetailsOptions struct {
+	Concurrency int // max batches fetched at the same time (default: 3)
+	StartIndex  int // index of the first transaction in the block to return (resume point)
+}
+
+// BlockBatchError is yielded by BlockTransactionDetails when a batch (or the block enumeration) fails.
+//
+// Every transaction before Index has already been yielded, so set StartIndex to Index to resume.
+type BlockBatchError struct {
+	Index int      // block index of the first transaction that was not returned
+	TxIDs []string // transaction IDs in the failed batch (empty if enumerating the block failed)
+	Err   error    // underlying error
+}
+
+// Error returns the error message
+func (e *BlockBatchError) Error() string {
+	return fmt.Sprintf("block transactions from index %d: %s", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *BlockBatchError) Unwrap() error {
+	return e.Err
+}
+
+// blockBatch is a batch of transaction IDs and its result
+type blockBatch struct {
+	index  int
+	txIDs  []string
+	result chan blockBatchResult
+}
+
+// blockBatchResult is the outcome of fetching a blockBatch
+type blockBatchResult struct {
+	txList TxList
+	err    error
+}
+
+// BlockTransactionDetails returns an iterator over the full TxInfo of every transaction in the block.
+//
+// Transaction IDs come from BlockTransactions and are fetched with BulkTransactionDetails in batches of
+// MaxTransactionsUTXO, with up to opts.Concurrency batches in flight. Results are yielded in block order.
+// Use opts.StartIndex to resume. A failed batch yields a *BlockBatchError (after every transaction before
+// it, so partial results are kept) and stops the iteration.
+func (c *Client) BlockTransactionDetails(ctx context.Context, hash string, opts *BlockTxDetailsOptions) iter.Seq2[*TxInfo, error] {
+	concurrency, startIndex := defaultBlockTxDetailsConcurrency, 0
+	if opts != nil {
+		if opts.Concurrency > 0 {
+			concurrency = opts.Concurrency
+		}
+		if opts.StartIndex > 0 {
+			startIndex = opts.StartIndex
+		}
+	}
+
+	return func(yield func(*TxInfo, error) bool) {
+		if err := c.validateHash("hash", hash); err != nil {
+			yield(nil, err)
+			return
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// The buffer keeps up to concurrency batches ahead of the consumer, in block order
+		pending := make(chan *blockBatch, concurrency)
+		go c.produceBlockBatches(ctx, hash, startIndex, concurrency, pending)
+
+		// Stop the producer and wait for it to exit (in-flight batches write to buffered channels)
+		defer func() {
+			cancel()
+			for range pending {
+				// discard batches queued before the cancellation
+			}
+		}()
+
+		for batch := range pending {
+			result := <-batch.result
+			if result.err != nil {
+				yield(nil, &BlockBatchError{Index: batch.index, TxIDs: batch.txIDs, Err: result.err})
+				return
+			}
+			for _, tx := range result.txList {
+				if !yield(tx, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// produceBlockBatches enumerates the block's transactions from startIndex, sends each batch to pending
+// (in block order) and fetches up to concurrency batches at the same time. It closes pending when done.
+func (c *Client) produceBlockBatches(ctx context.Context, hash string, startIndex, concurrency int, pending chan<- *blockBatch) {
+	defer close(pending)
+
+	sem := make(chan struct{}, concurrency)
+	send := func(batch *blockBatch) bool {
+		select {
+		case pending <- batch:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	dispatch := func(batch *blockBatch) bool {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return false
+		}
+		if !send(batch) {
+			<-sem
+			return false
+		}
+		go func() {
+			defer func() { <-sem }()
+			txList, err := c.BulkTransactionDetails(ctx, &TxHashes{TxIDs: batch.txIDs})
+			batch.result <- blockBatchResult{txList: txList, err: err}
+		}()
+		return true
+	}
+
+	index := 0
+	batch := &blockBatch{index: startIndex, result: make(chan blockBatchResult, 1)}
+	for txID, err := range c.BlockTransactions(ctx, hash) {
+		if err != nil {
+			// Flush the partial batch first so its transactions are still returned
+			if len(batch.txIDs) > 0 && !dispatch(batch) {
+				return
+			}
+			failed := &blockBatch{index: max(index, startIndex), result: make(chan blockBatchResult, 1)}
+			failed.result <- blockBatchResult{err: err}
+			send(failed)
+			return
+		}
+		if index++; index <= startIndex {
+			continue
+		}
+
+		batch.txIDs = append(batch.txIDs, txID)
+		if len(batch.txIDs) == MaxTransactionsUTXO {
+			if !dispatch(batch) {
+				return
+			}
+			batch = &blockBatch{index: index, result: make(chan blockBatchResult, 1)}
+		}
+	}
+
+	if len(batch.txIDs) > 0 {
+		dispatch(batch)
+	}
+}
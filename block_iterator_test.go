@@ -0,0 +1,309 @@
+package whatsonchain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPagedBlockHash = "000000000000000000885a4d8e9912f085b42288adc58b3ee5830a7da9f4fef4"
+
+// newPagedBlockMock returns a mock with a block of two transactions and the given pages
+func newPagedBlockMock(pages ...string) *mockHTTPCacheable {
+	uris := make([]string, 0, len(pages))
+	bodies := map[string]string{}
+	for i, page := range pages {
+		uri := fmt.Sprintf("/block/hash/%s/page/%d", testPagedBlockHash, i+1)
+		uris = append(uris, strconv.Quote(uri))
+		bodies[uri] = page
+	}
+	bodies["/block/hash/"+testPagedBlockHash] = fmt.Sprintf(
+		`{"hash":%q,"tx":["a","b"],"pages":{"size":%d,"uri":[%s]}}`,
+		testPagedBlockHash, len(pages), strings.Join(uris, ","),
+	)
+	return &mockHTTPCacheable{bodies: bodies}
+}
+
+// collectBlockTransactions drains the iterator
+func collectBlockTransactions(ctx context.Context, client ClientInterface) (txIDs []string, err error) {
+	for txID, iterErr := range client.BlockTransactions(ctx, testPagedBlockHash) {
+		if iterErr != nil {
+			return txIDs, iterErr
+		}
+		txIDs = append(txIDs, txID)
+	}
+	return txIDs, nil
+}
+
+// TestClient_BlockTransactions tests walking the block and all of its pages
+func TestClient_BlockTransactions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("block with pages", func(t *testing.T) {
+		t.Parallel()
+		mock := newPagedBlockMock(`["c","d"]`, `["e"]`)
+		client := newMockClient(mock)
+
+		txIDs, err := collectBlockTransactions(context.Background(), client)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "c", "d", "e"}, txIDs)
+		assert.Equal(t, int64(3), mock.calls.Load())
+	})
+
+	t.Run("block without pages", func(t *testing.T) {
+		t.Parallel()
+		client := newMockClient(newPagedBlockMock())
+
+		txIDs, err := collectBlockTransactions(context.Background(), client)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, txIDs)
+	})
+
+	t.Run("stop early", func(t *testing.T) {
+		t.Parallel()
+		mock := newPagedBlockMock(`["c","d"]`, `["e"]`)
+		client := newMockClient(mock)
+
+		var txIDs []string
+		for txID, err := range client.BlockTransactions(context.Background(), testPagedBlockHash) {
+			require.NoError(t, err)
+			txIDs = append(txIDs, txID)
+			if txID == "c" {
+				break
+			}
+		}
+		assert.Equal(t, []string{"a", "b", "c"}, txIDs)
+		assert.Equal(t, int64(2), mock.calls.Load())
+	})
+
+	t.Run("block not found", func(t *testing.T) {
+		t.Parallel()
+		client := newMockClient(&mockHTTPCacheable{})
+
+		txIDs, err := collectBlockTransactions(context.Background(), client)
+		require.ErrorIs(t, err, ErrBlockNotFound)
+		assert.Empty(t, txIDs)
+	})
+
+	t.Run("page error", func(t *testing.T) {
+		t.Parallel()
+		mock := newPagedBlockMock(`["c","d"]`, `["e"]`)
+		delete(mock.bodies, "/block/hash/"+testPagedBlockHash+"/page/2")
+		client := newMockClient(mock)
+
+		txIDs, err := collectBlockTransactions(context.Background(), client)
+		require.ErrorIs(t, err, ErrBlockNotFound)
+		assert.Equal(t, []string{"a", "b", "c", "d"}, txIDs)
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		t.Parallel()
+		mock := newPagedBlockMock(`["c","d"]`)
+		client := newMockClient(mock)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var txIDs []string
+		var err error
+		for txID, iterErr := range client.BlockTransactions(ctx, testPagedBlockHash) {
+			if iterErr != nil {
+				err = iterErr
+				break
+			}
+			txIDs = append(txIDs, txID)
+			if txID == "b" {
+				cancel()
+			}
+		}
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, []string{"a", "b"}, txIDs)
+		assert.Equal(t, int64(1), mock.calls.Load())
+	})
+}
+
+// mockHTTPBlockTxDetails serves a block of generated txids and answers /txs with their details
+type mockHTTPBlockTxDetails struct {
+	block    *mockHTTPCacheable
+	failTxID string // a /txs batch containing this txid returns HTTP 500
+	inFlight atomic.Int32
+	maxSeen  atomic.Int32
+	posts    atomic.Int32
+}
+
+// Do is a mock http request
+func (m *mockHTTPBlockTxDetails) Do(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodPost {
+		return m.block.Do(req)
+	}
+
+	m.posts.Add(1)
+	current := m.inFlight.Add(1)
+	defer m.inFlight.Add(-1)
+	for {
+		seen := m.maxSeen.Load()
+		if current <= seen || m.maxSeen.CompareAndSwap(seen, current) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	var hashes TxHashes
+	if err := json.NewDecoder(req.Body).Decode(&hashes); err != nil {
+		return nil, err
+	}
+	txList := make(TxList, 0, len(hashes.TxIDs))
+	for _, txID := range hashes.TxIDs {
+		if txID == m.failTxID {
+			return &http.Response{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(strings.NewReader("boom"))}, nil
+		}
+		txList = append(txList, &TxInfo{TxID: txID})
+	}
+	body, err := json.Marshal(txList)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(body))}, nil
+}
+
+// newBlockTxDetailsMock returns a mock block with 2 + pages*pageSize transactions (txids 0, 1, ...)
+func newBlockTxDetailsMock(pages, pageSize int) (*mockHTTPBlockTxDetails, []string) {
+	txIDs := []string{fmt.Sprintf("%064x", 0), fmt.Sprintf("%064x", 1)}
+	pageBodies := make([]string, 0, pages)
+	for p := 0; p < pages; p++ {
+		page := make([]string, 0, pageSize)
+		for i := 0; i < pageSize; i++ {
+			page = append(page, fmt.Sprintf("%064x", len(txIDs)))
+			txIDs = append(txIDs, page[i])
+		}
+		body, _ := json.Marshal(page)
+		pageBodies = append(pageBodies, string(body))
+	}
+	block := newPagedBlockMock(pageBodies...)
+	blockPath := "/block/hash/" + testPagedBlockHash
+	first, _ := json.Marshal(txIDs[:2])
+	block.bodies[blockPath] = strings.Replace(block.bodies[blockPath], `["a","b"]`, string(first), 1)
+	return &mockHTTPBlockTxDetails{block: block}, txIDs
+}
+
+// collectBlockTransactionDetails drains the iterator
+func collectBlockTransactionDetails(client ClientInterface, opts *BlockTxDetailsOptions) (txIDs []string, err error) {
+	for tx, iterErr := range client.BlockTransactionDetails(context.Background(), testPagedBlockHash, opts) {
+		if iterErr != nil {
+			return txIDs, iterErr
+		}
+		txIDs = append(txIDs, tx.TxID)
+	}
+	return txIDs, nil
+}
+
+// TestClient_BlockTransactionDetails tests fetching the details of every transaction in a block
+func TestClient_BlockTransactionDetails(t *testing.T) {
+	t.Parallel()
+
+	t.Run("block order with bounded concurrency", func(t *testing.T) {
+		t.Parallel()
+		mock, expected := newBlockTxDetailsMock(3, 30) // 92 txs = 5 batches
+		client := newMockClient(mock)
+
+		txIDs, err := collectBlockTransactionDetails(client, &BlockTxDetailsOptions{Concurrency: 2})
+		require.NoError(t, err)
+		assert.Equal(t, expected, txIDs)
+		assert.Equal(t, int32(5), mock.posts.Load())
+		assert.LessOrEqual(t, mock.maxSeen.Load(), int32(2))
+	})
+
+	t.Run("default options", func(t *testing.T) {
+		t.Parallel()
+		mock, expected := newBlockTxDetailsMock(1, 5)
+		client := newMockClient(mock)
+
+		txIDs, err := collectBlockTransactionDetails(client, nil)
+		require.NoError(t, err)
+		assert.Equal(t, expected, txIDs)
+	})
+
+	t.Run("resume from index", func(t *testing.T) {
+		t.Parallel()
+		mock, expected := newBlockTxDetailsMock(2, 20)
+		client := newMockClient(mock)
+
+		txIDs, err := collectBlockTransactionDetails(client, &BlockTxDetailsOptions{StartIndex: 25})
+		require.NoError(t, err)
+		assert.Equal(t, expected[25:], txIDs)
+		assert.Equal(t, int32(1), mock.posts.Load())
+	})
+
+	t.Run("partial results on batch failure", func(t *testing.T) {
+		t.Parallel()
+		mock, expected := newBlockTxDetailsMock(2, 30) // 62 txs
+		mock.failTxID = expected[45]
+		client := newMockClient(mock)
+
+		txIDs, err := collectBlockTransactionDetails(client, &BlockTxDetailsOptions{Concurrency: 4})
+		var batchErr *BlockBatchError
+		require.ErrorAs(t, err, &batchErr)
+		require.ErrorIs(t, err, ErrRequestFailed)
+		assert.Equal(t, 40, batchErr.Index)
+		assert.Equal(t, expected[40:60], batchErr.TxIDs)
+		assert.Equal(t, expected[:40], txIDs)
+
+		// Resuming from the failed index picks up where it stopped
+		mock.failTxID = ""
+		txIDs, err = collectBlockTransactionDetails(client, &BlockTxDetailsOptions{StartIndex: batchErr.Index})
+		require.NoError(t, err)
+		assert.Equal(t, expected[40:], txIDs)
+	})
+
+	t.Run("block not found", func(t *testing.T) {
+		t.Parallel()
+		client := newMockClient(&mockHTTPBlockTxDetails{block: &mockHTTPCacheable{}})
+
+		txIDs, err := collectBlockTransactionDetails(client, nil)
+		var batchErr *BlockBatchError
+		require.ErrorAs(t, err, &batchErr)
+		require.ErrorIs(t, err, ErrBlockNotFound)
+		assert.Equal(t, 0, batchErr.Index)
+		assert.Empty(t, txIDs)
+	})
+
+	t.Run("page failure keeps enumerated transactions", func(t *testing.T) {
+		t.Parallel()
+		mock, expected := newBlockTxDetailsMock(2, 10)
+		delete(mock.block.bodies, "/block/hash/"+testPagedBlockHash+"/page/2")
+		client := newMockClient(mock)
+
+		txIDs, err := collectBlockTransactionDetails(client, nil)
+		var batchErr *BlockBatchError
+		require.ErrorAs(t, err, &batchErr)
+		assert.Equal(t, 12, batchErr.Index)
+		assert.Empty(t, batchErr.TxIDs)
+		assert.Equal(t, expected[:12], txIDs)
+	})
+
+	t.Run("stop early", func(t *testing.T) {
+		t.Parallel()
+		mock, _ := newBlockTxDetailsMock(4, 50)
+		client := newMockClient(mock)
+
+		count := 0
+		for _, err := range client.BlockTransactionDetails(context.Background(), testPagedBlockHash, nil) {
+			require.NoError(t, err)
+			if count++; count == 3 {
+				break
+			}
+		}
+		assert.Equal(t, 3, count)
+		assert.Less(t, mock.posts.Load(), int32(11))
+	})
+}
@@ -0,0 +1,221 @@
+package whatsonchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// BlockEventType is the kind of change SubscribeBlocks reports
+type BlockEventType string
+
+const (
+	// BlockEventConnected is a block added to the active chain
+	BlockEventConnected BlockEventType = "connected"
+
+	// BlockEventDisconnected is a block removed from the active chain by a reorg
+	BlockEventDisconnected BlockEventType = "disconnected"
+
+	// BlockEventError is a failed poll; the subscription keeps going
+	BlockEventError BlockEventType = "error"
+)
+
+const (
+	// defaultBlockSubscriptionBuffer is the default BlockSubscriptionOptions.Buffer
+	defaultBlockSubscriptionBuffer = 16
+
+	// defaultBlockSubscriptionInterval is the default BlockSubscriptionOptions.Interval
+	defaultBlockSubscriptionInterval = 10 * time.Second
+
+	// defaultBlockSubscriptionReorgDepth is the default BlockSubscriptionOptions.MaxReorgDepth
+	defaultBlockSubscriptionReorgDepth = 100
+)
+
+// BlockEvent is a change to the active chain reported by SubscribeBlocks
+type BlockEvent struct {
+	Type  BlockEventType `json:"type"`
+	Block *BlockInfo     `json:"block,omitempty"` // the block connected or disconnected
+	Err   error          `json:"-"`               // why the poll failed (BlockEventError)
+}
+
+// BlockSubscriptionOptions configures SubscribeBlocks
+type BlockSubscriptionOptions struct {
+	Buffer        int           // size of the event channel (default 16)
+	Interval      time.Duration // time between GetChainInfo polls (default 10s)
+	MaxReorgDepth int           // most blocks disconnected by one reorg before giving up (default 100)
+	StartHeight   int64         // first block height to deliver (default: blocks after the current tip)
+}
+
+// SubscribeBlocks reports changes to the active chain on the returned channel until the context is
+// canceled, then closes it.
+//
+// The tip is polled with GetChainInfo; when BestBlockHash changes, every height from the last block
+// delivered up to the new tip is fetched with GetBlockByHeight and connected in order, so no block is
+// skipped. A block whose PreviousBlockHash does not match the last block delivered means a reorg: the
+// stale blocks are disconnected (newest first, walking PreviousBlockHash with GetBlockByHash past the
+// blocks remembered) until the chains link up, then the new ones are connected. A reorg deeper than
+// MaxReorgDepth is reported as ErrReorgTooDeep and delivery resumes from the new tip.
+// Failed polls are sent as BlockEventError events. Events wait for the reader, so a slow reader delays
+// polling rather than losing blocks.
+func (c *Client) SubscribeBlocks(ctx context.Context, opts *BlockSubscriptionOptions) <-chan *BlockEvent {
+	sub := &blockSubscription{
+		client:   c,
+		interval: defaultBlockSubscriptionInterval,
+		maxDepth: defaultBlockSubscriptionReorgDepth,
+	}
+	buffer := defaultBlockSubscriptionBuffer
+	if opts != nil {
+		if opts.Buffer > 0 {
+			buffer = opts.Buffer
+		}
+		if opts.Interval > 0 {
+			sub.interval = opts.Interval
+		}
+		if opts.MaxReorgDepth > 0 {
+			sub.maxDepth = opts.MaxReorgDepth
+		}
+		sub.startHeight = max(opts.StartHeight, 0)
+	}
+	sub.events = make(chan *BlockEvent, buffer)
+
+	go sub.run(ctx)
+	return sub.events
+}
+
+// blockSubscription is the state of a SubscribeBlocks goroutine
+type blockSubscription struct {
+	chain       []*BlockInfo // the last blocks of the active chain seen, oldest first (at most maxDepth)
+	client      ClientInterface
+	events      chan *BlockEvent
+	interval    time.Duration
+	maxDepth    int
+	startHeight int64
+}
+
+// run polls until the context is canceled, then closes the event channel
+func (s *blockSubscription) run(ctx context.Context) {
+	defer close(s.events)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		if err := s.poll(ctx); err != nil {
+			if ctx.Err() != nil || !s.send(ctx, &BlockEvent{Type: BlockEventError, Err: err}) {
+				return
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// poll moves the subscription to the current tip, sending the events on the way
+func (s *blockSubscription) poll(ctx context.Context) error {
+	info, err := s.client.GetChainInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(s.chain) == 0 {
+		// The first poll (or after a too deep reorg) starts from the block before the first one to deliver
+		baseline := info.Blocks
+		if s.startHeight > 0 {
+			if baseline = s.startHeight - 1; baseline > info.Blocks {
+				return nil // wait for the chain to reach the start height
+			}
+			s.startHeight = 0
+		}
+		var block *BlockInfo
+		if block, err = s.client.GetBlockByHeight(ctx, baseline); err != nil {
+			return err
+		}
+		s.chain = append(s.chain, block)
+	}
+
+	for depth := 0; ; {
+		top := s.chain[len(s.chain)-1]
+		if top.Height >= info.Blocks {
+			if top.Hash == info.BestBlockHash {
+				return nil
+			}
+			// The tip changed without growing: check our top block is still in the active chain
+			block, err := s.client.GetBlockByHeight(ctx, top.Height)
+			if err != nil && !errors.Is(err, ErrBlockNotFound) {
+				return err
+			} else if err == nil && block.Hash == top.Hash {
+				return nil // GetChainInfo is behind GetBlockByHeight
+			}
+			if err = s.disconnect(ctx, &depth); err != nil {
+				return err
+			}
+			continue
+		}
+
+		block, err := s.client.GetBlockByHeight(ctx, top.Height+1)
+		if err != nil {
+			return err
+		}
+		if block.PreviousBlockHash != top.Hash {
+			if err = s.disconnect(ctx, &depth); err != nil {
+				return err
+			}
+			continue
+		}
+		if err = s.connect(ctx, block); err != nil {
+			return err
+		}
+	}
+}
+
+// connect adds the block at the top of the chain and sends it
+func (s *blockSubscription) connect(ctx context.Context, block *BlockInfo) error {
+	s.chain = append(s.chain, block)
+	if len(s.chain) > s.maxDepth {
+		s.chain = s.chain[len(s.chain)-s.maxDepth:]
+	}
+	if !s.send(ctx, &BlockEvent{Type: BlockEventConnected, Block: block}) {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// disconnect removes the block at the top of the chain and sends it. When it is the last block
+// remembered, its parent is fetched by PreviousBlockHash so the walk back can go on, up to maxDepth
+// blocks in one reorg; past that the chain is forgotten and the next poll starts from the tip.
+func (s *blockSubscription) disconnect(ctx context.Context, depth *int) error {
+	if *depth >= s.maxDepth {
+		s.chain = nil
+		return fmt.Errorf("%w: no common block in the last %d", ErrReorgTooDeep, s.maxDepth)
+	}
+
+	block := s.chain[len(s.chain)-1]
+	if len(s.chain) == 1 {
+		parent, err := s.client.GetBlockByHash(ctx, block.PreviousBlockHash)
+		if err != nil {
+			return err
+		}
+		s.chain = []*BlockInfo{parent, block}
+	}
+
+	*depth++
+	s.chain = s.chain[:len(s.chain)-1]
+	if !s.send(ctx, &BlockEvent{Type: BlockEventDisconnected, Block: block}) {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// send sends the event, returning false if the context was canceled first
+func (s *blockSubscription) send(ctx context.Context, event *BlockEvent) bool {
+	select {
+	case s.events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,269 @@
+package whatsonchain
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockHTTPBlockChain answers GetChainInfo, GetBlockByHeight and GetBlockByHash from a chain of
+// block hashes that tests replace to mine blocks and reorg. Reorged blocks can still be fetched by hash.
+// Tests name blocks with labels such as "a10", see testChainHash.
+type mockHTTPBlockChain struct {
+	blocks map[string]*BlockInfo // every block ever in the chain, by hash
+	chain  []string              // block hash by height
+	fail   bool                  // answer GetChainInfo with HTTP 500
+	mu     sync.Mutex
+}
+
+// testChainHash returns a well-formed block hash carrying the label (hex encoded, zero padded)
+func testChainHash(label string) string {
+	encoded := hex.EncodeToString([]byte(label))
+	return strings.Repeat("0", 64-len(encoded)) + encoded
+}
+
+// testChainLabel returns the label carried by a testChainHash
+func testChainLabel(hash string) string {
+	label, _ := hex.DecodeString(strings.TrimLeft(hash, "0"))
+	return string(label)
+}
+
+// newMockHTTPBlockChain returns a chain of blocks "a0" to "a<tip>"
+func newMockHTTPBlockChain(tip int) *mockHTTPBlockChain {
+	m := &mockHTTPBlockChain{blocks: make(map[string]*BlockInfo)}
+	m.set(0, testChainLabels("a", 0, tip)...)
+	return m
+}
+
+// testChainLabels returns the labels prefix<from> to prefix<to>
+func testChainLabels(prefix string, from, to int) []string {
+	labels := make([]string, 0, to-from+1)
+	for height := from; height <= to; height++ {
+		labels = append(labels, fmt.Sprintf("%s%d", prefix, height))
+	}
+	return labels
+}
+
+// set replaces the chain from the height with the labeled blocks
+func (m *mockHTTPBlockChain) set(height int, labels ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.chain = m.chain[:height:height]
+	for _, label := range labels {
+		m.chain = append(m.chain, testChainHash(label))
+	}
+	for h := height; h < len(m.chain); h++ {
+		block := &BlockInfo{Hash: m.chain[h], Height: int64(h)}
+		if h > 0 {
+			block.PreviousBlockHash = m.chain[h-1]
+		}
+		m.blocks[block.Hash] = block
+	}
+}
+
+// setFail sets whether GetChainInfo fails
+func (m *mockHTTPBlockChain) setFail(fail bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fail = fail
+}
+
+// Do is a mock http request
+func (m *mockHTTPBlockChain) Do(req *http.Request) (*http.Response, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	respond := func(statusCode int, body any) (*http.Response, error) {
+		data, _ := json.Marshal(body)
+		return &http.Response{StatusCode: statusCode, Body: io.NopCloser(strings.NewReader(string(data)))}, nil
+	}
+
+	path := req.URL.Path
+	switch {
+	case strings.HasSuffix(path, "/chain/info"):
+		if m.fail {
+			return respond(http.StatusInternalServerError, nil)
+		}
+		return respond(http.StatusOK, &ChainInfo{BestBlockHash: m.chain[len(m.chain)-1], Blocks: int64(len(m.chain) - 1)})
+	case strings.Contains(path, "/block/height/"):
+		var height int
+		_, _ = fmt.Sscanf(path[strings.LastIndex(path, "/")+1:], "%d", &height)
+		if height >= len(m.chain) {
+			return respond(http.StatusNotFound, nil)
+		}
+		return respond(http.StatusOK, m.blocks[m.chain[height]])
+	case strings.Contains(path, "/block/hash/"):
+		block, ok := m.blocks[path[strings.LastIndex(path, "/")+1:]]
+		if !ok {
+			return respond(http.StatusNotFound, nil)
+		}
+		return respond(http.StatusOK, block)
+	}
+	return respond(http.StatusNotFound, nil)
+}
+
+// nextBlockEvents reads n events, formatted as "connected:a11"
+func nextBlockEvents(t *testing.T, events <-chan *BlockEvent, n int) []string {
+	t.Helper()
+
+	got := make([]string, 0, n)
+	for range n {
+		select {
+		case event, ok := <-events:
+			require.True(t, ok, "channel closed")
+			if event.Type == BlockEventError {
+				got = append(got, fmt.Sprintf("error:%v", event.Err))
+				continue
+			}
+			got = append(got, fmt.Sprintf("%s:%s", event.Type, testChainLabel(event.Block.Hash)))
+		case <-time.After(time.Second):
+			require.Failf(t, "timeout", "got %v", got)
+		}
+	}
+	return got
+}
+
+// TestClient_SubscribeBlocks tests blocks are connected in order, including skipped heights
+func TestClient_SubscribeBlocks(t *testing.T) {
+	t.Parallel()
+
+	t.Run("new blocks", func(t *testing.T) {
+		t.Parallel()
+		mock := newMockHTTPBlockChain(10)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events := newCachedMockClient(mock).SubscribeBlocks(ctx, &BlockSubscriptionOptions{Interval: 5 * time.Millisecond})
+
+		time.Sleep(20 * time.Millisecond) // let the first poll set the baseline at block 10
+		mock.set(11, "a11", "a12", "a13")
+		assert.Equal(t, []string{"connected:a11", "connected:a12", "connected:a13"}, nextBlockEvents(t, events, 3))
+
+		mock.set(14, "a14")
+		assert.Equal(t, []string{"connected:a14"}, nextBlockEvents(t, events, 1))
+
+		cancel()
+		for range events { //nolint:revive // drain until the channel is closed
+		}
+	})
+
+	t.Run("start height", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events := newCachedMockClient(newMockHTTPBlockChain(10)).SubscribeBlocks(ctx, &BlockSubscriptionOptions{
+			Interval: 5 * time.Millisecond, StartHeight: 8,
+		})
+		assert.Equal(t, []string{"connected:a8", "connected:a9", "connected:a10"}, nextBlockEvents(t, events, 3))
+	})
+
+	t.Run("start height above the tip", func(t *testing.T) {
+		t.Parallel()
+		mock := newMockHTTPBlockChain(10)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events := newCachedMockClient(mock).SubscribeBlocks(ctx, &BlockSubscriptionOptions{
+			Interval: 5 * time.Millisecond, StartHeight: 12,
+		})
+		mock.set(11, "a11", "a12", "a13")
+		assert.Equal(t, []string{"connected:a12", "connected:a13"}, nextBlockEvents(t, events, 2))
+	})
+}
+
+// TestClient_SubscribeBlocks_Reorg tests reorgs disconnect the stale blocks before connecting the new ones
+func TestClient_SubscribeBlocks_Reorg(t *testing.T) {
+	t.Parallel()
+
+	t.Run("longer chain", func(t *testing.T) {
+		t.Parallel()
+		mock := newMockHTTPBlockChain(10)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events := newCachedMockClient(mock).SubscribeBlocks(ctx, &BlockSubscriptionOptions{
+			Interval: 5 * time.Millisecond, StartHeight: 10,
+		})
+		assert.Equal(t, []string{"connected:a10"}, nextBlockEvents(t, events, 1))
+
+		mock.set(9, "b9", "b10", "b11")
+		assert.Equal(t, []string{
+			"disconnected:a10", "disconnected:a9", "connected:b9", "connected:b10", "connected:b11",
+		}, nextBlockEvents(t, events, 5))
+	})
+
+	t.Run("same height", func(t *testing.T) {
+		t.Parallel()
+		mock := newMockHTTPBlockChain(10)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events := newCachedMockClient(mock).SubscribeBlocks(ctx, &BlockSubscriptionOptions{
+			Interval: 5 * time.Millisecond, StartHeight: 10,
+		})
+		assert.Equal(t, []string{"connected:a10"}, nextBlockEvents(t, events, 1))
+
+		mock.set(10, "b10")
+		assert.Equal(t, []string{"disconnected:a10", "connected:b10"}, nextBlockEvents(t, events, 2))
+	})
+
+	t.Run("too deep", func(t *testing.T) {
+		t.Parallel()
+		mock := newMockHTTPBlockChain(10)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events := newCachedMockClient(mock).SubscribeBlocks(ctx, &BlockSubscriptionOptions{
+			Interval: 5 * time.Millisecond, MaxReorgDepth: 2, StartHeight: 10,
+		})
+		assert.Equal(t, []string{"connected:a10"}, nextBlockEvents(t, events, 1))
+
+		mock.set(5, testChainLabels("b", 5, 11)...)
+		got := nextBlockEvents(t, events, 3)
+		assert.Equal(t, []string{"disconnected:a10", "disconnected:a9"}, got[:2])
+		assert.Contains(t, got[2], ErrReorgTooDeep.Error())
+
+		// Delivery resumes from the new tip
+		time.Sleep(20 * time.Millisecond) // let the next poll set the baseline at block 11
+		mock.set(12, "b12")
+		assert.Equal(t, []string{"connected:b12"}, nextBlockEvents(t, events, 1))
+	})
+}
+
+// TestClient_SubscribeBlocks_Errors tests failed polls are sent as events and the subscription recovers
+func TestClient_SubscribeBlocks_Errors(t *testing.T) {
+	t.Parallel()
+
+	mock := newMockHTTPBlockChain(10)
+	mock.fail = true
+	ctx, cancel := context.WithCancel(context.Background())
+	events := newCachedMockClient(mock).SubscribeBlocks(ctx, &BlockSubscriptionOptions{
+		Interval: 5 * time.Millisecond, StartHeight: 10,
+	})
+
+	select {
+	case event := <-events:
+		require.Equal(t, BlockEventError, event.Type)
+		require.Error(t, event.Err)
+		assert.Nil(t, event.Block)
+	case <-time.After(time.Second):
+		require.Fail(t, "no error event")
+	}
+
+	mock.setFail(false)
+	for event := range events {
+		if event.Type == BlockEventConnected {
+			assert.Equal(t, testChainHash("a10"), event.Block.Hash)
+			break
+		}
+	}
+
+	cancel()
+	for range events { //nolint:revive // drain until the channel is closed
+	}
+}
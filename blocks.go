@@ -10,8 +10,12 @@ import (
 //
 // For more information: https://docs.whatsonchain.com/#get-by-hash
 func (c *Client) GetBlockByHash(ctx context.Context, hash string) (*BlockInfo, error) {
+	if err := c.validateHash("hash", hash); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/block/hash/%s", hash)
-	return requestAndUnmarshal[BlockInfo](ctx, c, url, http.MethodGet, nil, ErrBlockNotFound)
+	return cachedRequestAndUnmarshal[BlockInfo](ctx, c, CacheEndpointBlock, url, ErrBlockNotFound)
 }
 
 // GetBlockByHeight this endpoint retrieves block details with given block height.
@@ -27,6 +31,10 @@ func (c *Client) GetBlockByHeight(ctx context.Context, height int64) (*BlockInfo
 //
 // For more information: https://docs.whatsonchain.com/#get-block-pages
 func (c *Client) GetBlockPages(ctx context.Context, hash string, page int) (BlockPagesInfo, error) {
+	if err := c.validateHash("hash", hash); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/block/hash/%s/page/%d", hash, page)
 	return requestAndUnmarshalSlice[string](ctx, c, url, http.MethodGet, nil, ErrBlockNotFound)
 }
@@ -35,8 +43,12 @@ func (c *Client) GetBlockPages(ctx context.Context, hash string, page int) (Bloc
 //
 // For more information: https://docs.whatsonchain.com/#get-header-by-hash
 func (c *Client) GetHeaderByHash(ctx context.Context, hash string) (*BlockInfo, error) {
+	if err := c.validateBlockHashOrHeight("hash", hash); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/block/%s/header", hash)
-	return requestAndUnmarshal[BlockInfo](ctx, c, url, http.MethodGet, nil, ErrBlockNotFound)
+	return cachedRequestAndUnmarshal[BlockInfo](ctx, c, CacheEndpointHeader, url, ErrBlockNotFound)
 }
 
 // GetHeaders this endpoint retrieves last 10 block headers.
@@ -55,6 +67,19 @@ func (c *Client) GetHeaderBytesFileLinks(ctx context.Context) (*HeaderBytesResou
 	return requestAndUnmarshal[HeaderBytesResource](ctx, c, url, http.MethodGet, nil, ErrHeadersNotFound)
 }
 
+// GetHeaderBytesFile downloads a header file from a link returned by GetHeaderBytesFileLinks.
+// The uri is requested as given (it may be on another host than the API).
+func (c *Client) GetHeaderBytesFile(ctx context.Context, uri string) ([]byte, error) {
+	resp, _, err := c.request(ctx, uri, http.MethodGet, nil)
+	if err != nil {
+		return nil, notFoundError(err, ErrHeadersNotFound)
+	}
+	if len(resp) == 0 {
+		return nil, ErrHeadersNotFound
+	}
+	return resp, nil
+}
+
 // GetLatestHeaderBytes this endpoint retrieves latest header bytes.
 //
 // For more information: https://docs.whatsonchain.com/#get-latest-headers
@@ -64,12 +89,15 @@ func (c *Client) GetLatestHeaderBytes(ctx context.Context, count int) (string, e
 		path = fmt.Sprintf("%s?count=%d", path, count)
 	}
 	url := c.buildURL(path)
-	resp, err := requestString(ctx, c, url)
+	return requestString(ctx, c, url, ErrHeadersNotFound)
+}
+
+// GetLatestBlockHeaders retrieves the latest headers (see GetLatestHeaderBytes) and parses them.
+// It returns ErrInvalidBlockHeader if the response is not concatenated 80-byte headers.
+func (c *Client) GetLatestBlockHeaders(ctx context.Context, count int) ([]*BlockHeader, error) {
+	headerBytes, err := c.GetLatestHeaderBytes(ctx, count)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	if len(resp) == 0 {
-		return "", ErrHeadersNotFound
-	}
-	return resp, nil
+	return ParseBlockHeadersHex(headerBytes)
 }
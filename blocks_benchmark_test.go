@@ -15,7 +15,7 @@ func (m *mockHTTPBlocksBenchmark) Do(req *http.Request) (*http.Response, error)
 	resp := new(http.Response)
 	resp.StatusCode = http.StatusOK
 
-	blockResponse := `{"hash":"00000000000000000373d17e4f4f8e0f0f3f3e3d3c3b3a39383736353433323","confirmations":100,"size":1234567,"height":700000,"version":536870912,"versionHex":"20000000","merkleroot":"abc123def456","tx":["tx1","tx2","tx3"],"txcount":3,"time":1609459200,"mediantime":1609456000,"nonce":123456789,"bits":"1a012345","difficulty":1234567.89,"chainwork":"00000000000000000000000000000000000000000000000000000000000000ff","previousblockhash":"00000000000000000373d17e4f4f8e0f0f3f3e3d3c3b3a39383736353433322","nextblockhash":"00000000000000000373d17e4f4f8e0f0f3f3e3d3c3b3a39383736353433324","coinbaseTx":{"txid":"coinbase123","hash":"coinbase123","version":1,"size":100,"locktime":0,"vin":[],"vout":[]},"totalFees":1.5,"miner":"Unknown","pages":{"size":3,"uri":["/v1/bsv/main/block/hash/00000000000000000373d17e4f4f8e0f0f3f3e3d3c3b3a39383736353433323/page/0"]}}`
+	blockResponse := `{"hash":"000000000000000000373d17e4f4f8e0f0f3f3e3d3c3b3a39383736353433323","confirmations":100,"size":1234567,"height":700000,"version":536870912,"versionHex":"20000000","merkleroot":"abc123def456","tx":["tx1","tx2","tx3"],"txcount":3,"time":1609459200,"mediantime":1609456000,"nonce":123456789,"bits":"1a012345","difficulty":1234567.89,"chainwork":"00000000000000000000000000000000000000000000000000000000000000ff","previousblockhash":"00000000000000000373d17e4f4f8e0f0f3f3e3d3c3b3a39383736353433322","nextblockhash":"00000000000000000373d17e4f4f8e0f0f3f3e3d3c3b3a39383736353433324","coinbaseTx":{"txid":"coinbase123","hash":"coinbase123","version":1,"size":100,"locktime":0,"vin":[],"vout":[]},"totalFees":1.5,"miner":"Unknown","pages":{"size":3,"uri":["/v1/bsv/main/block/hash/000000000000000000373d17e4f4f8e0f0f3f3e3d3c3b3a39383736353433323/page/0"]}}`
 
 	// Block by hash
 	if strings.Contains(req.URL.String(), "/block/hash/") && !strings.Contains(req.URL.String(), "/page/") && !strings.Contains(req.URL.String(), "/header") {
@@ -75,7 +75,7 @@ func BenchmarkGetBlockByHash(b *testing.B) {
 	)
 
 	ctx := context.Background()
-	blockHash := "00000000000000000373d17e4f4f8e0f0f3f3e3d3c3b3a39383736353433323"
+	blockHash := "000000000000000000373d17e4f4f8e0f0f3f3e3d3c3b3a39383736353433323"
 
 	b.ResetTimer()
 	b.ReportAllocs()
@@ -130,7 +130,7 @@ func BenchmarkGetBlockPages(b *testing.B) {
 	)
 
 	ctx := context.Background()
-	blockHash := "00000000000000000373d17e4f4f8e0f0f3f3e3d3c3b3a39383736353433323"
+	blockHash := "000000000000000000373d17e4f4f8e0f0f3f3e3d3c3b3a39383736353433323"
 
 	tests := []struct {
 		name string
@@ -164,7 +164,7 @@ func BenchmarkGetHeaderByHash(b *testing.B) {
 	)
 
 	ctx := context.Background()
-	blockHash := "00000000000000000373d17e4f4f8e0f0f3f3e3d3c3b3a39383736353433323"
+	blockHash := "000000000000000000373d17e4f4f8e0f0f3f3e3d3c3b3a39383736353433323"
 
 	b.ResetTimer()
 	b.ReportAllocs()
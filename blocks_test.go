@@ -33,13 +33,13 @@ func (m *mockHTTPBlocks) Do(req *http.Request) (*http.Response, error) {
 	}
 
 	// Invalid (by hash) return an error
-	if strings.Contains(req.URL.String(), "hash/invalidBlockHash") {
+	if strings.Contains(req.URL.String(), "hash/"+testHashError) {
 		resp.Body = io.NopCloser(bytes.NewBufferString(""))
 		return resp, ErrBadRequest
 	}
 
 	// Not found
-	if strings.Contains(req.URL.String(), "hash/notFound") {
+	if strings.Contains(req.URL.String(), "hash/"+testHashNotFound) {
 		resp.StatusCode = http.StatusNotFound
 		resp.Body = io.NopCloser(bytes.NewBufferString(""))
 		return resp, nil
@@ -56,13 +56,13 @@ func (m *mockHTTPBlocks) Do(req *http.Request) (*http.Response, error) {
 	}
 
 	// Invalid (by hash) return an error
-	if strings.Contains(req.URL.String(), "invalidBlockHeaderHash/header") {
+	if strings.Contains(req.URL.String(), testHashError+"/header") {
 		resp.Body = io.NopCloser(bytes.NewBufferString(""))
 		return resp, ErrBadRequest
 	}
 
 	// Not found
-	if strings.Contains(req.URL.String(), "notFound/header") {
+	if strings.Contains(req.URL.String(), testHashNotFound+"/header") {
 		resp.StatusCode = http.StatusNotFound
 		resp.Body = io.NopCloser(bytes.NewBufferString(""))
 		return resp, nil
@@ -108,19 +108,19 @@ func (m *mockHTTPBlocks) Do(req *http.Request) (*http.Response, error) {
 	}
 
 	// Invalid (by page) return null
-	if strings.Contains(req.URL.String(), "hash/invalidBlockPage/page") {
+	if strings.Contains(req.URL.String(), "hash/"+testHashInvalid+"/page") {
 		resp.StatusCode = http.StatusOK
 		resp.Body = io.NopCloser(bytes.NewBufferString(`null`))
 	}
 
 	// Invalid (by page) return an error
-	if strings.Contains(req.URL.String(), "hash/error/page") {
+	if strings.Contains(req.URL.String(), "hash/"+testHashError+"/page") {
 		resp.Body = io.NopCloser(bytes.NewBufferString(""))
 		return resp, ErrBadRequest
 	}
 
 	// Not found (by page) return an error
-	if strings.Contains(req.URL.String(), "hash/notFound/page") {
+	if strings.Contains(req.URL.String(), "hash/"+testHashNotFound+"/page") {
 		resp.StatusCode = http.StatusNotFound
 		resp.Body = io.NopCloser(bytes.NewBufferString(""))
 		return resp, nil
@@ -215,8 +215,8 @@ func TestClient_GetBlockByHash(t *testing.T) {
 		statusCode    int
 	}{
 		{"0000000000000000025b8506c83450afe84f0318775a52c7b91ee64aad0d5a23", "0000000000000000025b8506c83450afe84f0318775a52c7b91ee64aad0d5a23", false, http.StatusOK},
-		{"invalidBlockHash", "", true, http.StatusBadRequest},
-		{testMockNotFound, "", true, http.StatusNotFound},
+		{testHashError, "", true, http.StatusBadRequest},
+		{testHashNotFound, "", true, http.StatusNotFound},
 	}
 
 	// Test all
@@ -284,9 +284,9 @@ func TestClient_GetBlockPages(t *testing.T) {
 		statusCode    int
 	}{
 		{"000000000000000000885a4d8e9912f085b42288adc58b3ee5830a7da9f4fef4", "51c4933d986da4c0de51ea8446b7db4aa1753f205c594591a09998b1d05d7cfe", false, http.StatusOK},
-		{"invalidBlockPage", "null", false, http.StatusOK},
-		{testMockError, "", true, http.StatusBadRequest},
-		{testMockNotFound, "", true, http.StatusNotFound},
+		{testHashInvalid, "null", false, http.StatusOK},
+		{testHashError, "", true, http.StatusBadRequest},
+		{testHashNotFound, "", true, http.StatusNotFound},
 	}
 
 	// Test all
@@ -319,8 +319,8 @@ func TestClient_GetHeaderByHash(t *testing.T) {
 		statusCode    int
 	}{
 		{"000000000000000004a288072ebb35e37233f419918f9783d499979cb6ac33eb", "000000000000000004a288072ebb35e37233f419918f9783d499979cb6ac33eb", false, http.StatusOK},
-		{"invalidBlockHeaderHash", "", true, http.StatusBadRequest},
-		{testMockNotFound, "", true, http.StatusNotFound},
+		{testHashError, "", true, http.StatusBadRequest},
+		{testHashNotFound, "", true, http.StatusNotFound},
 	}
 
 	// Test all
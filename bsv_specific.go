@@ -19,6 +19,10 @@ func (c *Client) GetOpReturnData(ctx context.Context, txHash string) (string, er
 		return "", ErrBSVChainRequired
 	}
 
+	if err := c.validateHash("txid", txHash); err != nil {
+		return "", err
+	}
+
 	url := c.buildURL("/tx/%s/opreturn", txHash)
-	return requestString(ctx, c, url)
+	return requestString(ctx, c, url, ErrTransactionNotFound)
 }
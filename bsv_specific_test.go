@@ -3,6 +3,7 @@ package whatsonchain
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
 	"net/http"
 	"strings"
@@ -85,7 +86,7 @@ func TestClient_GetOpReturnData(t *testing.T) {
 	t.Run("error response handling", func(t *testing.T) {
 		client := newMockClientBSV(&mockHTTPOpReturnNotFound{})
 
-		_, err := client.GetOpReturnData(context.Background(), "nonexistent")
+		_, err := client.GetOpReturnData(context.Background(), testHashNotFound)
 		if err == nil {
 			t.Fatal("expected an error, got nil")
 		}
@@ -94,9 +95,9 @@ func TestClient_GetOpReturnData(t *testing.T) {
 	t.Run("empty response handling", func(t *testing.T) {
 		client := newMockClientBSV(&mockHTTPOpReturnEmpty{})
 
-		data, err := client.GetOpReturnData(context.Background(), "empty")
-		if err != nil {
-			t.Fatalf("expected no error, got %v", err)
+		data, err := client.GetOpReturnData(context.Background(), testTxID1)
+		if !errors.Is(err, ErrTransactionNotFound) {
+			t.Fatalf("expected ErrTransactionNotFound, got %v", err)
 		}
 
 		if data != "" {
@@ -107,7 +108,7 @@ func TestClient_GetOpReturnData(t *testing.T) {
 	t.Run("chain restriction - BTC client", func(t *testing.T) {
 		btcClient := newMockClientBTC(&mockHTTPOpReturnValid{})
 
-		_, err := btcClient.GetOpReturnData(context.Background(), "test")
+		_, err := btcClient.GetOpReturnData(context.Background(), testTxID1)
 		if err == nil {
 			t.Fatal("expected an error for BTC chain, got nil")
 		}
@@ -121,7 +122,7 @@ func TestClient_GetOpReturnData(t *testing.T) {
 	t.Run("chain restriction - BSV client", func(t *testing.T) {
 		bsvClient := newMockClientBSV(&mockHTTPOpReturnValid{})
 
-		data, err := bsvClient.GetOpReturnData(context.Background(), "test")
+		data, err := bsvClient.GetOpReturnData(context.Background(), testTxID1)
 		if err != nil {
 			t.Fatalf("BSV client should allow GetOpReturnData, got error: %v", err)
 		}
@@ -0,0 +1,217 @@
+package whatsonchain
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// defaultBulkConcurrency is the default number of batches a bulk Processor fetches at the same time
+const defaultBulkConcurrency = 3
+
+// BulkProgress reports the progress of a bulk Processor after each batch (see WithBulkProgress)
+type BulkProgress struct {
+	Operation    string `json:"operation"`     // the bulk endpoint, e.g. "BulkTransactionDetails"
+	BatchesDone  int    `json:"batches_done"`  // batches finished so far, including failed ones
+	BatchesTotal int    `json:"batches_total"` // batches in the request
+	Failed       int    `json:"failed"`        // batches failed so far
+	ItemsDone    int    `json:"items_done"`    // items in the finished batches
+	ItemsTotal   int    `json:"items_total"`   // items in the request
+}
+
+// BulkProgressFunc is called by a bulk Processor after each batch finishes.
+// Calls are never concurrent, and arrive in the order batches finish (not input order).
+type BulkProgressFunc func(progress BulkProgress)
+
+// WithBulkConcurrency sets how many batches a bulk Processor fetches at the same time (default 3).
+// Every request still waits for the client's rate limiter, so raise the rate limit along with it.
+// Values less than 1 mean one batch at a time.
+//
+// Whatever the concurrency, a Processor returns its results in input order, and a failed batch
+// does not stop the others: it is left out of the results and reported in a *BulkError.
+func WithBulkConcurrency(workers int) ClientOption {
+	return func(c *clientOptions) {
+		c.bulkConcurrency = max(workers, 1)
+	}
+}
+
+// WithBulkProgress sets a callback reporting the progress of the bulk Processors
+func WithBulkProgress(progress BulkProgressFunc) ClientOption {
+	return func(c *clientOptions) {
+		c.bulkProgress = progress
+	}
+}
+
+// bulkRun is how a bulkFanOut runs its batches
+type bulkRun struct {
+	concurrency int
+	operation   string
+	progress    BulkProgressFunc
+}
+
+// bulkRun returns the client's concurrency and progress callback for a bulk operation
+func (c *Client) bulkRun(operation string) bulkRun {
+	c.optionsMu.RLock()
+	defer c.optionsMu.RUnlock()
+	return bulkRun{concurrency: c.options.bulkConcurrency, operation: operation, progress: c.options.bulkProgress}
+}
+
+// BulkBatchError is a batch of a bulk Processor request that failed
+type BulkBatchError struct {
+	Offset int   // index in the input of the first item in the batch
+	Count  int   // number of items in the batch
+	Err    error // underlying error
+}
+
+// Error returns the error message
+func (e *BulkBatchError) Error() string {
+	return fmt.Sprintf("bulk batch of %d from index %d: %s", e.Count, e.Offset, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *BulkBatchError) Unwrap() error {
+	return e.Err
+}
+
+// BulkError is returned by a bulk Processor when one or more batches fail.
+// The results of the other batches are still returned.
+//
+// It unwraps to every *BulkBatchError, so errors.Is, errors.As and IsRateLimited/IsNotFound match
+// the error of any batch. Retry the failed items with input[Offset:Offset+Count].
+type BulkError struct {
+	Batches []*BulkBatchError // the failed batches, in input order
+	Total   int               // number of batches in the request
+}
+
+// Error returns the error message, e.g. "2 of 5 bulk batches failed: bulk batch of 20 from index 0: ..."
+func (e *BulkError) Error() string {
+	if len(e.Batches) == 0 {
+		return fmt.Sprintf("0 of %d bulk batches failed", e.Total)
+	}
+	return fmt.Sprintf("%d of %d bulk batches failed: %s", len(e.Batches), e.Total, e.Batches[0])
+}
+
+// Unwrap returns the batch errors
+func (e *BulkError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Batches))
+	for _, batch := range e.Batches {
+		errs = append(errs, batch)
+	}
+	return errs
+}
+
+// bulkFanOut splits items into batches of size and fetches each one, with up to run.concurrency batches in
+// flight (every request still goes through the client's rate limiter), and merges the results in input order.
+//
+// A failed batch does not stop the others: its results are left out and it is reported in a *BulkError.
+// Batches not started before the context is canceled fail with the context's error.
+func bulkFanOut[T any, S ~[]E, E any](ctx context.Context, run bulkRun, items []T, size int,
+	fetch func(context.Context, []T) (S, error),
+) (S, error) {
+	batches := chunkSlice(items, size)
+	if len(batches) == 0 {
+		return nil, nil
+	}
+
+	results := make([]S, len(batches))
+	errs := make([]error, len(batches))
+	progress := BulkProgress{Operation: run.operation, BatchesTotal: len(batches), ItemsTotal: len(items)}
+	var progressMu sync.Mutex
+	finish := func(i int) {
+		if run.progress == nil {
+			return
+		}
+		progressMu.Lock()
+		defer progressMu.Unlock()
+		progress.BatchesDone++
+		progress.ItemsDone += len(batches[i])
+		if errs[i] != nil {
+			progress.Failed++
+		}
+		run.progress(progress)
+	}
+
+	sem := make(chan struct{}, max(run.concurrency, 1))
+	var wg sync.WaitGroup
+	for i, batch := range batches {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			finish(i)
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			results[i], errs[i] = fetch(ctx, batch)
+			finish(i)
+		}()
+	}
+	wg.Wait()
+
+	var merged S
+	bulkErr := &BulkError{Total: len(batches)}
+	for i, batch := range batches {
+		if errs[i] != nil {
+			bulkErr.Batches = append(bulkErr.Batches, &BulkBatchError{Offset: i * size, Count: len(batch), Err: errs[i]})
+			continue
+		}
+		merged = append(merged, results[i]...)
+	}
+	if len(bulkErr.Batches) > 0 {
+		return merged, bulkErr
+	}
+	return merged, nil
+}
+
+// bulkAddresses runs an address bulk endpoint over any number of addresses (see bulkFanOut)
+func bulkAddresses[S ~[]E, E any](ctx context.Context, c *Client, operation string, list *AddressList,
+	fetch func(context.Context, *AddressList) (S, error),
+) (S, error) {
+	if list == nil {
+		return nil, ErrMissingRequest
+	}
+	if err := c.validateAddresses("addresses", list.Addresses); err != nil {
+		return nil, err
+	}
+	return bulkFanOut(ctx, c.bulkRun(operation), list.Addresses, MaxAddressesForLookup,
+		func(ctx context.Context, batch []string) (S, error) {
+			return fetch(ctx, &AddressList{Addresses: batch})
+		})
+}
+
+// bulkScripts runs a script bulk endpoint over any number of script hashes (see bulkFanOut)
+func bulkScripts[S ~[]E, E any](ctx context.Context, c *Client, operation string, list *ScriptsList,
+	fetch func(context.Context, *ScriptsList) (S, error),
+) (S, error) {
+	if list == nil {
+		return nil, ErrMissingRequest
+	}
+	if err := c.validateHashes("scripts", list.Scripts); err != nil {
+		return nil, err
+	}
+	return bulkFanOut(ctx, c.bulkRun(operation), list.Scripts, MaxScriptsForLookup,
+		func(ctx context.Context, batch []string) (S, error) {
+			return fetch(ctx, &ScriptsList{Scripts: batch})
+		})
+}
+
+// bulkTxIDs runs a transaction bulk endpoint over any number of txids (see bulkFanOut)
+func bulkTxIDs[S ~[]E, E any](ctx context.Context, c *Client, operation string, hashes *TxHashes, size int,
+	fetch func(context.Context, *TxHashes) (S, error),
+) (S, error) {
+	if hashes == nil {
+		return nil, ErrMissingRequest
+	}
+	if err := c.validateHashes("txids", hashes.TxIDs); err != nil {
+		return nil, err
+	}
+	return bulkFanOut(ctx, c.bulkRun(operation), hashes.TxIDs, size,
+		func(ctx context.Context, batch []string) (S, error) {
+			return fetch(ctx, &TxHashes{TxIDs: batch})
+		})
+}
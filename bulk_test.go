@@ -0,0 +1,366 @@
+package whatsonchain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockHTTPBulkEcho answers a bulk request with one record per item in the request, in the same order.
+// String items are echoed as {"address","txid"}, objects as-is. A batch containing fail returns HTTP 404.
+type mockHTTPBulkEcho struct {
+	calls atomic.Int64
+	fail  string
+}
+
+// Do is a mock http request
+func (m *mockHTTPBulkEcho) Do(req *http.Request) (*http.Response, error) {
+	m.calls.Add(1)
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	var request map[string][]json.RawMessage
+	if err = json.Unmarshal(body, &request); err != nil {
+		return nil, err
+	}
+
+	records := make([]json.RawMessage, 0)
+	for _, items := range request {
+		for _, item := range items {
+			var value string
+			if json.Unmarshal(item, &value) == nil {
+				if value == m.fail {
+					return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(""))}, nil
+				}
+				item = json.RawMessage(fmt.Sprintf(`{"address":%q,"txid":%q}`, value, value))
+			}
+			records = append(records, item)
+		}
+	}
+	response, _ := json.Marshal(records)
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(string(response)))}, nil
+}
+
+// testBulkItems returns n distinct transaction ids
+func testBulkItems(n int) []string {
+	items := make([]string, n)
+	for i := range items {
+		items[i] = fmt.Sprintf("%064x", i)
+	}
+	return items
+}
+
+// testBulkAddresses returns n distinct mainnet P2PKH addresses
+func testBulkAddresses(n int) []string {
+	addresses := make([]string, n)
+	for i := range addresses {
+		addresses[i] = base58CheckEncode(append([]byte{0x00}, fmt.Sprintf("%020d", i)...))
+	}
+	return addresses
+}
+
+// TestBulkFanOut tests batches run concurrently and merge in input order
+func TestBulkFanOut(t *testing.T) {
+	t.Parallel()
+
+	t.Run("order and concurrency", func(t *testing.T) {
+		t.Parallel()
+
+		var inFlight, maxInFlight atomic.Int64
+		items := testBulkItems(95)
+		results, err := bulkFanOut(context.Background(), bulkRun{concurrency: 3}, items, 10, func(_ context.Context, batch []string) ([]string, error) {
+			current := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				peak := maxInFlight.Load()
+				if current <= peak || maxInFlight.CompareAndSwap(peak, current) {
+					break
+				}
+			}
+			// Later batches finish first
+			time.Sleep(time.Duration(len(items)-slices.Index(items, batch[0])) * 10 * time.Microsecond)
+			return slices.Clone(batch), nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, items, results)
+		assert.LessOrEqual(t, maxInFlight.Load(), int64(3))
+	})
+
+	t.Run("failed batches", func(t *testing.T) {
+		t.Parallel()
+
+		errBatch := errors.New("batch failed")
+		items := testBulkItems(45)
+		results, err := bulkFanOut(context.Background(), bulkRun{concurrency: 2}, items, 20, func(_ context.Context, batch []string) ([]string, error) {
+			if batch[0] == items[20] {
+				return nil, errBatch
+			}
+			return batch, nil
+		})
+		assert.Equal(t, append(slices.Clone(items[:20]), items[40:]...), results)
+
+		var bulkErr *BulkError
+		require.ErrorAs(t, err, &bulkErr)
+		require.ErrorIs(t, err, errBatch)
+		assert.Equal(t, 3, bulkErr.Total)
+		require.Len(t, bulkErr.Batches, 1)
+		assert.Equal(t, 20, bulkErr.Batches[0].Offset)
+		assert.Equal(t, 20, bulkErr.Batches[0].Count)
+		assert.Equal(t, "1 of 3 bulk batches failed: bulk batch of 20 from index 20: batch failed", err.Error())
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := bulkFanOut(ctx, bulkRun{concurrency: 1}, testBulkItems(100), 10, func(ctx context.Context, batch []string) ([]string, error) {
+			return batch, ctx.Err()
+		})
+		var bulkErr *BulkError
+		require.ErrorAs(t, err, &bulkErr)
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Len(t, bulkErr.Batches, 10)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		results, err := bulkFanOut(context.Background(), bulkRun{concurrency: 3}, nil, 20, func(context.Context, []string) ([]string, error) {
+			t.Error("fetch called for no items")
+			return nil, nil
+		})
+		require.NoError(t, err)
+		assert.Nil(t, results)
+	})
+}
+
+// TestBulkError tests the message and unwrapping of a BulkError
+func TestBulkError(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "0 of 2 bulk batches failed", (&BulkError{Total: 2}).Error())
+
+	err := error(&BulkError{Total: 3, Batches: []*BulkBatchError{
+		{Offset: 0, Count: 20, Err: &APIError{StatusCode: http.StatusTooManyRequests}},
+		{Offset: 40, Count: 5, Err: ErrAddressNotFound},
+	}})
+	assert.True(t, IsRateLimited(err))
+	assert.True(t, IsNotFound(err))
+	require.ErrorIs(t, err, ErrRequestFailed)
+
+	var batchErr *BulkBatchError
+	require.ErrorAs(t, err, &batchErr)
+	assert.Equal(t, 0, batchErr.Offset)
+}
+
+// TestClient_BulkProcessors tests the Processor variants split, fetch and merge any number of items
+func TestClient_BulkProcessors(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	items := testBulkItems(45)
+
+	t.Run("addresses", func(t *testing.T) {
+		t.Parallel()
+
+		addresses := testBulkAddresses(45)
+		mock := &mockHTTPBulkEcho{}
+		client := newCachedMockClient(mock)
+		balances, err := client.BulkAddressConfirmedBalanceProcessor(ctx, &AddressList{Addresses: addresses})
+		require.NoError(t, err)
+		require.Len(t, balances, len(addresses))
+		for i, balance := range balances {
+			assert.Equal(t, addresses[i], balance.Address)
+		}
+		assert.Equal(t, int64(3), mock.calls.Load())
+	})
+
+	t.Run("transaction status", func(t *testing.T) {
+		t.Parallel()
+
+		mock := &mockHTTPBulkEcho{fail: items[30]}
+		client := newCachedMockClient(mock)
+		statuses, err := client.BulkTransactionStatusProcessor(ctx, &TxHashes{TxIDs: items})
+		require.ErrorIs(t, err, ErrTransactionNotFound)
+		var bulkErr *BulkError
+		require.ErrorAs(t, err, &bulkErr)
+		require.Len(t, bulkErr.Batches, 1)
+		assert.Equal(t, 20, bulkErr.Batches[0].Offset)
+
+		// The other batches are still returned, in order
+		require.Len(t, statuses, 25)
+		assert.Equal(t, items[19], statuses[19].TxID)
+		assert.Equal(t, items[40], statuses[20].TxID)
+	})
+
+	t.Run("spent outputs", func(t *testing.T) {
+		t.Parallel()
+
+		utxos := make([]BulkSpentUTXO, 0, 25)
+		for i := 0; i < 25; i++ {
+			utxos = append(utxos, BulkSpentUTXO{TxID: testTxID1, Vout: i})
+		}
+		mock := &mockHTTPBulkEcho{}
+		client := newCachedMockClient(mock)
+		spent, err := client.BulkSpentOutputsProcessor(ctx, &BulkSpentOutputRequest{UTXOs: utxos})
+		require.NoError(t, err)
+		require.Len(t, spent, 25)
+		assert.Equal(t, 24, spent[24].Vout)
+		assert.Equal(t, int64(2), mock.calls.Load())
+	})
+
+	t.Run("missing request", func(t *testing.T) {
+		t.Parallel()
+
+		client := newCachedMockClient(&mockHTTPBulkEcho{})
+		_, err := client.BulkScriptConfirmedUTXOsProcessor(ctx, nil)
+		require.ErrorIs(t, err, ErrMissingRequest)
+		_, err = client.BulkAddressHistoryProcessor(ctx, nil)
+		require.ErrorIs(t, err, ErrMissingRequest)
+		_, err = client.BulkTransactionStatusProcessor(ctx, nil)
+		require.ErrorIs(t, err, ErrMissingRequest)
+		_, err = client.BulkRawTransactionOutputDataProcessor(ctx, nil)
+		require.ErrorIs(t, err, ErrMissingRequest)
+		_, err = client.BulkSpentOutputsProcessor(ctx, nil)
+		require.ErrorIs(t, err, ErrMissingRequest)
+	})
+
+	t.Run("invalid input is checked before any batch", func(t *testing.T) {
+		t.Parallel()
+
+		mock := &mockHTTPBulkEcho{}
+		client := newCachedMockClient(mock)
+		scripts := slices.Repeat([]string{testScriptHash1}, 30)
+		scripts[25] = "abc"
+		_, err := client.BulkScriptConfirmedHistoryProcessor(ctx, &ScriptsList{Scripts: scripts})
+		requireInvalidInput(t, err, "scripts[25]")
+		_, err = client.BulkRawTransactionOutputDataProcessor(ctx, &BulkRawOutputRequest{TxIDs: []BulkRawOutputTxID{{TxID: "abc"}}})
+		requireInvalidInput(t, err, "txids[0].txid")
+		assert.Zero(t, mock.calls.Load())
+	})
+}
+
+// TestClient_BulkProcessors_RateLimit tests concurrent batches still share the client's rate limiter
+func TestClient_BulkProcessors_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var times []time.Time
+	client := newCachedMockClient(&mockHTTPFunc{fn: func(*http.Request) (*http.Response, error) {
+		mu.Lock()
+		times = append(times, time.Now())
+		mu.Unlock()
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`[{}]`))}, nil
+	}}, WithRateLimit(20), WithRateBurst(1))
+
+	_, err := client.BulkAddressUnconfirmedUTXOsProcessor(context.Background(), &AddressList{Addresses: testBulkAddresses(100)})
+	require.NoError(t, err)
+	require.Len(t, times, 5)
+	slices.SortFunc(times, func(a, b time.Time) int { return a.Compare(b) })
+	assert.GreaterOrEqual(t, times[4].Sub(times[0]), 150*time.Millisecond)
+}
+
+// TestWithBulkConcurrency tests the worker count bounds the batches in flight
+func TestWithBulkConcurrency(t *testing.T) {
+	t.Parallel()
+
+	for _, workers := range []int{1, 4} {
+		t.Run(fmt.Sprint(workers), func(t *testing.T) {
+			t.Parallel()
+
+			var inFlight, maxInFlight atomic.Int64
+			client := newCachedMockClient(&mockHTTPFunc{fn: func(*http.Request) (*http.Response, error) {
+				current := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					peak := maxInFlight.Load()
+					if current <= peak || maxInFlight.CompareAndSwap(peak, current) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`[{"txid":"a"}]`))}, nil
+			}}, WithRateLimit(1000), WithBulkConcurrency(workers))
+
+			txList, err := client.BulkTransactionDetailsProcessor(context.Background(), &TxHashes{TxIDs: testBulkItems(200)})
+			require.NoError(t, err)
+			assert.Len(t, txList, 10)
+			assert.LessOrEqual(t, maxInFlight.Load(), int64(workers))
+			if workers > 1 {
+				assert.Greater(t, maxInFlight.Load(), int64(1))
+			}
+		})
+	}
+
+	options := defaultClientOptions()
+	assert.Equal(t, defaultBulkConcurrency, options.bulkConcurrency)
+	WithBulkConcurrency(0)(options)
+	assert.Equal(t, 1, options.bulkConcurrency)
+}
+
+// TestWithBulkProgress tests the progress callback is called after every batch
+func TestWithBulkProgress(t *testing.T) {
+	t.Parallel()
+
+	items := testBulkItems(65)
+	var updates []BulkProgress
+	client := newCachedMockClient(&mockHTTPBulkEcho{fail: items[50]}, WithBulkConcurrency(4), WithBulkProgress(func(progress BulkProgress) {
+		updates = append(updates, progress) // calls are serialized
+	}))
+
+	txList, err := client.BulkRawTransactionDataProcessor(context.Background(), &TxHashes{TxIDs: items})
+	require.ErrorIs(t, err, ErrTransactionNotFound)
+	assert.Len(t, txList, 45)
+	assert.Equal(t, items[64], txList[44].TxID)
+
+	require.Len(t, updates, 4)
+	for i, update := range updates {
+		assert.Equal(t, "BulkRawTransactionData", update.Operation)
+		assert.Equal(t, i+1, update.BatchesDone)
+		assert.Equal(t, 4, update.BatchesTotal)
+		assert.Equal(t, 65, update.ItemsTotal)
+	}
+	last := updates[3]
+	assert.Equal(t, 65, last.ItemsDone)
+	assert.Equal(t, 1, last.Failed)
+}
+
+// TestClient_AddressUnspentTransactionDetails_PartialFailure tests UTXOs are returned when some details fail
+func TestClient_AddressUnspentTransactionDetails_PartialFailure(t *testing.T) {
+	t.Parallel()
+
+	utxos := make([]string, 0, 30)
+	for i := 0; i < 30; i++ {
+		utxos = append(utxos, fmt.Sprintf(`{"tx_hash":"%064x","tx_pos":0,"value":1}`, i))
+	}
+	echo := &mockHTTPBulkEcho{fail: fmt.Sprintf("%064x", 25)}
+	client := newCachedMockClient(&mockHTTPFunc{fn: func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodGet {
+			body := `{"result":[` + strings.Join(utxos, ",") + `]}`
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+		}
+		return echo.Do(req)
+	}})
+
+	history, err := client.AddressUnspentTransactionDetails(context.Background(), testAddress1, 0)
+	var bulkErr *BulkError
+	require.ErrorAs(t, err, &bulkErr)
+	assert.Equal(t, 20, bulkErr.Batches[0].Offset)
+	require.Len(t, history, 30)
+	assert.NotNil(t, history[19].Info)
+	assert.Nil(t, history[20].Info)
+}
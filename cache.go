@@ -0,0 +1,203 @@
+package whatsonchain
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"time"
+)
+
+// Cache is a pluggable response cache used for immutable endpoints.
+//
+// Keys are the fully-built request URLs (see buildURL) and values are the raw
+// response bodies. Implementations must be safe for concurrent use. A ttl of
+// zero means the entry never expires.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+	Delete(key string)
+}
+
+// CacheEndpoint identifies an endpoint whose responses can be cached
+type CacheEndpoint string
+
+const (
+	// CacheEndpointBlock is for GetBlockByHash (a cached block's confirmations are a snapshot, see DefaultCacheRules)
+	CacheEndpointBlock CacheEndpoint = "block"
+
+	// CacheEndpointHeader is for GetHeaderByHash (a cached header's confirmations are a snapshot, see DefaultCacheRules)
+	CacheEndpointHeader CacheEndpoint = "header"
+
+	// CacheEndpointMerkleProofTSC is for GetMerkleProofTSC
+	CacheEndpointMerkleProofTSC CacheEndpoint = "merkle_proof_tsc"
+
+	// CacheEndpointRawTransaction is for GetRawTransactionData
+	CacheEndpointRawTransaction CacheEndpoint = "raw_transaction"
+
+	// CacheEndpointTransaction is for GetTxByHash (a cached tx's confirmations are a snapshot, see DefaultCacheRules)
+	CacheEndpointTransaction CacheEndpoint = "transaction"
+
+	// CacheEndpointTransactionBinary is for GetTransactionAsBinary
+	CacheEndpointTransactionBinary CacheEndpoint = "transaction_binary"
+
+	// defaultCacheMinConfirmations is how deep a tx, block or merkle proof must be before it is cached
+	defaultCacheMinConfirmations int64 = 6
+
+	// defaultCacheConfirmationsTTL is how long a response carrying a confirmations count is cached (about one block)
+	defaultCacheConfirmationsTTL = 10 * time.Minute
+)
+
+// CacheRule controls how responses from a single endpoint are cached
+type CacheRule struct {
+	Disabled         bool          // skip the cache for this endpoint entirely
+	MinConfirmations int64         // only cache responses reporting at least this many confirmations (0 = no check)
+	TTL              time.Duration // how long an entry lives (0 = never expires)
+}
+
+// CacheStats is a snapshot of the client's response cache counters
+type CacheStats struct {
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+}
+
+// DefaultCacheRules returns the default per-endpoint cache rules.
+//
+// Transactions, blocks and headers report a "confirmations" count and are only
+// cached once they are at least 6 blocks deep. The cached count is a snapshot that
+// falls behind as blocks are mined, so these entries expire after 10 minutes (about
+// one block). Merkle proofs carry no confirmation count, so the header of their
+// target block is fetched to apply the same check; the proof itself never changes.
+// Raw transaction data is keyed by txid and never changes, so it is cached without a check.
+func DefaultCacheRules() map[CacheEndpoint]CacheRule {
+	return map[CacheEndpoint]CacheRule{
+		CacheEndpointBlock:             {MinConfirmations: defaultCacheMinConfirmations, TTL: defaultCacheConfirmationsTTL},
+		CacheEndpointHeader:            {MinConfirmations: defaultCacheMinConfirmations, TTL: defaultCacheConfirmationsTTL},
+		CacheEndpointMerkleProofTSC:    {MinConfirmations: defaultCacheMinConfirmations},
+		CacheEndpointRawTransaction:    {},
+		CacheEndpointTransaction:       {MinConfirmations: defaultCacheMinConfirmations, TTL: defaultCacheConfirmationsTTL},
+		CacheEndpointTransactionBinary: {},
+	}
+}
+
+// WithCache sets the response cache used for immutable endpoints
+func WithCache(cache Cache) ClientOption {
+	return func(c *clientOptions) {
+		c.cache = cache
+	}
+}
+
+// WithCacheRule overrides the cache rule for a single endpoint
+func WithCacheRule(endpoint CacheEndpoint, rule CacheRule) ClientOption {
+	return func(c *clientOptions) {
+		if c.cacheRules == nil {
+			c.cacheRules = DefaultCacheRules()
+		}
+		c.cacheRules[endpoint] = rule
+	}
+}
+
+// CacheStats returns the current cache hit/miss counters
+func (c *Client) CacheStats() CacheStats {
+	return CacheStats{
+		Hits:   c.cacheHits.Load(),
+		Misses: c.cacheMisses.Load(),
+	}
+}
+
+// cachedRequest performs a GET request through the response cache (if configured).
+// Only successful, non-empty responses that satisfy the endpoint's rule are stored.
+func (c *Client) cachedRequest(ctx context.Context, endpoint CacheEndpoint, url string) ([]byte, int, error) {
+	c.optionsMu.RLock()
+	cache := c.options.cache
+	rule, ok := c.options.cacheRules[endpoint]
+	c.optionsMu.RUnlock()
+
+	// No cache configured (or disabled for this endpoint)
+	if cache == nil || !ok || rule.Disabled {
+		return c.request(ctx, url, http.MethodGet, nil)
+	}
+
+	// Serve from the cache if we can
+	if body, found := cache.Get(url); found {
+		c.cacheHits.Add(1)
+		return body, http.StatusOK, nil
+	}
+	c.cacheMisses.Add(1)
+
+	// Fire the request
+	body, statusCode, err := c.request(ctx, url, http.MethodGet, nil)
+	if err != nil || statusCode != http.StatusOK || len(body) == 0 {
+		return body, statusCode, err
+	}
+
+	// Store the response if it is deep enough in the chain
+	if rule.MinConfirmations <= 0 || c.cacheConfirmations(ctx, endpoint, body) >= rule.MinConfirmations {
+		cache.Set(url, body, rule.TTL)
+	}
+
+	return body, statusCode, nil
+}
+
+// cacheConfirmations returns how deep a response is in the chain: its "confirmations" field, or for
+// merkle proofs (which have none) the confirmations of the shallowest block they target (0 if unknown)
+func (c *Client) cacheConfirmations(ctx context.Context, endpoint CacheEndpoint, body []byte) int64 {
+	if endpoint != CacheEndpointMerkleProofTSC {
+		return responseConfirmations(body)
+	}
+
+	var proofs []*MerkleTSCInfo
+	if err := json.Unmarshal(body, &proofs); err != nil || len(proofs) == 0 {
+		return 0
+	}
+	confirmations := int64(math.MaxInt64)
+	for _, proof := range proofs {
+		if proof == nil {
+			return 0
+		}
+		header, err := c.GetHeaderByHash(ctx, proof.Target)
+		if err != nil {
+			return 0
+		}
+		confirmations = min(confirmations, header.Confirmations)
+	}
+	return confirmations
+}
+
+// responseConfirmations extracts the top-level "confirmations" field from a JSON response
+func responseConfirmations(body []byte) int64 {
+	var resp struct {
+		Confirmations int64 `json:"confirmations"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return 0
+	}
+	return resp.Confirmations
+}
+
+// cachedRequestAndUnmarshal is requestAndUnmarshal for GET endpoints served through the response cache
+func cachedRequestAndUnmarshal[T any](ctx context.Context, c *Client, endpoint CacheEndpoint, url string, emptyErr error) (*T, error) {
+	resp, _, err := c.cachedRequest(ctx, endpoint, url)
+	if err != nil {
+		return nil, notFoundError(err, emptyErr)
+	}
+	return unmarshalResponse[T](resp, emptyErr)
+}
+
+// cachedRequestAndUnmarshalSlice is requestAndUnmarshalSlice for GET endpoints served through the response cache
+func cachedRequestAndUnmarshalSlice[T any](ctx context.Context, c *Client, endpoint CacheEndpoint, url string, emptyErr error) ([]T, error) {
+	resp, _, err := c.cachedRequest(ctx, endpoint, url)
+	if err != nil {
+		return nil, notFoundError(err, emptyErr)
+	}
+	return unmarshalSliceResponse[T](resp, emptyErr)
+}
+
+// cachedRequestString is requestString for GET endpoints served through the response cache
+func cachedRequestString(ctx context.Context, c *Client, endpoint CacheEndpoint, url string, emptyErr error) (string, error) {
+	resp, _, err := c.cachedRequest(ctx, endpoint, url)
+	if err != nil {
+		return "", notFoundError(err, emptyErr)
+	}
+	return stringResponse(resp, emptyErr)
+}
@@ -0,0 +1,102 @@
+package whatsonchain
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// fileCacheExtension is the extension used for cache entry files
+	fileCacheExtension = ".cache"
+
+	// fileCacheHeaderSize is the size of the expiry header written before each value
+	fileCacheHeaderSize = 8
+)
+
+// FileCache is a filesystem-backed implementation of Cache.
+//
+// Each entry is written to its own file named after the SHA-256 of the key. The first
+// 8 bytes of the file hold the expiry (Unix nanoseconds, 0 = never) followed by the value.
+// Errors are treated as cache misses; the cache is best-effort by design.
+type FileCache struct {
+	dir string
+}
+
+// NewFileCache creates a new filesystem cache rooted at dir, creating it if needed
+func NewFileCache(dir string) (*FileCache, error) {
+	if dir == "" {
+		return nil, ErrInvalidCacheDirectory
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, ErrInvalidCacheDirectory
+	}
+	return &FileCache{dir: dir}, nil
+}
+
+// Get returns the cached value for key, if present and not expired
+func (f *FileCache) Get(key string) ([]byte, bool) {
+	path := f.path(key)
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is derived from a hash inside the cache directory
+	if err != nil || len(data) < fileCacheHeaderSize {
+		return nil, false
+	}
+
+	expiresAt := int64(binary.BigEndian.Uint64(data[:fileCacheHeaderSize])) //nolint:gosec // G115: written by Set from a valid int64
+	if expiresAt != 0 && time.Now().UnixNano() > expiresAt {
+		_ = os.Remove(path)
+		return nil, false
+	}
+
+	return data[fileCacheHeaderSize:], true
+}
+
+// Set writes value under key; the write is atomic so concurrent readers never see a partial entry
+func (f *FileCache) Set(key string, value []byte, ttl time.Duration) {
+	var expiresAt int64
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl).UnixNano()
+	}
+
+	data := make([]byte, fileCacheHeaderSize+len(value))
+	binary.BigEndian.PutUint64(data[:fileCacheHeaderSize], uint64(expiresAt)) //nolint:gosec // G115: expiry is never negative
+	copy(data[fileCacheHeaderSize:], value)
+
+	tmp, err := os.CreateTemp(f.dir, "tmp-*")
+	if err != nil {
+		return
+	}
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	if err = tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	if err = os.Rename(tmp.Name(), f.path(key)); err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+// Delete removes key from the cache
+func (f *FileCache) Delete(key string) {
+	_ = os.Remove(f.path(key))
+}
+
+// path returns the file path for a cache key
+func (f *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(f.dir, hex.EncodeToString(sum[:])+fileCacheExtension)
+}
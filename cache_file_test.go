@@ -0,0 +1,100 @@
+package whatsonchain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewFileCache tests creating a new file cache
+func TestNewFileCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("creates directory", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "nested", "cache")
+		c, err := NewFileCache(dir)
+		require.NoError(t, err)
+		require.NotNil(t, c)
+
+		info, err := os.Stat(dir)
+		require.NoError(t, err)
+		assert.True(t, info.IsDir())
+	})
+
+	t.Run("empty directory", func(t *testing.T) {
+		c, err := NewFileCache("")
+		require.ErrorIs(t, err, ErrInvalidCacheDirectory)
+		assert.Nil(t, c)
+	})
+
+	t.Run("path is a file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "file")
+		require.NoError(t, os.WriteFile(file, []byte("x"), 0o600))
+
+		c, err := NewFileCache(file)
+		require.Error(t, err)
+		assert.Nil(t, c)
+	})
+}
+
+// TestFileCache_GetSetDelete tests the basic cache operations
+func TestFileCache_GetSetDelete(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewFileCache(t.TempDir())
+	require.NoError(t, err)
+
+	key := "https://api.whatsonchain.com/v1/bsv/main/tx/hash/abc"
+
+	_, ok := c.Get(key)
+	assert.False(t, ok)
+
+	c.Set(key, []byte(`{"txid":"abc"}`), 0)
+	got, ok := c.Get(key)
+	require.True(t, ok)
+	assert.JSONEq(t, `{"txid":"abc"}`, string(got))
+
+	// Survives a new instance on the same directory
+	c2, err := NewFileCache(c.dir)
+	require.NoError(t, err)
+	got, ok = c2.Get(key)
+	require.True(t, ok)
+	assert.JSONEq(t, `{"txid":"abc"}`, string(got))
+
+	c.Delete(key)
+	_, ok = c.Get(key)
+	assert.False(t, ok)
+}
+
+// TestFileCache_Expiry tests that expired entries are removed
+func TestFileCache_Expiry(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewFileCache(t.TempDir())
+	require.NoError(t, err)
+
+	c.Set("short", []byte("value"), 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+
+	_, ok := c.Get("short")
+	assert.False(t, ok)
+
+	_, err = os.Stat(c.path("short"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+// TestFileCache_CorruptEntry tests that a truncated entry is treated as a miss
+func TestFileCache_CorruptEntry(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewFileCache(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(c.path("bad"), []byte{0x01}, 0o600))
+	_, ok := c.Get("bad")
+	assert.False(t, ok)
+}
@@ -0,0 +1,112 @@
+package whatsonchain
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// defaultMemoryCacheCapacity is the default number of entries held by a MemoryCache
+const defaultMemoryCacheCapacity = 1000
+
+// memoryCacheEntry is a single entry in the MemoryCache
+type memoryCacheEntry struct {
+	expiresAt time.Time // zero means never expires
+	key       string
+	value     []byte
+}
+
+// MemoryCache is an in-memory, size-bounded LRU implementation of Cache
+type MemoryCache struct {
+	capacity int                      // maximum number of entries
+	entries  map[string]*list.Element // key -> element in order
+	mu       sync.Mutex               // protects entries and order
+	order    *list.List               // most recently used at the front
+}
+
+// NewMemoryCache creates a new in-memory LRU cache holding up to capacity entries.
+// Values less than 1 use the default capacity (1000).
+func NewMemoryCache(capacity int) *MemoryCache {
+	if capacity < 1 {
+		capacity = defaultMemoryCacheCapacity
+	}
+	return &MemoryCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+// Get returns a copy of the cached value for key, if present and not expired
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*memoryCacheEntry)
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		m.removeElement(elem)
+		return nil, false
+	}
+
+	m.order.MoveToFront(elem)
+	return append([]byte(nil), entry.value...), true
+}
+
+// Set stores a copy of value under key, evicting the least recently used entry if full
+func (m *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+	value = append([]byte(nil), value...)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Update in place
+	if elem, ok := m.entries[key]; ok {
+		entry := elem.Value.(*memoryCacheEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		m.order.MoveToFront(elem)
+		return
+	}
+
+	// Evict the oldest entries until there is room
+	for m.order.Len() >= m.capacity {
+		m.removeElement(m.order.Back())
+	}
+
+	m.entries[key] = m.order.PushFront(&memoryCacheEntry{
+		expiresAt: expiresAt,
+		key:       key,
+		value:     value,
+	})
+}
+
+// Delete removes key from the cache
+func (m *MemoryCache) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if elem, ok := m.entries[key]; ok {
+		m.removeElement(elem)
+	}
+}
+
+// Len returns the number of entries currently in the cache (including expired entries not yet evicted)
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+// removeElement removes an element from both the list and the map (caller must hold mu)
+func (m *MemoryCache) removeElement(elem *list.Element) {
+	entry := m.order.Remove(elem).(*memoryCacheEntry)
+	delete(m.entries, entry.key)
+}
@@ -0,0 +1,126 @@
+package whatsonchain
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewMemoryCache tests creating a new memory cache
+func TestNewMemoryCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("custom capacity", func(t *testing.T) {
+		c := NewMemoryCache(5)
+		require.NotNil(t, c)
+		assert.Equal(t, 5, c.capacity)
+		assert.Equal(t, 0, c.Len())
+	})
+
+	t.Run("invalid capacity uses default", func(t *testing.T) {
+		c := NewMemoryCache(0)
+		assert.Equal(t, defaultMemoryCacheCapacity, c.capacity)
+	})
+}
+
+// TestMemoryCache_GetSetDelete tests the basic cache operations
+func TestMemoryCache_GetSetDelete(t *testing.T) {
+	t.Parallel()
+
+	c := NewMemoryCache(10)
+
+	_, ok := c.Get("missing")
+	assert.False(t, ok)
+
+	c.Set("key", []byte("value"), 0)
+	got, ok := c.Get("key")
+	require.True(t, ok)
+	assert.Equal(t, []byte("value"), got)
+
+	// Returned values are copies
+	got[0] = 'X'
+	got, ok = c.Get("key")
+	require.True(t, ok)
+	assert.Equal(t, []byte("value"), got)
+
+	// Overwrite
+	c.Set("key", []byte("other"), 0)
+	got, ok = c.Get("key")
+	require.True(t, ok)
+	assert.Equal(t, []byte("other"), got)
+	assert.Equal(t, 1, c.Len())
+
+	c.Delete("key")
+	_, ok = c.Get("key")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len())
+
+	// Deleting a missing key is a no-op
+	c.Delete("key")
+}
+
+// TestMemoryCache_Expiry tests that expired entries are not returned
+func TestMemoryCache_Expiry(t *testing.T) {
+	t.Parallel()
+
+	c := NewMemoryCache(10)
+	c.Set("short", []byte("value"), 10*time.Millisecond)
+	c.Set("forever", []byte("value"), 0)
+
+	time.Sleep(20 * time.Millisecond)
+
+	_, ok := c.Get("short")
+	assert.False(t, ok)
+	_, ok = c.Get("forever")
+	assert.True(t, ok)
+	assert.Equal(t, 1, c.Len())
+}
+
+// TestMemoryCache_LRUEviction tests that the least recently used entry is evicted
+func TestMemoryCache_LRUEviction(t *testing.T) {
+	t.Parallel()
+
+	c := NewMemoryCache(2)
+	c.Set("a", []byte("1"), 0)
+	c.Set("b", []byte("2"), 0)
+
+	// Touch "a" so "b" becomes the least recently used
+	_, ok := c.Get("a")
+	require.True(t, ok)
+
+	c.Set("c", []byte("3"), 0)
+	assert.Equal(t, 2, c.Len())
+
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+	_, ok = c.Get("a")
+	assert.True(t, ok)
+	_, ok = c.Get("c")
+	assert.True(t, ok)
+}
+
+// TestMemoryCache_Concurrent tests concurrent access to the cache
+func TestMemoryCache_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	c := NewMemoryCache(50)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := fmt.Sprintf("key-%d-%d", i, j)
+				c.Set(key, []byte(key), 0)
+				_, _ = c.Get(key)
+				c.Delete(key)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, c.Len(), 50)
+}
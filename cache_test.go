@@ -0,0 +1,244 @@
+package whatsonchain
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockHTTPCacheable is a mock that counts requests and returns a fixed body per URL suffix
+type mockHTTPCacheable struct {
+	bodies     map[string]string
+	calls      atomic.Int64
+	statusCode int
+}
+
+// Do is a mock http request
+func (m *mockHTTPCacheable) Do(req *http.Request) (*http.Response, error) {
+	m.calls.Add(1)
+	statusCode := m.statusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	for suffix, body := range m.bodies {
+		if strings.HasSuffix(req.URL.Path, suffix) {
+			return &http.Response{StatusCode: statusCode, Body: io.NopCloser(strings.NewReader(body))}, nil
+		}
+	}
+	return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(""))}, nil
+}
+
+// newCachedMockClient returns a client with a memory cache for testing
+func newCachedMockClient(httpClient HTTPInterface, opts ...ClientOption) ClientInterface {
+	opts = append([]ClientOption{
+		WithNetwork(NetworkMain),
+		WithHTTPClient(httpClient),
+		WithRateLimit(100),
+		WithCache(NewMemoryCache(10)),
+	}, opts...)
+	client, _ := NewClient(context.Background(), opts...)
+	return client
+}
+
+// TestDefaultCacheRules tests the default cache rules
+func TestDefaultCacheRules(t *testing.T) {
+	t.Parallel()
+
+	rules := DefaultCacheRules()
+	require.Len(t, rules, 6)
+	assert.Equal(t, defaultCacheMinConfirmations, rules[CacheEndpointTransaction].MinConfirmations)
+	assert.Equal(t, defaultCacheMinConfirmations, rules[CacheEndpointBlock].MinConfirmations)
+	assert.Equal(t, defaultCacheMinConfirmations, rules[CacheEndpointHeader].MinConfirmations)
+	assert.Equal(t, defaultCacheMinConfirmations, rules[CacheEndpointMerkleProofTSC].MinConfirmations)
+	assert.Zero(t, rules[CacheEndpointRawTransaction].MinConfirmations)
+	assert.Zero(t, rules[CacheEndpointTransactionBinary].MinConfirmations)
+
+	// Confirmation counts go stale, the proof and raw data do not
+	assert.Equal(t, defaultCacheConfirmationsTTL, rules[CacheEndpointTransaction].TTL)
+	assert.Equal(t, defaultCacheConfirmationsTTL, rules[CacheEndpointBlock].TTL)
+	assert.Equal(t, defaultCacheConfirmationsTTL, rules[CacheEndpointHeader].TTL)
+	assert.Zero(t, rules[CacheEndpointMerkleProofTSC].TTL)
+	assert.Zero(t, rules[CacheEndpointRawTransaction].TTL)
+
+	// Each call returns a fresh map
+	rules[CacheEndpointTransaction] = CacheRule{Disabled: true}
+	assert.False(t, DefaultCacheRules()[CacheEndpointTransaction].Disabled)
+}
+
+// TestClient_Cache_NoCacheConfigured tests that requests are not cached without WithCache
+func TestClient_Cache_NoCacheConfigured(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockHTTPCacheable{bodies: map[string]string{"/tx/hash/" + testTxID1: `{"txid":"` + testTxID1 + `","confirmations":100}`}}
+	client := newMockClient(mock)
+
+	for i := 0; i < 2; i++ {
+		tx, err := client.GetTxByHash(context.Background(), testTxID1)
+		require.NoError(t, err)
+		assert.Equal(t, testTxID1, tx.TxID)
+	}
+	assert.Equal(t, int64(2), mock.calls.Load())
+	assert.Equal(t, CacheStats{}, client.CacheStats())
+}
+
+// TestClient_Cache_ConfirmedTransaction tests that deep transactions are served from the cache
+func TestClient_Cache_ConfirmedTransaction(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockHTTPCacheable{bodies: map[string]string{"/tx/hash/" + testTxID1: `{"txid":"` + testTxID1 + `","confirmations":100}`}}
+	client := newCachedMockClient(mock)
+
+	for i := 0; i < 3; i++ {
+		tx, err := client.GetTxByHash(context.Background(), testTxID1)
+		require.NoError(t, err)
+		assert.Equal(t, testTxID1, tx.TxID)
+		assert.Equal(t, int64(100), tx.Confirmations)
+	}
+	assert.Equal(t, int64(1), mock.calls.Load())
+	assert.Equal(t, CacheStats{Hits: 2, Misses: 1}, client.CacheStats())
+}
+
+// TestClient_Cache_ShallowTransaction tests that transactions below the confirmation threshold are not cached
+func TestClient_Cache_ShallowTransaction(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockHTTPCacheable{bodies: map[string]string{"/tx/hash/" + testTxID1: `{"txid":"` + testTxID1 + `","confirmations":2}`}}
+	client := newCachedMockClient(mock)
+
+	for i := 0; i < 2; i++ {
+		_, err := client.GetTxByHash(context.Background(), testTxID1)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int64(2), mock.calls.Load())
+	assert.Equal(t, CacheStats{Hits: 0, Misses: 2}, client.CacheStats())
+}
+
+// TestClient_Cache_CustomRule tests overriding a rule with WithCacheRule
+func TestClient_Cache_CustomRule(t *testing.T) {
+	t.Parallel()
+
+	t.Run("lower confirmation threshold", func(t *testing.T) {
+		mock := &mockHTTPCacheable{bodies: map[string]string{"/tx/hash/" + testTxID1: `{"txid":"` + testTxID1 + `","confirmations":2}`}}
+		client := newCachedMockClient(mock, WithCacheRule(CacheEndpointTransaction, CacheRule{MinConfirmations: 1}))
+
+		for i := 0; i < 2; i++ {
+			_, err := client.GetTxByHash(context.Background(), testTxID1)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int64(1), mock.calls.Load())
+	})
+
+	t.Run("disabled endpoint", func(t *testing.T) {
+		mock := &mockHTTPCacheable{bodies: map[string]string{"/tx/" + testTxID1 + "/hex": `0100`}}
+		client := newCachedMockClient(mock, WithCacheRule(CacheEndpointRawTransaction, CacheRule{Disabled: true}))
+
+		for i := 0; i < 2; i++ {
+			_, err := client.GetRawTransactionData(context.Background(), testTxID1)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int64(2), mock.calls.Load())
+		assert.Equal(t, CacheStats{}, client.CacheStats())
+	})
+
+	t.Run("rule on options without defaults", func(t *testing.T) {
+		opts := &clientOptions{}
+		WithCacheRule(CacheEndpointBlock, CacheRule{TTL: time.Minute})(opts)
+		assert.Len(t, opts.cacheRules, 6)
+		assert.Equal(t, time.Minute, opts.cacheRules[CacheEndpointBlock].TTL)
+	})
+}
+
+// TestClient_Cache_Endpoints tests each cacheable endpoint
+func TestClient_Cache_Endpoints(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockHTTPCacheable{bodies: map[string]string{
+		"/tx/" + testTxID1 + "/hex":              `0100000001`,
+		"/tx/" + testTxID1 + "/bin":              "\x01\x00\x00\x00",
+		"/block/hash/" + testBlockHash1:          `{"hash":"` + testBlockHash1 + `","confirmations":10}`,
+		"/block/" + testBlockHash1 + "/header":   `{"hash":"` + testBlockHash1 + `","confirmations":10}`,
+		"/tx/" + testTxID1 + "/proof/tsc":        `[{"index":1,"nodes":["*"],"target":"` + testBlockHash1 + `","txOrId":"` + testTxID1 + `"}]`,
+		"/tx/" + testTxID2 + "/proof/tsc":        `[{"index":1,"nodes":["*"],"target":"` + testBlockHash170 + `","txOrId":"` + testTxID2 + `"}]`,
+		"/block/hash/" + testBlockHash170:        `{"hash":"` + testBlockHash170 + `","confirmations":1}`,
+		"/block/" + testBlockHash170 + "/header": `{"hash":"` + testBlockHash170 + `","confirmations":1}`,
+	}}
+	client := newCachedMockClient(mock)
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		fn          func() error
+		expectCalls int64
+	}{
+		{"raw transaction", func() error { _, err := client.GetRawTransactionData(ctx, testTxID1); return err }, 1},
+		{"transaction binary", func() error { _, err := client.GetTransactionAsBinary(ctx, testTxID1); return err }, 1},
+		{"block by hash", func() error { _, err := client.GetBlockByHash(ctx, testBlockHash1); return err }, 1},
+		{"header by hash", func() error { _, err := client.GetHeaderByHash(ctx, testBlockHash1); return err }, 1},
+		{"merkle proof", func() error { _, err := client.GetMerkleProofTSC(ctx, testTxID1); return err }, 1},
+		{"shallow block", func() error { _, err := client.GetBlockByHash(ctx, testBlockHash170); return err }, 3},
+		{"shallow header", func() error { _, err := client.GetHeaderByHash(ctx, testBlockHash170); return err }, 3},
+		// The proof and the header of its shallow target block, every time
+		{"shallow merkle proof", func() error { _, err := client.GetMerkleProofTSC(ctx, testTxID2); return err }, 6},
+	}
+
+	for _, tt := range tests {
+		before := mock.calls.Load()
+		for i := 0; i < 3; i++ {
+			require.NoError(t, tt.fn(), tt.name)
+		}
+		assert.Equal(t, tt.expectCalls, mock.calls.Load()-before, tt.name)
+	}
+}
+
+// TestClient_Cache_ErrorsNotCached tests that failed responses are never cached
+func TestClient_Cache_ErrorsNotCached(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockHTTPCacheable{
+		bodies:     map[string]string{"/tx/" + testTxID1 + "/hex": `Internal Server Error`},
+		statusCode: http.StatusInternalServerError,
+	}
+	client := newCachedMockClient(mock)
+
+	for i := 0; i < 2; i++ {
+		_, err := client.GetRawTransactionData(context.Background(), testTxID1)
+		require.ErrorIs(t, err, ErrRequestFailed)
+	}
+	assert.Equal(t, int64(2), mock.calls.Load())
+	assert.Equal(t, CacheStats{Hits: 0, Misses: 2}, client.CacheStats())
+}
+
+// TestClient_Cache_KeyedByNetwork tests that cache keys include the chain and network
+func TestClient_Cache_KeyedByNetwork(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockHTTPCacheable{bodies: map[string]string{"/tx/" + testTxID1 + "/hex": `0100`}}
+	client := newCachedMockClient(mock)
+	ctx := context.Background()
+
+	_, err := client.GetRawTransactionData(ctx, testTxID1)
+	require.NoError(t, err)
+
+	require.NoError(t, client.SetNetwork(NetworkTest))
+	_, err = client.GetRawTransactionData(ctx, testTxID1)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2), mock.calls.Load())
+}
+
+// TestResponseConfirmations tests extracting confirmations from a response body
+func TestResponseConfirmations(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, int64(7), responseConfirmations([]byte(`{"confirmations":7}`)))
+	assert.Equal(t, int64(0), responseConfirmations([]byte(`{}`)))
+	assert.Equal(t, int64(0), responseConfirmations([]byte(`not json`)))
+	assert.Equal(t, int64(0), responseConfirmations([]byte(`[1,2]`)))
+}
@@ -20,13 +20,25 @@ func (c *Client) GetChainInfo(ctx context.Context) (*ChainInfo, error) {
 // For more information: https://docs.whatsonchain.com/#get-circulating-supply
 func (c *Client) GetCirculatingSupply(ctx context.Context) (float64, error) {
 	url := c.buildURL("/circulatingsupply")
-	resp, err := requestString(ctx, c, url)
+	resp, err := requestString(ctx, c, url, ErrChainInfoNotFound)
 	if err != nil {
 		return 0, err
 	}
 	return strconv.ParseFloat(strings.TrimSpace(resp), 64)
 }
 
+// GetCirculatingSupplySatoshis this endpoint retrieves the current circulating supply as exact satoshis
+//
+// For more information: https://docs.whatsonchain.com/#get-circulating-supply
+func (c *Client) GetCirculatingSupplySatoshis(ctx context.Context) (Satoshis, error) {
+	url := c.buildURL("/circulatingsupply")
+	resp, err := requestString(ctx, c, url, ErrChainInfoNotFound)
+	if err != nil {
+		return 0, err
+	}
+	return parseAmount(resp, UnitBSV, true)
+}
+
 // GetChainTips this endpoint retrieves the chain tips
 //
 // For more information: https://docs.whatsonchain.com/#get-chain-tips
@@ -17,13 +17,13 @@ func (m *mockHTTPChainInfoBenchmark) Do(req *http.Request) (*http.Response, erro
 
 	// Chain info
 	if strings.Contains(req.URL.String(), "/chain/info") {
-		resp.Body = io.NopCloser(strings.NewReader(`{"chain":"main","blocks":700000,"headers":700000,"bestblockhash":"00000000000000000373d17e4f4f8e0f0f3f3e3d3c3b3a39383736353433323","difficulty":1234567.89,"mediantime":1609456000,"verificationprogress":0.999999,"chainwork":"00000000000000000000000000000000000000000000000000000000000000ff","pruned":false}`))
+		resp.Body = io.NopCloser(strings.NewReader(`{"chain":"main","blocks":700000,"headers":700000,"bestblockhash":"000000000000000000373d17e4f4f8e0f0f3f3e3d3c3b3a39383736353433323","difficulty":1234567.89,"mediantime":1609456000,"verificationprogress":0.999999,"chainwork":"00000000000000000000000000000000000000000000000000000000000000ff","pruned":false}`))
 		return resp, nil
 	}
 
 	// Chain tips
 	if strings.Contains(req.URL.String(), "/chain/tips") {
-		resp.Body = io.NopCloser(strings.NewReader(`[{"height":700000,"hash":"00000000000000000373d17e4f4f8e0f0f3f3e3d3c3b3a39383736353433323","branchlen":0,"status":"active"}]`))
+		resp.Body = io.NopCloser(strings.NewReader(`[{"height":700000,"hash":"000000000000000000373d17e4f4f8e0f0f3f3e3d3c3b3a39383736353433323","branchlen":0,"status":"active"}]`))
 		return resp, nil
 	}
 
@@ -0,0 +1,219 @@
+package whatsonchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of a CircuitBreaker
+type CircuitState int
+
+const (
+	// CircuitClosed lets all requests through (healthy)
+	CircuitClosed CircuitState = iota
+
+	// CircuitOpen fails all requests fast until the cooldown has passed
+	CircuitOpen
+
+	// CircuitHalfOpen lets a single probe request through to test if the API has recovered
+	CircuitHalfOpen
+)
+
+const (
+	// defaultCircuitBreakerCooldown is how long the breaker stays open before probing the API
+	defaultCircuitBreakerCooldown = 30 * time.Second
+)
+
+// String returns the state name (closed, open, half-open)
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitOpenError is returned when the circuit breaker is open and the request was not sent.
+// It satisfies errors.Is(err, ErrCircuitOpen).
+type CircuitOpenError struct {
+	Failures   int           `json:"failures"`    // consecutive failures that opened the circuit
+	RetryAfter time.Duration `json:"retry_after"` // time left until the breaker lets a probe request through
+}
+
+// Error returns the error message, e.g. "circuit breaker is open: 5 consecutive failures, retry in 29s"
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s: %d consecutive failures, retry in %s", ErrCircuitOpen, e.Failures, e.RetryAfter)
+}
+
+// Unwrap returns ErrCircuitOpen so errors.Is(err, ErrCircuitOpen) is true
+func (e *CircuitOpenError) Unwrap() error {
+	return ErrCircuitOpen
+}
+
+// CircuitBreaker stops sending requests to an API that is clearly down.
+//
+// After failureThreshold consecutive failures (transport errors or 5xx responses) the
+// circuit opens and every request fails fast with a *CircuitOpenError. Once the cooldown
+// has passed the circuit is half-open: a single probe request is let through, and its
+// outcome either closes the circuit again or re-opens it for another cooldown. A request
+// canceled by the caller is not counted either way (a canceled probe lets the next one through).
+// It is safe for concurrent use.
+type CircuitBreaker struct {
+	cooldown         time.Duration
+	failureThreshold int
+	failures         int
+	mu               sync.Mutex
+	now              func() time.Time // clock (replaced in tests)
+	openedAt         time.Time
+	probing          bool // a half-open probe request is in flight
+	state            CircuitState
+}
+
+// NewCircuitBreaker creates a new circuit breaker.
+// A failureThreshold less than 1 is clamped to 1 and a cooldown of 0 or less defaults to 30 seconds.
+func NewCircuitBreaker(failureThreshold int, cooldown time.Duration) *CircuitBreaker {
+	if failureThreshold < 1 {
+		failureThreshold = 1
+	}
+	if cooldown <= 0 {
+		cooldown = defaultCircuitBreakerCooldown
+	}
+	return &CircuitBreaker{
+		cooldown:         cooldown,
+		failureThreshold: failureThreshold,
+		now:              time.Now,
+	}
+}
+
+// State returns the current state. An open circuit whose cooldown has passed reports CircuitHalfOpen.
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == CircuitOpen && cb.now().Sub(cb.openedAt) >= cb.cooldown {
+		return CircuitHalfOpen
+	}
+	return cb.state
+}
+
+// Failures returns the current number of consecutive failures
+func (cb *CircuitBreaker) Failures() int {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.failures
+}
+
+// Reset closes the circuit and clears the failure count
+func (cb *CircuitBreaker) Reset() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.failures = 0
+	cb.probing = false
+	cb.state = CircuitClosed
+}
+
+// allow returns nil if a request may be sent, or a *CircuitOpenError if it must fail fast.
+// probe is true for the single half-open probe request, whose outcome must be passed back to record or release.
+func (cb *CircuitBreaker) allow() (probe bool, err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case CircuitClosed:
+		return false, nil
+	case CircuitOpen:
+		remaining := cb.cooldown - cb.now().Sub(cb.openedAt)
+		if remaining > 0 {
+			return false, &CircuitOpenError{Failures: cb.failures, RetryAfter: remaining}
+		}
+		// Cooldown is over, let a single probe through
+		cb.state = CircuitHalfOpen
+		cb.probing = true
+		return true, nil
+	case CircuitHalfOpen:
+		if cb.probing {
+			return false, &CircuitOpenError{Failures: cb.failures}
+		}
+		cb.probing = true
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// record updates the breaker with the outcome of a request let through by allow.
+// Once the circuit has opened, only the probe's outcome closes or re-opens it: requests
+// that were let through before it opened are ignored.
+func (cb *CircuitBreaker) record(probe, failed bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if probe {
+		cb.probing = false
+	} else if cb.state != CircuitClosed {
+		return
+	}
+
+	if !failed {
+		cb.failures = 0
+		cb.state = CircuitClosed
+		return
+	}
+
+	cb.failures++
+	if cb.state == CircuitHalfOpen || cb.failures >= cb.failureThreshold {
+		cb.state = CircuitOpen
+		cb.openedAt = cb.now()
+	}
+}
+
+// release gives up a request let through by allow without recording an outcome (e.g. canceled by the caller).
+// A released probe frees the half-open slot for the next request.
+func (cb *CircuitBreaker) release(probe bool) {
+	if !probe {
+		return
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.probing = false
+}
+
+// circuitFailure returns true if the outcome counts as a failure for the circuit breaker.
+// Transport errors and 5xx responses count; a canceled context or a 4xx (including 429) do not.
+func circuitFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled)
+	}
+	return resp != nil && resp.StatusCode >= http.StatusInternalServerError
+}
+
+// WithCircuitBreaker enables a circuit breaker that fails fast with a *CircuitOpenError after
+// failureThreshold consecutive failures, then probes the API again after the cooldown.
+// It has no effect when a custom HTTP client is set with WithHTTPClient.
+func WithCircuitBreaker(failureThreshold int, cooldown time.Duration) ClientOption {
+	return func(c *clientOptions) {
+		c.circuitBreaker = NewCircuitBreaker(failureThreshold, cooldown)
+	}
+}
+
+// CircuitState returns the state of the client's circuit breaker (CircuitClosed if not enabled)
+func (c *Client) CircuitState() CircuitState {
+	c.optionsMu.RLock()
+	breaker := c.options.circuitBreaker
+	c.optionsMu.RUnlock()
+
+	if breaker == nil {
+		return CircuitClosed
+	}
+	return breaker.State()
+}
@@ -0,0 +1,342 @@
+package whatsonchain
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testClock is a manually advanced clock for the circuit breaker
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// Now returns the current fake time
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the fake time forward
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// newTestCircuitBreaker returns a breaker driven by a fake clock
+func newTestCircuitBreaker(threshold int, cooldown time.Duration) (*CircuitBreaker, *testClock) {
+	clock := &testClock{now: time.Unix(1700000000, 0)}
+	cb := NewCircuitBreaker(threshold, cooldown)
+	cb.now = clock.Now
+	return cb, clock
+}
+
+// TestNewCircuitBreaker tests the defaults
+func TestNewCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	cb := NewCircuitBreaker(0, 0)
+	assert.Equal(t, 1, cb.failureThreshold)
+	assert.Equal(t, defaultCircuitBreakerCooldown, cb.cooldown)
+	assert.Equal(t, CircuitClosed, cb.State())
+	assert.Equal(t, 0, cb.Failures())
+}
+
+// TestCircuitState_String tests the state names
+func TestCircuitState_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "closed", CircuitClosed.String())
+	assert.Equal(t, "open", CircuitOpen.String())
+	assert.Equal(t, "half-open", CircuitHalfOpen.String())
+	assert.Equal(t, "unknown", CircuitState(99).String())
+}
+
+// testCircuitRequest lets a request through the breaker and records its outcome
+func testCircuitRequest(t *testing.T, cb *CircuitBreaker, failed bool) {
+	t.Helper()
+
+	probe, err := cb.allow()
+	require.NoError(t, err)
+	cb.record(probe, failed)
+}
+
+// TestCircuitBreaker_Transitions tests closed -> open -> half-open -> closed/open
+func TestCircuitBreaker_Transitions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("opens after consecutive failures", func(t *testing.T) {
+		t.Parallel()
+		cb, _ := newTestCircuitBreaker(3, time.Minute)
+
+		for i := 0; i < 2; i++ {
+			testCircuitRequest(t, cb, true)
+		}
+		assert.Equal(t, CircuitClosed, cb.State())
+
+		testCircuitRequest(t, cb, true)
+		assert.Equal(t, CircuitOpen, cb.State())
+		assert.Equal(t, 3, cb.Failures())
+
+		_, err := cb.allow()
+		require.ErrorIs(t, err, ErrCircuitOpen)
+		var openErr *CircuitOpenError
+		require.ErrorAs(t, err, &openErr)
+		assert.Equal(t, 3, openErr.Failures)
+		assert.Equal(t, time.Minute, openErr.RetryAfter)
+		assert.Equal(t, "circuit breaker is open: 3 consecutive failures, retry in 1m0s", err.Error())
+	})
+
+	t.Run("success resets the failure count", func(t *testing.T) {
+		t.Parallel()
+		cb, _ := newTestCircuitBreaker(2, time.Minute)
+
+		testCircuitRequest(t, cb, true)
+		testCircuitRequest(t, cb, false)
+		testCircuitRequest(t, cb, true)
+		assert.Equal(t, CircuitClosed, cb.State())
+		assert.Equal(t, 1, cb.Failures())
+	})
+
+	t.Run("half-open probe success closes the circuit", func(t *testing.T) {
+		t.Parallel()
+		cb, clock := newTestCircuitBreaker(1, time.Minute)
+
+		testCircuitRequest(t, cb, true)
+		assert.Equal(t, CircuitOpen, cb.State())
+
+		clock.Advance(time.Minute)
+		assert.Equal(t, CircuitHalfOpen, cb.State())
+
+		// Only a single probe is let through
+		probe, err := cb.allow()
+		require.NoError(t, err)
+		assert.True(t, probe)
+		_, err = cb.allow()
+		require.ErrorIs(t, err, ErrCircuitOpen)
+
+		cb.record(probe, false)
+		assert.Equal(t, CircuitClosed, cb.State())
+		assert.Equal(t, 0, cb.Failures())
+		probe, err = cb.allow()
+		require.NoError(t, err)
+		assert.False(t, probe)
+	})
+
+	t.Run("half-open probe failure re-opens the circuit", func(t *testing.T) {
+		t.Parallel()
+		cb, clock := newTestCircuitBreaker(2, time.Minute)
+
+		testCircuitRequest(t, cb, true)
+		testCircuitRequest(t, cb, true)
+		clock.Advance(time.Minute)
+		testCircuitRequest(t, cb, true)
+		assert.Equal(t, CircuitOpen, cb.State())
+		_, err := cb.allow()
+		require.ErrorIs(t, err, ErrCircuitOpen)
+
+		clock.Advance(30 * time.Second)
+		assert.Equal(t, CircuitOpen, cb.State())
+	})
+
+	t.Run("requests let through before opening do not close it", func(t *testing.T) {
+		t.Parallel()
+		cb, clock := newTestCircuitBreaker(2, time.Minute)
+
+		early, err := cb.allow()
+		require.NoError(t, err)
+		testCircuitRequest(t, cb, true)
+		testCircuitRequest(t, cb, true)
+		require.Equal(t, CircuitOpen, cb.State())
+
+		cb.record(early, false)
+		assert.Equal(t, CircuitOpen, cb.State())
+		assert.Equal(t, 2, cb.Failures())
+
+		// Nor take the half-open probe's place
+		clock.Advance(time.Minute)
+		probe, err := cb.allow()
+		require.NoError(t, err)
+		cb.record(early, false)
+		assert.Equal(t, CircuitHalfOpen, cb.State())
+		_, err = cb.allow()
+		require.ErrorIs(t, err, ErrCircuitOpen)
+		cb.record(probe, false)
+		assert.Equal(t, CircuitClosed, cb.State())
+	})
+
+	t.Run("reset closes the circuit", func(t *testing.T) {
+		t.Parallel()
+		cb, _ := newTestCircuitBreaker(1, time.Minute)
+
+		testCircuitRequest(t, cb, true)
+		cb.Reset()
+		assert.Equal(t, CircuitClosed, cb.State())
+		_, err := cb.allow()
+		require.NoError(t, err)
+	})
+}
+
+// TestCircuitBreaker_Canceled tests requests canceled by the caller leave the state alone
+func TestCircuitBreaker_Canceled(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cancel while open", func(t *testing.T) {
+		t.Parallel()
+		cb, _ := newTestCircuitBreaker(2, time.Minute)
+
+		canceled, err := cb.allow()
+		require.NoError(t, err)
+		testCircuitRequest(t, cb, true)
+		testCircuitRequest(t, cb, true)
+		require.Equal(t, CircuitOpen, cb.State())
+
+		cb.release(canceled)
+		assert.Equal(t, CircuitOpen, cb.State())
+		assert.Equal(t, 2, cb.Failures())
+	})
+
+	t.Run("cancel while half-open", func(t *testing.T) {
+		t.Parallel()
+		cb, clock := newTestCircuitBreaker(1, time.Minute)
+
+		testCircuitRequest(t, cb, true)
+		clock.Advance(time.Minute)
+		probe, err := cb.allow()
+		require.NoError(t, err)
+		require.True(t, probe)
+
+		cb.release(probe)
+		assert.Equal(t, CircuitHalfOpen, cb.State())
+		assert.Equal(t, 1, cb.Failures())
+
+		// The next request becomes the probe
+		probe, err = cb.allow()
+		require.NoError(t, err)
+		assert.True(t, probe)
+	})
+
+	t.Run("retryable client", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		breaker, clock := newTestCircuitBreaker(1, time.Minute)
+		client := NewRetryableHTTPClient(nil, 0, nil)
+		client.SetCircuitBreaker(breaker)
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		require.Equal(t, CircuitOpen, breaker.State())
+
+		// The probe is canceled before it is sent
+		clock.Advance(time.Minute)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = client.Do(req.WithContext(ctx))
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, CircuitHalfOpen, breaker.State())
+		assert.Equal(t, 1, breaker.Failures())
+	})
+}
+
+// TestCircuitFailure tests which outcomes count as failures
+func TestCircuitFailure(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, circuitFailure(nil, errNetworkError))
+	assert.True(t, circuitFailure(nil, context.DeadlineExceeded))
+	assert.False(t, circuitFailure(nil, context.Canceled))
+	assert.True(t, circuitFailure(&http.Response{StatusCode: http.StatusServiceUnavailable}, nil))
+	assert.False(t, circuitFailure(&http.Response{StatusCode: http.StatusTooManyRequests}, nil))
+	assert.False(t, circuitFailure(&http.Response{StatusCode: http.StatusNotFound}, nil))
+	assert.False(t, circuitFailure(&http.Response{StatusCode: http.StatusOK}, nil))
+}
+
+// TestRetryableHTTPClient_CircuitBreaker tests that the breaker stops retries and fails fast
+func TestRetryableHTTPClient_CircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	var requestCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requestCount.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewRetryableHTTPClient(nil, 5, NewExponentialBackoff(time.Millisecond, time.Millisecond, 1, 0))
+	client.SetCircuitBreaker(NewCircuitBreaker(2, time.Minute))
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	// The breaker opens after 2 attempts and stops the remaining retries
+	resp, err := client.Do(req)
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Nil(t, resp)
+	assert.Equal(t, int32(2), requestCount.Load())
+
+	// The next request fails fast without hitting the server
+	resp, err = client.Do(req)
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Nil(t, resp)
+	assert.Equal(t, int32(2), requestCount.Load())
+}
+
+// TestClient_CircuitState tests the client option and state getter
+func TestClient_CircuitState(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled by default", func(t *testing.T) {
+		t.Parallel()
+		client, err := NewClient(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, CircuitClosed, client.CircuitState())
+	})
+
+	t.Run("opens after failures", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		client, err := NewClient(
+			context.Background(),
+			WithRequestRetryCount(0),
+			WithCircuitBreaker(1, time.Minute),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, CircuitClosed, client.CircuitState())
+
+		// A breaker still needs the built-in client (even without retries)
+		_, ok := client.HTTPClient().(*RetryableHTTPClient)
+		require.True(t, ok)
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		resp, err := client.HTTPClient().Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, CircuitOpen, client.CircuitState())
+
+		_, err = client.GetChainInfo(context.Background())
+		require.ErrorIs(t, err, ErrCircuitOpen)
+	})
+}
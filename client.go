@@ -4,6 +4,7 @@ import (
 	"net"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -37,9 +38,14 @@ type HTTPInterface interface {
 // c.optionsMu for concurrent access. The Set* and getter methods acquire
 // this mutex automatically, so callers may safely call them from any goroutine.
 type Client struct {
+	cacheHits     atomic.Uint64  // number of responses served from the cache
+	cacheMisses   atomic.Uint64  // number of cacheable responses fetched from the API
+	endpoints     *endpointPool  // health scoring and failover between base URLs
+	handler       RequestHandler // the middleware chain wrapped around httpClient
 	httpClient    HTTPInterface  // carries out the http operations
 	lastRequest   *LastRequest   // is the raw information from the last request
 	lastRequestMu sync.RWMutex   // protects lastRequest for concurrent access
+	limiter       *rateLimiter   // shared token bucket enforced on every request
 	options       *clientOptions // single source of truth for all configuration
 	optionsMu     sync.RWMutex   // protects options fields for concurrent access
 }
@@ -51,14 +57,26 @@ type clientOptions struct {
 	backOffInitialTimeout          time.Duration
 	backOffMaximumJitterInterval   time.Duration
 	backOffMaxTimeout              time.Duration
+	bulkConcurrency                int
+	bulkProgress                   BulkProgressFunc
+	cache                          Cache
+	cacheRules                     map[CacheEndpoint]CacheRule
 	chain                          ChainType
+	circuitBreaker                 *CircuitBreaker
 	customHTTPClient               HTTPInterface
 	dialerKeepAlive                time.Duration
 	dialerTimeout                  time.Duration
+	endpoints                      []string
+	maxRetryAfter                  time.Duration
+	middleware                     []Middleware
 	network                        NetworkType
+	rateBurst                      int
 	rateLimit                      int
 	requestRetryCount              int
 	requestTimeout                 time.Duration
+	retryHooks                     []RetryHook
+	retryPolicy                    RetryPolicy
+	skipInputValidation            bool
 	transportExpectContinueTimeout time.Duration
 	transportIdleTimeout           time.Duration
 	transportMaxIdleConnections    int
@@ -76,6 +94,8 @@ func defaultClientOptions() *clientOptions {
 		backOffInitialTimeout:          2 * time.Millisecond,
 		backOffMaximumJitterInterval:   2 * time.Millisecond,
 		backOffMaxTimeout:              10 * time.Millisecond,
+		bulkConcurrency:                defaultBulkConcurrency,
+		cacheRules:                     DefaultCacheRules(),
 		chain:                          ChainBSV, // Default to BSV for backward compatibility
 		dialerKeepAlive:                20 * time.Second,
 		dialerTimeout:                  5 * time.Second,
@@ -91,6 +111,22 @@ func defaultClientOptions() *clientOptions {
 	}
 }
 
+// baseURL returns the primary API base URL (the first endpoint, or the default)
+func (o *clientOptions) baseURL() string {
+	if len(o.endpoints) > 0 {
+		return o.endpoints[0]
+	}
+	return apiEndpointBase
+}
+
+// effectiveRateBurst returns the configured burst, falling back to the rate limit when unset
+func (o *clientOptions) effectiveRateBurst() int {
+	if o.rateBurst > 0 {
+		return o.rateBurst
+	}
+	return o.rateLimit
+}
+
 // validChains contains the set of valid chain types
 var validChains = map[ChainType]bool{ //nolint:gochecknoglobals // read-only lookup table
 	ChainBSV: true,
@@ -143,6 +179,17 @@ func WithRateLimit(rateLimit int) ClientOption {
 	}
 }
 
+// WithRateBurst sets the maximum number of requests that may be fired back-to-back
+// before the rate limit kicks in. Values less than 1 mean "same as the rate limit".
+func WithRateBurst(burst int) ClientOption {
+	return func(c *clientOptions) {
+		if burst < 0 {
+			burst = 0
+		}
+		c.rateBurst = burst
+	}
+}
+
 // WithHTTPClient sets a custom HTTP client
 func WithHTTPClient(httpClient HTTPInterface) ClientOption {
 	return func(c *clientOptions) {
@@ -174,6 +221,14 @@ func WithBackoff(initialTimeout, maxTimeout time.Duration, exponentFactor float6
 	}
 }
 
+// WithMaxRetryAfter sets the longest Retry-After delay waited before a retry (default 1 minute).
+// A response asking for a longer wait is returned as an *APIError carrying RetryAfter instead.
+func WithMaxRetryAfter(limit time.Duration) ClientOption {
+	return func(c *clientOptions) {
+		c.maxRetryAfter = limit
+	}
+}
+
 // WithDialer sets the dialer configuration
 func WithDialer(keepAlive, timeout time.Duration) ClientOption {
 	return func(c *clientOptions) {
@@ -207,14 +262,30 @@ type LastRequest struct {
 func newClientFromOptions(opts *clientOptions) *Client {
 	// Create a client
 	c := &Client{
+		httpClient:  newHTTPClient(opts),
 		lastRequest: &LastRequest{},
+		limiter:     newRateLimiter(opts.rateLimit, opts.effectiveRateBurst()),
 		options:     opts,
 	}
 
+	// Score every base URL (failover needs more than one, see WithEndpoints)
+	if len(opts.endpoints) > 0 {
+		c.endpoints = newEndpointPool(opts.endpoints)
+	} else {
+		c.endpoints = newEndpointPool([]string{apiEndpointBase})
+	}
+
+	// Wrap the http client with the middleware chain
+	c.handler = chainMiddleware(c.doRequest, opts.middleware)
+
+	return c
+}
+
+// newHTTPClient creates the HTTP client (custom, simple or retryable) from clientOptions
+func newHTTPClient(opts *clientOptions) HTTPInterface {
 	// Is there a custom HTTP client to use?
 	if opts.customHTTPClient != nil {
-		c.httpClient = opts.customHTTPClient
-		return c
+		return opts.customHTTPClient
 	}
 
 	// dial is the net dialer for clientDefaultTransport
@@ -236,20 +307,25 @@ func newClientFromOptions(opts *clientOptions) *Client {
 		Timeout:   opts.requestTimeout,
 	}
 
-	// Determine the strategy for the http client (no retry enabled)
-	if opts.requestRetryCount <= 0 {
-		c.httpClient = NewSimpleHTTPClient(baseHTTPClient)
-	} else { // Retry enabled
-		// Create exponential back-off
-		backOff := NewExponentialBackoff(
-			opts.backOffInitialTimeout,
-			opts.backOffMaxTimeout,
-			opts.backOffExponentFactor,
-			opts.backOffMaximumJitterInterval,
-		)
-
-		c.httpClient = NewRetryableHTTPClient(baseHTTPClient, opts.requestRetryCount, backOff)
+	// Determine the strategy for the http client (no retry, retry policy, retry hook or circuit breaker)
+	if opts.requestRetryCount <= 0 && opts.circuitBreaker == nil && opts.retryPolicy == nil && len(opts.retryHooks) == 0 {
+		return NewSimpleHTTPClient(baseHTTPClient)
 	}
 
-	return c
+	// Retry enabled: create exponential back-off
+	backOff := NewExponentialBackoff(
+		opts.backOffInitialTimeout,
+		opts.backOffMaxTimeout,
+		opts.backOffExponentFactor,
+		opts.backOffMaximumJitterInterval,
+	)
+
+	retryable := NewRetryableHTTPClient(baseHTTPClient, opts.requestRetryCount, backOff)
+	retryable.SetCircuitBreaker(opts.circuitBreaker)
+	retryable.SetMaxRetryAfter(opts.maxRetryAfter)
+	retryable.SetRetryPolicy(opts.retryPolicy)
+	for _, hook := range opts.retryHooks {
+		retryable.AddRetryHook(hook)
+	}
+	return retryable
 }
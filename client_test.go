@@ -99,6 +99,30 @@ func TestWithRateLimit(t *testing.T) {
 	assert.Equal(t, rateLimit, client.RateLimit())
 }
 
+// TestWithRateBurst tests the WithRateBurst option
+func TestWithRateBurst(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults to rate limit", func(t *testing.T) {
+		client, err := NewClient(context.Background(), WithRateLimit(7))
+		require.NoError(t, err)
+		assert.Equal(t, 7, client.RateBurst())
+	})
+
+	t.Run("explicit burst", func(t *testing.T) {
+		client, err := NewClient(context.Background(), WithRateLimit(7), WithRateBurst(20))
+		require.NoError(t, err)
+		assert.Equal(t, 20, client.RateBurst())
+		assert.Equal(t, 7, client.RateLimit())
+	})
+
+	t.Run("negative burst falls back to rate limit", func(t *testing.T) {
+		client, err := NewClient(context.Background(), WithRateLimit(4), WithRateBurst(-1))
+		require.NoError(t, err)
+		assert.Equal(t, 4, client.RateBurst())
+	})
+}
+
 // TestWithHTTPClient tests the WithHTTPClient option
 func TestWithHTTPClient(t *testing.T) {
 	t.Parallel()
@@ -261,6 +285,8 @@ func TestSetRateLimit(t *testing.T) {
 	newLimit := 15
 	client.SetRateLimit(newLimit)
 	assert.Equal(t, newLimit, client.RateLimit())
+	assert.Equal(t, newLimit, client.RateBurst())
+	assert.Equal(t, 0, client.RateLimitQueueDepth())
 }
 
 // TestSetChain tests the SetChain method
@@ -0,0 +1,400 @@
+package whatsonchain
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// CoinSelectionStrategy is how SelectCoins chooses the UTXOs that fund a transaction
+type CoinSelectionStrategy string
+
+const (
+	// CoinSelectionLargestFirst spends the largest UTXOs first: the fewest inputs, so the smallest fee
+	CoinSelectionLargestFirst CoinSelectionStrategy = "largest_first"
+
+	// CoinSelectionSmallestFirst spends the smallest UTXOs first, consolidating dust at a higher fee
+	CoinSelectionSmallestFirst CoinSelectionStrategy = "smallest_first"
+
+	// CoinSelectionBranchAndBound searches for inputs that pay the target and fee without change
+	// (the Bitcoin Core algorithm), falling back to largest first when there are none
+	CoinSelectionBranchAndBound CoinSelectionStrategy = "branch_and_bound"
+
+	// CoinSelectionRandomImprove picks random UTXOs until the target is covered, then adds random UTXOs
+	// while they bring the change closer to the target (CIP-2), keeping the UTXO set in useful sizes
+	CoinSelectionRandomImprove CoinSelectionStrategy = "random_improve"
+)
+
+const (
+	// defaultCoinSelectionDustLimit is the default CoinSelectionOptions.DustLimit
+	defaultCoinSelectionDustLimit = 1
+
+	// defaultCoinSelectionInputSize is the default CoinSelectionOptions.InputSize (P2PKH, compressed key)
+	defaultCoinSelectionInputSize = 148
+
+	// defaultCoinSelectionOutputSize is the default CoinSelectionOptions.OutputSize (P2PKH)
+	defaultCoinSelectionOutputSize = 34
+
+	// defaultCoinSelectionOutputs is the default CoinSelectionOptions.Outputs
+	defaultCoinSelectionOutputs = 1
+
+	// coinSelectionMaxTries is the most branches the branch and bound search visits
+	coinSelectionMaxTries = 100_000
+
+	// txFixedSize is the size of the version and lock time of a transaction
+	txFixedSize = 8
+)
+
+// CoinSelectionOptions are the options for SelectCoins
+type CoinSelectionOptions struct {
+	DustLimit  Satoshis              // smallest change output; less is left to the miner (default 1)
+	InputSize  int                   // estimated size of an input in bytes (default 148, P2PKH)
+	OutputSize int                   // size of each output, change included, in bytes (default 34, P2PKH)
+	Outputs    int                   // number of outputs paying the target (default 1)
+	Strategy   CoinSelectionStrategy // default CoinSelectionLargestFirst
+}
+
+// CoinSelection is the funding of a transaction chosen by SelectCoins
+type CoinSelection struct {
+	Change Satoshis       // the change output, zero when there is none
+	Fee    Satoshis       // the input value paid to neither the target nor the change
+	Inputs AddressHistory // the UTXOs to spend
+	Size   int            // estimated size of the transaction in bytes
+	Total  Satoshis       // the value of the inputs
+}
+
+// SelectCoins chooses the UTXOs paying target satoshis at feeRate satoshis per 1000 bytes.
+// Change below the dust limit is left as fee; UTXOs worth no more than the fee to spend them are never chosen.
+// It returns an *InvalidInputError for a bad target, fee rate or strategy, and ErrInsufficientFunds
+// when the UTXOs cannot cover the target and fee.
+func (h AddressHistory) SelectCoins(target, feeRate Satoshis, opts *CoinSelectionOptions) (*CoinSelection, error) {
+	if target <= 0 {
+		return nil, &InvalidInputError{Field: "target", Reason: "must be positive", Value: strconv.FormatInt(int64(target), 10)}
+	}
+	if feeRate < 0 {
+		return nil, &InvalidInputError{Field: "feeRate", Reason: "must not be negative", Value: strconv.FormatInt(int64(feeRate), 10)}
+	}
+	selector := newCoinSelector(int64(target), int64(feeRate), opts)
+
+	// Spendable coins, largest first (the order branch and bound searches in)
+	inputFee := selector.fee(selector.inputSize)
+	coins := make(AddressHistory, 0, len(h))
+	var available int64
+	for _, record := range h {
+		if record != nil && record.Value > inputFee {
+			coins = append(coins, record)
+			available += record.Value
+		}
+	}
+	slices.SortStableFunc(coins, func(a, b *HistoryRecord) int { return cmp.Compare(b.Value, a.Value) })
+	if selector.result(coins, available) == nil {
+		return nil, fmt.Errorf("%w: %d satoshis spendable for a target of %d", ErrInsufficientFunds, available, target)
+	}
+
+	var selection *CoinSelection
+	strategy := CoinSelectionLargestFirst
+	if opts != nil && opts.Strategy != "" {
+		strategy = opts.Strategy
+	}
+	switch strategy {
+	case CoinSelectionLargestFirst:
+		selection = selector.accumulate(coins)
+	case CoinSelectionSmallestFirst:
+		slices.Reverse(coins)
+		selection = selector.accumulate(coins)
+	case CoinSelectionBranchAndBound:
+		if selection = selector.branchAndBound(coins); selection == nil {
+			selection = selector.accumulate(coins)
+		}
+	case CoinSelectionRandomImprove:
+		rand.Shuffle(len(coins), func(i, j int) { coins[i], coins[j] = coins[j], coins[i] }) //nolint:gosec // G404: the order needs no cryptographic randomness
+		selection = selector.randomImprove(coins)
+	default:
+		return nil, &InvalidInputError{Field: "strategy", Reason: "must be a CoinSelectionStrategy", Value: string(strategy)}
+	}
+	return selection, nil
+}
+
+// SelectCoins chooses the UTXOs paying target satoshis at feeRate satoshis per 1000 bytes.
+// The inputs are returned as history records. See: AddressHistory.SelectCoins()
+func (s ScriptList) SelectCoins(target, feeRate Satoshis, opts *CoinSelectionOptions) (*CoinSelection, error) {
+	history := make(AddressHistory, 0, len(s))
+	for _, record := range s {
+		if record != nil {
+			history = append(history, &HistoryRecord{
+				Height: record.Height, TxHash: record.TxHash, TxPos: record.TxPos, Value: record.Value,
+			})
+		}
+	}
+	return history.SelectCoins(target, feeRate, opts)
+}
+
+// ExcludeSpent returns the UTXOs not already spent, in a block or pending in the mempool, using
+// BulkSpentOutputsProcessor. Any failed lookup, including an outpoint missing from the response
+// (ErrSpentStatusUnknown), is returned as an error rather than risk a double spend.
+func (h AddressHistory) ExcludeSpent(ctx context.Context, client ClientInterface) (AddressHistory, error) {
+	outpoints := make([]BulkSpentUTXO, 0, len(h))
+	for _, record := range h {
+		if record != nil {
+			outpoints = append(outpoints, utxoOutpoint(record.TxHash, record.TxPos))
+		}
+	}
+	spent, err := spentOutpoints(ctx, client, outpoints)
+	if err != nil {
+		return nil, err
+	}
+
+	unspent := make(AddressHistory, 0, len(outpoints))
+	for _, record := range h {
+		if record != nil && !spent[utxoOutpoint(record.TxHash, record.TxPos)] {
+			unspent = append(unspent, record)
+		}
+	}
+	return unspent, nil
+}
+
+// ExcludeSpent returns the UTXOs not already spent, in a block or pending in the mempool.
+// See: AddressHistory.ExcludeSpent()
+func (s ScriptList) ExcludeSpent(ctx context.Context, client ClientInterface) (ScriptList, error) {
+	outpoints := make([]BulkSpentUTXO, 0, len(s))
+	for _, record := range s {
+		if record != nil {
+			outpoints = append(outpoints, utxoOutpoint(record.TxHash, record.TxPos))
+		}
+	}
+	spent, err := spentOutpoints(ctx, client, outpoints)
+	if err != nil {
+		return nil, err
+	}
+
+	unspent := make(ScriptList, 0, len(outpoints))
+	for _, record := range s {
+		if record != nil && !spent[utxoOutpoint(record.TxHash, record.TxPos)] {
+			unspent = append(unspent, record)
+		}
+	}
+	return unspent, nil
+}
+
+// utxoOutpoint returns the outpoint of a UTXO record
+func utxoOutpoint(txHash string, txPos int64) BulkSpentUTXO {
+	return BulkSpentUTXO{TxID: strings.ToLower(txHash), Vout: int(txPos)} //nolint:gosec // G115: output indexes fit in an int
+}
+
+// spentOutpoints returns the set of the outpoints that are spent.
+// An outpoint missing from the response returns ErrSpentStatusUnknown: it cannot be assumed unspent.
+func spentOutpoints(ctx context.Context, client ClientInterface, outpoints []BulkSpentUTXO) (map[BulkSpentUTXO]bool, error) {
+	spent := make(map[BulkSpentUTXO]bool)
+	if len(outpoints) == 0 {
+		return spent, nil
+	}
+	results, err := client.BulkSpentOutputsProcessor(ctx, &BulkSpentOutputRequest{UTXOs: outpoints})
+	if err != nil {
+		return nil, err
+	}
+
+	reported := make(map[BulkSpentUTXO]bool, len(results))
+	for _, result := range results {
+		outpoint := BulkSpentUTXO{TxID: strings.ToLower(result.TxID), Vout: result.Vout}
+		reported[outpoint] = true
+		if result.Spent != nil {
+			spent[outpoint] = true
+		}
+	}
+	for _, outpoint := range outpoints {
+		if !reported[BulkSpentUTXO{TxID: strings.ToLower(outpoint.TxID), Vout: outpoint.Vout}] {
+			return nil, fmt.Errorf("%w: %s_%d is missing from the response", ErrSpentStatusUnknown, outpoint.TxID, outpoint.Vout)
+		}
+	}
+	return spent, nil
+}
+
+// coinSelector holds the target and the size and fee model of one SelectCoins call (amounts in satoshis)
+type coinSelector struct {
+	dustLimit  int64
+	feeRate    int64 // satoshis per 1000 bytes
+	inputSize  int
+	outputSize int
+	outputs    int
+	target     int64
+}
+
+// newCoinSelector applies the option defaults
+func newCoinSelector(target, feeRate int64, opts *CoinSelectionOptions) *coinSelector {
+	if opts == nil {
+		opts = &CoinSelectionOptions{}
+	}
+	c := &coinSelector{
+		dustLimit:  int64(opts.DustLimit),
+		feeRate:    feeRate,
+		inputSize:  opts.InputSize,
+		outputSize: opts.OutputSize,
+		outputs:    opts.Outputs,
+		target:     target,
+	}
+	if c.dustLimit <= 0 {
+		c.dustLimit = defaultCoinSelectionDustLimit
+	}
+	if c.inputSize <= 0 {
+		c.inputSize = defaultCoinSelectionInputSize
+	}
+	if c.outputSize <= 0 {
+		c.outputSize = defaultCoinSelectionOutputSize
+	}
+	if c.outputs <= 0 {
+		c.outputs = defaultCoinSelectionOutputs
+	}
+	return c
+}
+
+// size returns the estimated size of a transaction with the inputs, the target outputs and maybe change
+func (c *coinSelector) size(inputs int, change bool) int {
+	outputs := c.outputs
+	if change {
+		outputs++
+	}
+	return txFixedSize + varIntSize(inputs) + inputs*c.inputSize + varIntSize(outputs) + outputs*c.outputSize
+}
+
+// fee returns the fee of size bytes, rounded up
+func (c *coinSelector) fee(size int) int64 {
+	return (int64(size)*c.feeRate + 999) / 1000
+}
+
+// result returns the selection of the inputs worth total, with change when it reaches the dust limit,
+// or nil when they do not cover the target and fee
+func (c *coinSelector) result(inputs AddressHistory, total int64) *CoinSelection {
+	size := c.size(len(inputs), false)
+	if total < c.target+c.fee(size) {
+		return nil
+	}
+	selection := &CoinSelection{
+		Fee:    Satoshis(total - c.target),
+		Inputs: slices.Clone(inputs),
+		Size:   size,
+		Total:  Satoshis(total),
+	}
+	sizeWithChange := c.size(len(inputs), true)
+	if change := total - c.target - c.fee(sizeWithChange); change >= c.dustLimit {
+		selection.Change = Satoshis(change)
+		selection.Fee = Satoshis(c.fee(sizeWithChange))
+		selection.Size = sizeWithChange
+	}
+	return selection
+}
+
+// accumulate selects coins in order until they cover the target and fee
+func (c *coinSelector) accumulate(coins AddressHistory) *CoinSelection {
+	var total int64
+	for i, coin := range coins {
+		total += coin.Value
+		if selection := c.result(coins[:i+1], total); selection != nil {
+			return selection
+		}
+	}
+	return nil
+}
+
+// branchAndBound searches the coins, sorted largest first, for the inputs paying the target and fee
+// with the least excess and no change, or returns nil. Amounts are compared in thousandths of a
+// satoshi, so an input's share of the fee is exact.
+func (c *coinSelector) branchAndBound(coins AddressHistory) *CoinSelection {
+	values := make([]int64, len(coins)) // value net of the fee of spending it
+	var rest int64
+	for i, coin := range coins {
+		values[i] = coin.Value*1000 - int64(c.inputSize)*c.feeRate
+		rest += values[i]
+	}
+	low := c.target*1000 + int64(c.size(0, false))*c.feeRate       // pays the target and fee
+	high := low + int64(c.outputSize)*c.feeRate + c.dustLimit*1000 // enough left over to add change
+
+	var best *CoinSelection
+	bestExcess := high - low
+	selected := make(AddressHistory, 0, len(coins))
+	tries := 0
+
+	var search func(i int, sum, rest, total int64)
+	search = func(i int, sum, rest, total int64) {
+		if tries >= coinSelectionMaxTries || sum >= high {
+			return
+		}
+		tries++
+		if sum >= low {
+			// Adding coins would only add to the excess
+			if sum-low < bestExcess {
+				if selection := c.result(selected, total); selection != nil && selection.Change == 0 {
+					best, bestExcess = selection, sum-low
+				}
+			}
+			return
+		}
+		if i == len(coins) || sum+rest < low {
+			return
+		}
+
+		selected = append(selected, coins[i])
+		search(i+1, sum+values[i], rest-values[i], total+coins[i].Value)
+		selected = selected[:len(selected)-1]
+
+		// Leaving out coins of the same value as the one left out only repeats the branches above
+		rest -= values[i]
+		next := i + 1
+		for next < len(coins) && values[next] == values[i] {
+			rest -= values[next]
+			next++
+		}
+		search(next, sum, rest, total)
+	}
+	search(0, 0, rest, 0)
+	return best
+}
+
+// randomImprove selects the shuffled coins until they cover the target and fee, then adds coins
+// while they bring the change closer to the target, without exceeding it twice over (CIP-2)
+func (c *coinSelector) randomImprove(coins AddressHistory) *CoinSelection {
+	var total int64
+	n := 0
+	for n < len(coins) && c.result(coins[:n], total) == nil {
+		total += coins[n].Value
+		n++
+	}
+	inputs := slices.Clone(coins[:n])
+
+	// change is the change the inputs worth total would leave
+	change := func(inputs int, total int64) int64 {
+		return total - c.target - c.fee(c.size(inputs, true))
+	}
+	for _, coin := range coins[n:] {
+		current, next := change(len(inputs), total), change(len(inputs)+1, total+coin.Value)
+		if next <= 2*c.target && abs64(c.target-next) < abs64(c.target-current) {
+			inputs = append(inputs, coin)
+			total += coin.Value
+		}
+	}
+	return c.result(inputs, total)
+}
+
+// abs64 returns the absolute value of n
+func abs64(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// varIntSize returns the size of n as a variable length integer (CompactSize)
+func varIntSize(n int) int {
+	switch {
+	case n < 0xfd:
+		return 1
+	case n <= 0xffff:
+		return 3
+	case n <= 0xffffffff:
+		return 5
+	}
+	return 9
+}
@@ -0,0 +1,288 @@
+package whatsonchain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testUTXOs returns UTXOs with the values, each in its own transaction
+func testUTXOs(values ...int64) AddressHistory {
+	utxos := make(AddressHistory, 0, len(values))
+	for i, value := range values {
+		utxos = append(utxos, &HistoryRecord{Height: 800000, TxHash: fmt.Sprintf("%064x", i+1), TxPos: 0, Value: value})
+	}
+	return utxos
+}
+
+// selectedValues returns the values of the selected inputs
+func selectedValues(selection *CoinSelection) []int64 {
+	values := make([]int64, 0, len(selection.Inputs))
+	for _, input := range selection.Inputs {
+		values = append(values, input.Value)
+	}
+	return values
+}
+
+// requireBalanced checks the inputs pay exactly the target, change and fee
+func requireBalanced(t *testing.T, selection *CoinSelection, target Satoshis) {
+	t.Helper()
+
+	var total Satoshis
+	for _, input := range selection.Inputs {
+		total += Satoshis(input.Value)
+	}
+	require.Equal(t, total, selection.Total)
+	require.Equal(t, selection.Total, target+selection.Change+selection.Fee)
+}
+
+// TestAddressHistory_SelectCoins tests each strategy at 50 satoshis per 1000 bytes
+func TestAddressHistory_SelectCoins(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		utxos    AddressHistory
+		strategy CoinSelectionStrategy
+		inputs   []int64
+		change   Satoshis
+		fee      Satoshis
+		size     int
+	}{
+		// 1 input and 2 outputs: 226 bytes, 12 satoshis
+		{"largest first", testUTXOs(5000, 100000, 20000, 50000), "", []int64{100000}, 39988, 12, 226},
+		// 3 inputs and 2 outputs: 522 bytes, 27 satoshis
+		{"smallest first", testUTXOs(5000, 100000, 20000, 50000), CoinSelectionSmallestFirst, []int64{5000, 20000, 50000}, 14973, 27, 522},
+		// 1 input and 1 output: 192 bytes, 10 satoshis
+		{"branch and bound single", testUTXOs(100000, 60010, 50000), CoinSelectionBranchAndBound, []int64{60010}, 0, 10, 192},
+		// 2 inputs and 1 output: 340 bytes, 17 satoshis
+		{"branch and bound pair", testUTXOs(100000, 30000, 45000, 30017), CoinSelectionBranchAndBound, []int64{30017, 30000}, 0, 17, 340},
+		// Within the cost of change: 2 satoshis over, left as fee
+		{"branch and bound excess", testUTXOs(100000, 60012), CoinSelectionBranchAndBound, []int64{60012}, 0, 12, 192},
+		{"branch and bound fallback", testUTXOs(100000, 20000, 50000), CoinSelectionBranchAndBound, []int64{100000}, 39988, 12, 226},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			selection, err := test.utxos.SelectCoins(60000, 50, &CoinSelectionOptions{Strategy: test.strategy})
+			require.NoError(t, err)
+			assert.Equal(t, test.inputs, selectedValues(selection))
+			assert.Equal(t, test.change, selection.Change)
+			assert.Equal(t, test.fee, selection.Fee)
+			assert.Equal(t, test.size, selection.Size)
+			requireBalanced(t, selection, 60000)
+		})
+	}
+}
+
+// TestAddressHistory_SelectCoins_RandomImprove tests the change is brought close to the target
+func TestAddressHistory_SelectCoins_RandomImprove(t *testing.T) {
+	t.Parallel()
+
+	t.Run("equal coins", func(t *testing.T) {
+		t.Parallel()
+		values := make([]int64, 20)
+		for i := range values {
+			values[i] = 10000
+		}
+		for range 10 {
+			selection, err := testUTXOs(values...).SelectCoins(25000, 0, &CoinSelectionOptions{Strategy: CoinSelectionRandomImprove})
+			require.NoError(t, err)
+			assert.Len(t, selection.Inputs, 5)
+			assert.Equal(t, Satoshis(25000), selection.Change)
+			requireBalanced(t, selection, 25000)
+		}
+	})
+
+	t.Run("mixed coins", func(t *testing.T) {
+		t.Parallel()
+		utxos := testUTXOs(1000, 2500, 7000, 12000, 30000, 64000, 150000, 900)
+		for range 50 {
+			selection, err := utxos.SelectCoins(20000, 100, &CoinSelectionOptions{Strategy: CoinSelectionRandomImprove})
+			require.NoError(t, err)
+			requireBalanced(t, selection, 20000)
+			assert.True(t, selection.Change == 0 || selection.Change >= defaultCoinSelectionDustLimit)
+		}
+	})
+}
+
+// TestAddressHistory_SelectCoins_Options tests the size model and dust limit options
+func TestAddressHistory_SelectCoins_Options(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sizes and outputs", func(t *testing.T) {
+		t.Parallel()
+		// 1 input of 100 bytes and 3 outputs of 40 bytes: 230 bytes, 230 satoshis
+		selection, err := testUTXOs(50000).SelectCoins(10000, 1000, &CoinSelectionOptions{InputSize: 100, OutputSize: 40, Outputs: 2})
+		require.NoError(t, err)
+		assert.Equal(t, 230, selection.Size)
+		assert.Equal(t, Satoshis(230), selection.Fee)
+		assert.Equal(t, Satoshis(39770), selection.Change)
+	})
+
+	t.Run("change below the dust limit", func(t *testing.T) {
+		t.Parallel()
+		// Change of 500 satoshis would be left
+		selection, err := testUTXOs(10726).SelectCoins(10000, 1000, &CoinSelectionOptions{DustLimit: 546})
+		require.NoError(t, err)
+		assert.Zero(t, selection.Change)
+		assert.Equal(t, Satoshis(726), selection.Fee)
+		assert.Equal(t, 192, selection.Size)
+	})
+
+	t.Run("uneconomical coins", func(t *testing.T) {
+		t.Parallel()
+		// Spending an input costs 148 satoshis
+		selection, err := testUTXOs(148, 100, 20000).SelectCoins(1000, 1000, &CoinSelectionOptions{Strategy: CoinSelectionSmallestFirst})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{20000}, selectedValues(selection))
+	})
+}
+
+// TestAddressHistory_SelectCoins_Errors tests invalid arguments and insufficient funds
+func TestAddressHistory_SelectCoins_Errors(t *testing.T) {
+	t.Parallel()
+
+	utxos := testUTXOs(5000, 100000)
+
+	_, err := utxos.SelectCoins(0, 50, nil)
+	requireInvalidInput(t, err, "target")
+
+	_, err = utxos.SelectCoins(1000, -1, nil)
+	requireInvalidInput(t, err, "feeRate")
+
+	_, err = utxos.SelectCoins(1000, 50, &CoinSelectionOptions{Strategy: "first_fit"})
+	requireInvalidInput(t, err, "strategy")
+
+	_, err = utxos.SelectCoins(105000, 50, nil)
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	_, err = AddressHistory{nil}.SelectCoins(1000, 50, nil)
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+// TestScriptList_SelectCoins tests script UTXOs are selected as history records
+func TestScriptList_SelectCoins(t *testing.T) {
+	t.Parallel()
+
+	utxos := ScriptList{
+		{Height: 800000, TxHash: testTxID1, TxPos: 1, Value: 5000},
+		{Height: 800001, TxHash: testTxID2, TxPos: 0, Value: 100000},
+	}
+	selection, err := utxos.SelectCoins(60000, 50, nil)
+	require.NoError(t, err)
+	assert.Equal(t, AddressHistory{{Height: 800001, TxHash: testTxID2, TxPos: 0, Value: 100000}}, selection.Inputs)
+	assert.Equal(t, Satoshis(39988), selection.Change)
+}
+
+// mockHTTPSpentOutputs answers BulkSpentOutputs, reporting the outpoints in spent as spent
+func mockHTTPSpentOutputs(t *testing.T, spent map[BulkSpentUTXO]bool) HTTPInterface {
+	return &mockHTTPFunc{fn: func(req *http.Request) (*http.Response, error) {
+		assert.True(t, strings.HasSuffix(req.URL.Path, "/utxos/spent"))
+		var request BulkSpentOutputRequest
+		if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+			return nil, err
+		}
+
+		response := make(BulkSpentOutputResponse, 0, len(request.UTXOs))
+		for _, utxo := range request.UTXOs {
+			result := BulkSpentOutputResult{TxID: utxo.TxID, Vout: utxo.Vout}
+			if spent[utxo] {
+				result.Spent = &SpentOutput{TxID: testTxID2, Vin: 0}
+			}
+			response = append(response, result)
+		}
+		data, _ := json.Marshal(response)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(string(data)))}, nil
+	}}
+}
+
+// TestAddressHistory_ExcludeSpent tests spent UTXOs are removed, across batches
+func TestAddressHistory_ExcludeSpent(t *testing.T) {
+	t.Parallel()
+
+	values := make([]int64, MaxTransactionsUTXO+5)
+	for i := range values {
+		values[i] = int64(1000 + i)
+	}
+	utxos := testUTXOs(values...)
+	spent := map[BulkSpentUTXO]bool{
+		{TxID: utxos[3].TxHash, Vout: 0}:                     true,
+		{TxID: utxos[MaxTransactionsUTXO+1].TxHash, Vout: 0}: true,
+	}
+
+	unspent, err := utxos.ExcludeSpent(context.Background(), newMockClient(mockHTTPSpentOutputs(t, spent)))
+	require.NoError(t, err)
+	assert.Len(t, unspent, len(utxos)-2)
+	assert.NotContains(t, unspent, utxos[3])
+	assert.NotContains(t, unspent, utxos[MaxTransactionsUTXO+1])
+	assert.Equal(t, utxos[4], unspent[3])
+
+	t.Run("none", func(t *testing.T) {
+		t.Parallel()
+		unspent, err := AddressHistory{}.ExcludeSpent(context.Background(), newMockClient(&mockHTTPFunc{}))
+		require.NoError(t, err)
+		assert.Empty(t, unspent)
+	})
+
+	t.Run("failed lookup", func(t *testing.T) {
+		t.Parallel()
+		client := newMockClient(&mockHTTPFunc{fn: func(_ *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(strings.NewReader(""))}, nil
+		}})
+		_, err := utxos.ExcludeSpent(context.Background(), client)
+		require.Error(t, err)
+	})
+
+	t.Run("outpoint missing from the response", func(t *testing.T) {
+		t.Parallel()
+		client := newMockClient(&mockHTTPFunc{fn: func(req *http.Request) (*http.Response, error) {
+			var request BulkSpentOutputRequest
+			if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+				return nil, err
+			}
+			response := make(BulkSpentOutputResponse, 0, len(request.UTXOs))
+			for _, utxo := range request.UTXOs {
+				if utxo.TxID != utxos[7].TxHash {
+					response = append(response, BulkSpentOutputResult{TxID: utxo.TxID, Vout: utxo.Vout})
+				}
+			}
+			data, _ := json.Marshal(response)
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(string(data)))}, nil
+		}})
+		unspent, err := utxos.ExcludeSpent(context.Background(), client)
+		require.ErrorIs(t, err, ErrSpentStatusUnknown)
+		assert.Contains(t, err.Error(), utxos[7].TxHash+"_0")
+		assert.Nil(t, unspent)
+	})
+
+	t.Run("txid case", func(t *testing.T) {
+		t.Parallel()
+		upper := AddressHistory{{TxHash: strings.ToUpper(testTxID1), TxPos: 1, Value: 5000}}
+		client := newMockClient(mockHTTPSpentOutputs(t, map[BulkSpentUTXO]bool{{TxID: testTxID1, Vout: 1}: true}))
+		unspent, err := upper.ExcludeSpent(context.Background(), client)
+		require.NoError(t, err)
+		assert.Empty(t, unspent)
+	})
+}
+
+// TestScriptList_ExcludeSpent tests spent script UTXOs are removed
+func TestScriptList_ExcludeSpent(t *testing.T) {
+	t.Parallel()
+
+	utxos := ScriptList{
+		{Height: 800000, TxHash: testTxID1, TxPos: 1, Value: 5000},
+		{Height: 800001, TxHash: testTxID2, TxPos: 0, Value: 100000},
+	}
+	client := newMockClient(mockHTTPSpentOutputs(t, map[BulkSpentUTXO]bool{{TxID: testTxID1, Vout: 1}: true}))
+	unspent, err := utxos.ExcludeSpent(context.Background(), client)
+	require.NoError(t, err)
+	assert.Equal(t, ScriptList{utxos[1]}, unspent)
+}
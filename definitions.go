@@ -129,7 +129,8 @@ type BlockInfo struct {
 	PreviousBlockHash string         `json:"previousblockhash"`
 	Size              int64          `json:"size"`
 	Time              int64          `json:"time"`
-	TotalFees         float64        `json:"totalFees"`
+	TotalFees         float64        `json:"totalFees"` // use TotalFeesSatoshis for exact arithmetic
+	TotalFeesSatoshis Satoshis       `json:"-"`         // exact TotalFees, set when decoding JSON
 	Tx                []string       `json:"tx"`
 	TxCount           int64          `json:"txcount"`
 	Version           int64          `json:"version"`
@@ -541,9 +542,10 @@ type VinInfo struct {
 
 // VoutInfo is the vout info inside the CoinbaseTxInfo
 type VoutInfo struct {
-	N            int64            `json:"n"`
-	ScriptPubKey ScriptPubKeyInfo `json:"scriptPubKey"`
-	Value        float64          `json:"value"`
+	N             int64            `json:"n"`
+	ScriptPubKey  ScriptPubKeyInfo `json:"scriptPubKey"`
+	Value         float64          `json:"value"` // use ValueSatoshis for exact arithmetic
+	ValueSatoshis Satoshis         `json:"-"`     // exact Value, set when decoding JSON
 }
 
 // HeaderBytesResource is the response from get header bytes file links endpoint
@@ -0,0 +1,227 @@
+package whatsonchain
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultEndpointCooldown is how long a failing endpoint is ranked below healthy ones
+	defaultEndpointCooldown = 30 * time.Second
+)
+
+// EndpointHealth is a snapshot of the health score of a single base URL
+type EndpointHealth struct {
+	BaseURL             string    `json:"base_url"`             // base URL (without chain and network)
+	ConsecutiveFailures int       `json:"consecutive_failures"` // failures since the last success (the health score, lower is better)
+	Failures            uint64    `json:"failures"`             // total connection errors and 5xx responses
+	Healthy             bool      `json:"healthy"`              // false while the endpoint is cooling down after a failure
+	LastFailure         time.Time `json:"last_failure"`         // time of the last failure (zero if none)
+	Successes           uint64    `json:"successes"`            // total successful requests
+}
+
+// WithBaseURL sets the API base URL (default: https://api.whatsonchain.com/v1/).
+// The chain and network are appended to it, e.g. a self-hosted mirror or an httptest server.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *clientOptions) {
+		c.endpoints = []string{normalizeBaseURL(baseURL)}
+	}
+}
+
+// WithEndpoints sets a list of API base URLs and enables failover between them.
+//
+// Requests go to the healthiest endpoint (fewest consecutive failures, then list order).
+// On a connection error or a 5xx response the request is sent to the next endpoint,
+// and a failing endpoint is ranked below healthy ones until its cooldown has passed.
+// Broadcasts are only failed over on connection errors, never after a 5xx response.
+func WithEndpoints(baseURLs ...string) ClientOption {
+	return func(c *clientOptions) {
+		c.endpoints = nil
+		for _, baseURL := range baseURLs {
+			if baseURL = normalizeBaseURL(baseURL); baseURL != "" {
+				c.endpoints = append(c.endpoints, baseURL)
+			}
+		}
+	}
+}
+
+// normalizeBaseURL trims spaces and makes sure the base URL ends with a slash
+func normalizeBaseURL(baseURL string) string {
+	baseURL = strings.TrimSpace(baseURL)
+	if baseURL != "" && !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	return baseURL
+}
+
+// validateBaseURL returns ErrInvalidBaseURL if baseURL is not an absolute http(s) URL
+func validateBaseURL(baseURL string) error {
+	u, err := url.Parse(baseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: %q", ErrInvalidBaseURL, baseURL)
+	}
+	return nil
+}
+
+// BaseURL returns the primary API base URL (the first endpoint)
+func (c *Client) BaseURL() string {
+	c.optionsMu.RLock()
+	defer c.optionsMu.RUnlock()
+	return c.options.baseURL()
+}
+
+// EndpointHealth returns the health score of every configured base URL, in configuration order
+func (c *Client) EndpointHealth() []EndpointHealth {
+	return c.endpoints.health()
+}
+
+// endpointState is the health score of a single base URL
+type endpointState struct {
+	baseURL             string
+	consecutiveFailures int
+	failures            uint64
+	lastFailure         time.Time
+	successes           uint64
+}
+
+// endpointPool keeps a health score per base URL and fails requests over between them, ranked by health
+type endpointPool struct {
+	cooldown  time.Duration
+	endpoints []*endpointState
+	mu        sync.Mutex
+	now       func() time.Time
+}
+
+// newEndpointPool creates a pool for the base URLs (the first one is the primary used by buildURL)
+func newEndpointPool(baseURLs []string) *endpointPool {
+	p := &endpointPool{
+		cooldown: defaultEndpointCooldown,
+		now:      time.Now,
+	}
+	for _, baseURL := range baseURLs {
+		p.endpoints = append(p.endpoints, &endpointState{baseURL: baseURL})
+	}
+	return p
+}
+
+// isHealthy returns true if the endpoint has not failed within the cooldown (must hold p.mu)
+func (p *endpointPool) isHealthy(e *endpointState) bool {
+	return e.consecutiveFailures == 0 || p.now().Sub(e.lastFailure) >= p.cooldown
+}
+
+// score ranks an endpoint, lower is better (must hold p.mu)
+func (p *endpointPool) score(e *endpointState) int {
+	if p.isHealthy(e) {
+		return 0
+	}
+	return e.consecutiveFailures
+}
+
+// ranked returns the endpoints ordered by health score, then configuration order
+func (p *endpointPool) ranked() []*endpointState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ranked := slices.Clone(p.endpoints)
+	slices.SortStableFunc(ranked, func(a, b *endpointState) int {
+		return p.score(a) - p.score(b)
+	})
+	return ranked
+}
+
+// record updates the endpoint's health score with the outcome of a request
+func (p *endpointPool) record(e *endpointState, failed bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if failed {
+		e.consecutiveFailures++
+		e.failures++
+		e.lastFailure = p.now()
+		return
+	}
+	e.consecutiveFailures = 0
+	e.successes++
+}
+
+// health returns a snapshot of every endpoint's health score
+func (p *endpointPool) health() []EndpointHealth {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	health := make([]EndpointHealth, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		health = append(health, EndpointHealth{
+			BaseURL:             e.baseURL,
+			ConsecutiveFailures: e.consecutiveFailures,
+			Failures:            e.failures,
+			Healthy:             p.isHealthy(e),
+			LastFailure:         e.lastFailure,
+			Successes:           e.successes,
+		})
+	}
+	return health
+}
+
+// do sends the request (built against the primary base URL) to each endpoint in order of health
+// until one succeeds, and returns the last result if they all fail
+func (p *endpointPool) do(request *http.Request, handler RequestHandler) (body []byte, statusCode int, err error) {
+	primary := p.endpoints[0].baseURL
+	path, ok := strings.CutPrefix(request.URL.String(), primary)
+	if !ok {
+		return handler(request)
+	}
+
+	for _, endpoint := range p.ranked() {
+		attempt := request
+		if endpoint.baseURL != primary {
+			if attempt, err = cloneRequestForURL(request, endpoint.baseURL+path); err != nil {
+				return nil, 0, err
+			}
+		}
+
+		body, statusCode, err = handler(attempt)
+
+		// The caller gave up: not the endpoint's fault
+		if request.Context().Err() != nil {
+			return body, statusCode, err
+		}
+
+		failed := err != nil || statusCode >= http.StatusInternalServerError
+		p.record(endpoint, failed)
+		if !failed || !shouldFailover(request, err) {
+			return body, statusCode, err
+		}
+	}
+
+	return body, statusCode, err
+}
+
+// shouldFailover returns true if a failed request may be sent to the next endpoint.
+// Broadcasts are only failed over on connection errors: after a 5xx the server had the transaction.
+func shouldFailover(request *http.Request, err error) bool {
+	return err != nil || !isBroadcastRequest(request)
+}
+
+// cloneRequestForURL returns a copy of the request pointed at rawURL, with a fresh body
+func cloneRequestForURL(request *http.Request, rawURL string) (*http.Request, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	clone := request.Clone(request.Context())
+	clone.URL = u
+	clone.Host = ""
+	if request.GetBody != nil {
+		if clone.Body, err = request.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	return clone, nil
+}
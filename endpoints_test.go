@@ -0,0 +1,206 @@
+package whatsonchain
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testChainInfoBody = `{"chain":"main","blocks":700000}`
+
+// newEndpointClient returns a client without retries for the base URLs
+func newEndpointClient(t *testing.T, opts ...ClientOption) ClientInterface {
+	t.Helper()
+
+	opts = append([]ClientOption{WithRequestRetryCount(0), WithRateLimit(100)}, opts...)
+	client, err := NewClient(context.Background(), opts...)
+	require.NoError(t, err)
+	return client
+}
+
+// TestWithBaseURL tests pointing the client at a different base URL
+func TestWithBaseURL(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+		client := newEndpointClient(t)
+		assert.Equal(t, apiEndpointBase, client.BaseURL())
+		require.Len(t, client.EndpointHealth(), 1)
+		assert.True(t, client.EndpointHealth()[0].Healthy)
+	})
+
+	t.Run("httptest stand-in", func(t *testing.T) {
+		t.Parallel()
+		var path string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			path = req.URL.Path
+			_, _ = w.Write([]byte(testChainInfoBody))
+		}))
+		defer server.Close()
+
+		client := newEndpointClient(t, WithBaseURL(server.URL+"/v1"))
+		assert.Equal(t, server.URL+"/v1/", client.BaseURL())
+
+		info, err := client.GetChainInfo(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(700000), info.Blocks)
+		assert.Equal(t, "/v1/bsv/main/chain/info", path)
+
+		health := client.EndpointHealth()
+		require.Len(t, health, 1)
+		assert.Equal(t, uint64(1), health[0].Successes)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		for _, baseURL := range []string{"", "api.whatsonchain.com/v1", "ftp://example.com/", "http://"} {
+			_, err := NewClient(context.Background(), WithBaseURL(baseURL))
+			require.ErrorIs(t, err, ErrInvalidBaseURL, baseURL)
+		}
+		_, err := NewClient(context.Background(), WithEndpoints("https://example.com", "not a url"))
+		require.ErrorIs(t, err, ErrInvalidBaseURL)
+	})
+}
+
+// TestWithEndpoints_Failover tests rotating to the next base URL on failures
+func TestWithEndpoints_Failover(t *testing.T) {
+	t.Parallel()
+
+	t.Run("5xx fails over and is scored", func(t *testing.T) {
+		t.Parallel()
+		down, downCount := newStatusServer(t, http.StatusServiceUnavailable)
+		mirror, mirrorCount := newStatusServer(t, http.StatusOK)
+		client := newEndpointClient(t, WithEndpoints(down.URL, mirror.URL))
+
+		info, err := client.GetChainInfo(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "main", info.Chain)
+		assert.Equal(t, int32(1), downCount.Load())
+		assert.Equal(t, int32(1), mirrorCount.Load())
+
+		// The failing endpoint is ranked last while cooling down
+		_, err = client.GetChainInfo(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), downCount.Load())
+		assert.Equal(t, int32(2), mirrorCount.Load())
+
+		health := client.EndpointHealth()
+		require.Len(t, health, 2)
+		assert.Equal(t, down.URL+"/", health[0].BaseURL)
+		assert.False(t, health[0].Healthy)
+		assert.Equal(t, 1, health[0].ConsecutiveFailures)
+		assert.Equal(t, uint64(1), health[0].Failures)
+		assert.False(t, health[0].LastFailure.IsZero())
+		assert.True(t, health[1].Healthy)
+		assert.Equal(t, uint64(2), health[1].Successes)
+	})
+
+	t.Run("connection error fails over", func(t *testing.T) {
+		t.Parallel()
+		closed, _ := newStatusServer(t, http.StatusOK)
+		closed.Close()
+		mirror, mirrorCount := newStatusServer(t, http.StatusOK)
+		client := newEndpointClient(t, WithEndpoints(closed.URL, mirror.URL))
+
+		_, err := client.GetChainInfo(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), mirrorCount.Load())
+		assert.Equal(t, 1, client.EndpointHealth()[0].ConsecutiveFailures)
+	})
+
+	t.Run("4xx does not fail over", func(t *testing.T) {
+		t.Parallel()
+		badRequest, badCount := newStatusServer(t, http.StatusBadRequest)
+		mirror, mirrorCount := newStatusServer(t, http.StatusOK)
+		client := newEndpointClient(t, WithEndpoints(badRequest.URL, mirror.URL))
+
+		_, err := client.GetChainInfo(context.Background())
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+		assert.Equal(t, int32(1), badCount.Load())
+		assert.Equal(t, int32(0), mirrorCount.Load())
+		assert.True(t, client.EndpointHealth()[0].Healthy)
+	})
+
+	t.Run("all endpoints down", func(t *testing.T) {
+		t.Parallel()
+		first, firstCount := newStatusServer(t, http.StatusInternalServerError)
+		second, secondCount := newStatusServer(t, http.StatusBadGateway)
+		client := newEndpointClient(t, WithEndpoints(first.URL, second.URL))
+
+		_, err := client.GetChainInfo(context.Background())
+		assert.True(t, IsServerError(err))
+		assert.Equal(t, int32(1), firstCount.Load())
+		assert.Equal(t, int32(1), secondCount.Load())
+	})
+
+	t.Run("broadcast is not failed over after a 5xx", func(t *testing.T) {
+		t.Parallel()
+		down, downCount := newStatusServer(t, http.StatusInternalServerError)
+		mirror, mirrorCount := newStatusServer(t, http.StatusOK)
+		client := newEndpointClient(t, WithEndpoints(down.URL, mirror.URL))
+
+		_, err := client.BroadcastTx(context.Background(), "0100")
+		require.Error(t, err)
+		assert.Equal(t, int32(1), downCount.Load())
+		assert.Equal(t, int32(0), mirrorCount.Load())
+	})
+
+	t.Run("post body is resent", func(t *testing.T) {
+		t.Parallel()
+		down, _ := newStatusServer(t, http.StatusServiceUnavailable)
+		var body string
+		mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			data, _ := io.ReadAll(req.Body)
+			body = string(data)
+			_, _ = w.Write([]byte(`[]`))
+		}))
+		defer mirror.Close()
+		client := newEndpointClient(t, WithEndpoints(down.URL, mirror.URL))
+
+		_, _ = client.BulkTransactionDetails(context.Background(), &TxHashes{TxIDs: []string{testTxID1}})
+		assert.Contains(t, body, testTxID1)
+	})
+}
+
+// TestEndpointPool_Ranking tests the health score ordering and cooldown
+func TestEndpointPool_Ranking(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	pool := newEndpointPool([]string{"https://a/", "https://b/", "https://c/"})
+	pool.now = func() time.Time { return now }
+
+	rankedURLs := func() string {
+		urls := make([]string, 0, 3)
+		for _, e := range pool.ranked() {
+			urls = append(urls, e.baseURL)
+		}
+		return strings.Join(urls, ",")
+	}
+
+	assert.Equal(t, "https://a/,https://b/,https://c/", rankedURLs())
+
+	pool.record(pool.endpoints[0], true)
+	pool.record(pool.endpoints[0], true)
+	pool.record(pool.endpoints[1], true)
+	assert.Equal(t, "https://c/,https://b/,https://a/", rankedURLs())
+
+	// After the cooldown, failing endpoints get probed again in configuration order
+	now = now.Add(defaultEndpointCooldown)
+	assert.Equal(t, "https://a/,https://b/,https://c/", rankedURLs())
+
+	// A success resets the score
+	pool.record(pool.endpoints[0], false)
+	assert.Equal(t, 0, pool.health()[0].ConsecutiveFailures)
+	assert.Equal(t, uint64(2), pool.health()[0].Failures)
+}
@@ -82,3 +82,69 @@ var ErrInvalidChain = errors.New("invalid chain type: must be one of: bsv, btc")
 
 // ErrInvalidNetwork is when an invalid network type is provided
 var ErrInvalidNetwork = errors.New("invalid network type: must be one of: main, test, stn")
+
+// ErrInvalidCacheDirectory is when the file cache directory is missing or not a directory
+var ErrInvalidCacheDirectory = errors.New("invalid cache directory")
+
+// ErrCircuitOpen is when the circuit breaker is open and requests fail fast (see CircuitOpenError)
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// ErrInvalidAmount is when an amount is malformed or finer than one satoshi
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// ErrAmountOverflow is when an amount does not fit in Satoshis (int64)
+var ErrAmountOverflow = errors.New("amount overflows int64 satoshis")
+
+// ErrInvalidTransaction is when raw transaction bytes cannot be parsed
+var ErrInvalidTransaction = errors.New("invalid transaction")
+
+// ErrInvalidBaseURL is when a base URL is not an absolute http(s) URL
+var ErrInvalidBaseURL = errors.New("invalid base URL")
+
+// ErrInvalidMerkleProof is when a Merkle proof is malformed or does not match the transaction
+var ErrInvalidMerkleProof = errors.New("invalid merkle proof")
+
+// ErrMerkleRootMismatch is when a Merkle proof computes a different root than the block header's
+var ErrMerkleRootMismatch = errors.New("merkle root mismatch")
+
+// ErrInvalidMerklePath is when a BUMP (BRC-74) Merkle path is malformed or inconsistent
+var ErrInvalidMerklePath = errors.New("invalid merkle path")
+
+// ErrInvalidBEEF is when a BEEF (BRC-62) envelope is malformed or incomplete
+var ErrInvalidBEEF = errors.New("invalid BEEF")
+
+// ErrInvalidBlockHeader is when a block header is malformed, fails proof-of-work or does not link to its parent
+var ErrInvalidBlockHeader = errors.New("invalid block header")
+
+// ErrHeaderChainGap is when fetched headers do not connect to the local header chain
+var ErrHeaderChainGap = errors.New("headers do not connect to the local chain")
+
+// ErrInvalidChainWork is when a chainwork is not a hex number
+var ErrInvalidChainWork = errors.New("invalid chainwork")
+
+// ErrInvalidAddress is when an address is not a valid Base58Check address (for the network)
+var ErrInvalidAddress = errors.New("invalid address")
+
+// ErrInvalidInput is when a hash, address or outpoint argument is malformed (see InvalidInputError)
+var ErrInvalidInput = errors.New("invalid input")
+
+// ErrSpentStatusUnknown is when the API does not report whether an outpoint is spent
+var ErrSpentStatusUnknown = errors.New("spent status unknown")
+
+// ErrReorgTooDeep is when a reorg goes deeper than the blocks a block subscription remembers
+var ErrReorgTooDeep = errors.New("reorg deeper than the blocks remembered")
+
+// ErrWebSocketHandshake is when a WebSocket server does not accept the connection
+var ErrWebSocketHandshake = errors.New("websocket handshake failed")
+
+// ErrWebSocketProtocol is when a WebSocket peer breaks the protocol (RFC 6455)
+var ErrWebSocketProtocol = errors.New("websocket protocol error")
+
+// ErrWebSocketClosed is when a WebSocket peer closes the connection
+var ErrWebSocketClosed = errors.New("websocket closed")
+
+// ErrStreamCommand is when the streaming server rejects a connect, subscribe or unsubscribe command
+var ErrStreamCommand = errors.New("stream command rejected")
+
+// ErrInsufficientFunds is when the UTXOs cannot cover the target amount and the fee
+var ErrInsufficientFunds = errors.New("insufficient funds")
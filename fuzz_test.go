@@ -1,6 +1,7 @@
 package whatsonchain
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -233,3 +234,140 @@ func createTestClient(t *testing.T) *Client {
 
 	return newClientFromOptions(opts)
 }
+
+// FuzzParseTransaction tests the local transaction parser with arbitrary bytes
+// This fuzzer ensures parsing never panics and that serializing a parsed transaction round-trips
+func FuzzParseTransaction(f *testing.F) {
+	// Seed with real transactions and truncated/malformed variants
+	for _, txHex := range []string{testRawTxBlock170, testRawTxGenesisCoinbase} {
+		raw, err := hex.DecodeString(txHex)
+		require.NoError(f, err)
+		f.Add(raw)
+		f.Add(raw[:len(raw)/2])
+	}
+	f.Add([]byte{})
+	f.Add([]byte{0x01, 0x00, 0x00, 0x00})
+	f.Add([]byte{0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00})
+	f.Add([]byte{0x01, 0x00, 0x00, 0x00, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		tx, err := ParseTransaction(raw)
+		if err != nil {
+			require.ErrorIs(t, err, ErrInvalidTransaction)
+			return
+		}
+
+		// Canonical encoding: serializing gives back the exact input
+		require.Equal(t, raw, tx.Bytes(), "serialization should round-trip")
+
+		reparsed, err := ParseTransaction(tx.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, tx.TxID(), reparsed.TxID())
+		require.Len(t, tx.TxID(), 64)
+		require.NotNil(t, tx.TxInfo())
+	})
+}
+
+// FuzzParseMerklePath tests the BUMP parser with arbitrary bytes
+// This fuzzer ensures parsing never panics and that serializing a parsed path round-trips
+func FuzzParseMerklePath(f *testing.F) {
+	raw, err := hex.DecodeString(testBUMPHex)
+	require.NoError(f, err)
+	f.Add(raw)
+	f.Add(raw[:len(raw)/2])
+	f.Add([]byte{})
+	f.Add([]byte{0x01, 0x01, 0x01, 0x00, 0x01})
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		path, err := ParseMerklePath(raw)
+		if err != nil {
+			require.ErrorIs(t, err, ErrInvalidMerklePath)
+			return
+		}
+
+		serialized, err := path.Bytes()
+		require.NoError(t, err)
+		require.Equal(t, raw, serialized, "serialization should round-trip")
+
+		// Computing roots and proofs may fail on inconsistent paths, but never panics
+		for _, txid := range path.TxIDs() {
+			_, _ = path.ComputeRoot(txid)
+		}
+		_, _ = path.TSCProofs("")
+	})
+}
+
+// FuzzParseBEEF tests the BEEF parser with arbitrary bytes
+// This fuzzer ensures parsing never panics and that serializing a parsed envelope round-trips
+func FuzzParseBEEF(f *testing.F) {
+	path, err := NewMerklePathFromTSC(170, &MerkleTSCInfo{Index: 1, Nodes: []string{testCoinbaseTxID170}, TxOrID: testTxIDBlock170})
+	require.NoError(f, err)
+	tx, err := ParseTransactionHex(testRawTxBlock170)
+	require.NoError(f, err)
+	raw, err := (&BEEF{BUMPs: []*MerklePath{path}, Transactions: []*BEEFTransaction{{HasBUMP: true, Transaction: tx}}}).Bytes()
+	require.NoError(f, err)
+	f.Add(raw)
+	f.Add(raw[:len(raw)/2])
+	f.Add([]byte{})
+	f.Add([]byte{0x01, 0x00, 0xbe, 0xef, 0x00, 0x00})
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		beef, err := ParseBEEF(raw)
+		if err != nil {
+			require.ErrorIs(t, err, ErrInvalidBEEF)
+			return
+		}
+
+		serialized, err := beef.Bytes()
+		require.NoError(t, err)
+		require.Equal(t, raw, serialized, "serialization should round-trip")
+		require.NotNil(t, beef.Subject())
+	})
+}
+
+// FuzzParseBlockHeaders tests the block header parser with arbitrary bytes
+// This fuzzer ensures parsing never panics and that serializing parsed headers round-trips
+func FuzzParseBlockHeaders(f *testing.F) {
+	raw, err := hex.DecodeString(testHeaderGenesis + testHeaderBlock1)
+	require.NoError(f, err)
+	f.Add(raw)
+	f.Add(raw[:BlockHeaderSize])
+	f.Add(raw[:BlockHeaderSize-1])
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		headers, err := ParseBlockHeaders(raw)
+		if err != nil {
+			require.ErrorIs(t, err, ErrInvalidBlockHeader)
+			return
+		}
+
+		serialized := make([]byte, 0, len(raw))
+		for _, header := range headers {
+			serialized = append(serialized, header.Bytes()...)
+			_ = header.CheckProofOfWork()
+		}
+		require.Equal(t, raw, serialized, "serialization should round-trip")
+	})
+}
+
+// FuzzDecodeAddress tests the Base58Check address decoder with arbitrary strings
+// This fuzzer ensures decoding never panics and that decoded addresses encode back to the input
+func FuzzDecodeAddress(f *testing.F) {
+	f.Add("1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH")
+	f.Add("2MzQwSSnBHWHqSAqtTVQ6v47XtaisrJa1Vc")
+	f.Add("1111111111111111111114oLvT2")
+	f.Add("")
+	f.Add("0OIl")
+
+	f.Fuzz(func(t *testing.T, address string) {
+		decoded, err := DecodeAddress(address)
+		if err != nil {
+			require.ErrorIs(t, err, ErrInvalidAddress)
+			return
+		}
+
+		require.Equal(t, address, decoded.String(), "encoding should round-trip")
+		require.Len(t, decoded.ScriptHash(), 64)
+	})
+}
@@ -0,0 +1,166 @@
+package whatsonchain
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sync"
+)
+
+// HeaderStore is a pluggable store of the block headers of one chain, indexed by height
+// (used by HeaderChain).
+//
+// Headers are appended at the tip and removed from the tip (on a reorg), so the store always
+// holds a contiguous chain from height 0. Implementations must check that each appended header
+// links to the one before it and be safe for concurrent use.
+type HeaderStore interface {
+	// Append adds headers at the tip, the first one at Height()+1
+	Append(headers ...*BlockHeader) error
+	// HeaderAt returns the header at the height, or ErrBlockNotFound
+	HeaderAt(height int64) (*BlockHeader, error)
+	// Height returns the height of the tip, or -1 if the store is empty
+	Height() int64
+	// HeightOf returns the height of the header with the hash, or ErrBlockNotFound
+	HeightOf(hash string) (int64, error)
+	// HeightOfMerkleRoot returns the height of the header with the merkle root, or ErrBlockNotFound
+	HeightOfMerkleRoot(merkleRoot string) (int64, error)
+	// Truncate removes the headers at the height and above
+	Truncate(height int64) error
+}
+
+// MemoryHeaderStore is an in-memory implementation of HeaderStore.
+//
+// Headers are kept serialized (80 bytes each) with hash and merkle root indexes,
+// so a full chain of ~900,000 headers takes roughly 200 MB.
+type MemoryHeaderStore struct {
+	byHash       map[[32]byte]int64 // block hash -> height
+	byMerkleRoot map[[32]byte]int64 // merkle root -> height (the highest if repeated)
+	hashes       [][32]byte         // block hash by height
+	headers      []byte             // serialized headers by height
+	mu           sync.RWMutex       // protects all of the above
+}
+
+// NewMemoryHeaderStore creates a new, empty in-memory header store
+func NewMemoryHeaderStore() *MemoryHeaderStore {
+	return &MemoryHeaderStore{
+		byHash:       make(map[[32]byte]int64),
+		byMerkleRoot: make(map[[32]byte]int64),
+	}
+}
+
+// Append adds headers at the tip. It returns ErrInvalidBlockHeader, and adds none of them,
+// if a header does not link to the one before it.
+func (m *MemoryHeaderStore) Append(headers ...*BlockHeader) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	linked, err := m.link(headers)
+	if err != nil {
+		return err
+	}
+	for _, header := range linked {
+		m.add(header)
+	}
+	return nil
+}
+
+// HeaderAt returns the header at the height, or ErrBlockNotFound
+func (m *MemoryHeaderStore) HeaderAt(height int64) (*BlockHeader, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if height < 0 || height >= int64(len(m.hashes)) {
+		return nil, fmt.Errorf("%w: no header at height %d", ErrBlockNotFound, height)
+	}
+	return ParseBlockHeader(m.headers[height*BlockHeaderSize : (height+1)*BlockHeaderSize])
+}
+
+// Height returns the height of the tip, or -1 if the store is empty
+func (m *MemoryHeaderStore) Height() int64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return int64(len(m.hashes)) - 1
+}
+
+// HeightOf returns the height of the header with the hash, or ErrBlockNotFound
+func (m *MemoryHeaderStore) HeightOf(hash string) (int64, error) {
+	return m.lookup(m.byHash, hash)
+}
+
+// HeightOfMerkleRoot returns the height of the header with the merkle root, or ErrBlockNotFound
+func (m *MemoryHeaderStore) HeightOfMerkleRoot(merkleRoot string) (int64, error) {
+	return m.lookup(m.byMerkleRoot, merkleRoot)
+}
+
+// Truncate removes the headers at the height and above (a height above the tip does nothing)
+func (m *MemoryHeaderStore) Truncate(height int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for h := int64(len(m.hashes)) - 1; h >= max(height, 0); h-- {
+		if m.byHash[m.hashes[h]] == h {
+			delete(m.byHash, m.hashes[h])
+		}
+		root := [32]byte(m.headers[h*BlockHeaderSize+36 : h*BlockHeaderSize+68])
+		if m.byMerkleRoot[root] == h {
+			delete(m.byMerkleRoot, root)
+		}
+		m.hashes = m.hashes[:h]
+		m.headers = m.headers[:h*BlockHeaderSize]
+	}
+	return nil
+}
+
+// storedHeader is a serialized header and its hash (internal byte order)
+type storedHeader struct {
+	hash [32]byte
+	raw  []byte
+}
+
+// link serializes and hashes the headers, checking each one links to the one before it (or the tip)
+func (m *MemoryHeaderStore) link(headers []*BlockHeader) ([]storedHeader, error) {
+	var prev [32]byte // the genesis block's previous hash is all zeros
+	if len(m.hashes) > 0 {
+		prev = m.hashes[len(m.hashes)-1]
+	}
+	linked := make([]storedHeader, 0, len(headers))
+	for i, header := range headers {
+		if header == nil {
+			return nil, fmt.Errorf("%w: missing header %d", ErrInvalidBlockHeader, i)
+		}
+		raw := header.Bytes()
+		if [32]byte(raw[4:36]) != prev {
+			return nil, fmt.Errorf("%w: header at height %d has previous hash %s, expected %s",
+				ErrInvalidBlockHeader, len(m.hashes)+i, header.PrevHash, hex.EncodeToString(reverseBytes(prev[:])))
+		}
+		prev = [32]byte(doubleSHA256(raw))
+		linked = append(linked, storedHeader{hash: prev, raw: raw})
+	}
+	return linked, nil
+}
+
+// add appends a linked header and indexes it
+func (m *MemoryHeaderStore) add(header storedHeader) {
+	height := int64(len(m.hashes))
+	m.byHash[header.hash] = height
+	m.byMerkleRoot[[32]byte(header.raw[36:68])] = height
+	m.hashes = append(m.hashes, header.hash)
+	m.headers = append(m.headers, header.raw...)
+}
+
+// lookup returns the height for a hash (hex, in display order) in one of the indexes
+func (m *MemoryHeaderStore) lookup(index map[[32]byte]int64, hash string) (int64, error) {
+	raw, err := hex.DecodeString(hash)
+	if err != nil || len(raw) != 32 {
+		return 0, fmt.Errorf("%w: invalid hash %q", ErrBlockNotFound, hash)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	height, ok := index[[32]byte(reverseBytes(raw))]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrBlockNotFound, hash)
+	}
+	return height, nil
+}
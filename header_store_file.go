@@ -0,0 +1,126 @@
+package whatsonchain
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// FileHeaderStore is a file-backed implementation of HeaderStore.
+//
+// Headers are appended to a single file of serialized 80-byte headers (the same format as
+// the header files of GetHeaderBytesFileLinks) and loaded into a MemoryHeaderStore on open,
+// which serves all reads. A partial header left at the end of the file by an interrupted
+// write is discarded on open.
+type FileHeaderStore struct {
+	file   *os.File
+	memory *MemoryHeaderStore
+	mu     sync.Mutex // serializes writes to file and memory
+}
+
+// NewFileHeaderStore opens (or creates) the header file at path and loads its headers.
+// It returns ErrInvalidBlockHeader if the stored headers do not form a chain.
+func NewFileHeaderStore(path string) (*FileHeaderStore, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600) //nolint:gosec // G304: the path is chosen by the caller
+	if err != nil {
+		return nil, err
+	}
+	store := &FileHeaderStore{file: file, memory: NewMemoryHeaderStore()}
+	if err = store.load(); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// Append writes the headers to the file and adds them at the tip.
+// It returns ErrInvalidBlockHeader, and adds none of them, if a header does not link to the one before it.
+func (f *FileHeaderStore) Append(headers ...*BlockHeader) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	height := f.memory.Height()
+	if err := f.memory.Append(headers...); err != nil {
+		return err
+	}
+
+	data := make([]byte, 0, len(headers)*BlockHeaderSize)
+	for _, header := range headers {
+		data = append(data, header.Bytes()...)
+	}
+	if _, err := f.file.WriteAt(data, (height+1)*BlockHeaderSize); err != nil {
+		_ = f.memory.Truncate(height + 1)
+		_ = f.file.Truncate((height + 1) * BlockHeaderSize)
+		return err
+	}
+	return f.file.Sync()
+}
+
+// Close closes the header file; the store must not be used afterwards
+func (f *FileHeaderStore) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.file.Close()
+}
+
+// HeaderAt returns the header at the height, or ErrBlockNotFound
+func (f *FileHeaderStore) HeaderAt(height int64) (*BlockHeader, error) {
+	return f.memory.HeaderAt(height)
+}
+
+// Height returns the height of the tip, or -1 if the store is empty
+func (f *FileHeaderStore) Height() int64 {
+	return f.memory.Height()
+}
+
+// HeightOf returns the height of the header with the hash, or ErrBlockNotFound
+func (f *FileHeaderStore) HeightOf(hash string) (int64, error) {
+	return f.memory.HeightOf(hash)
+}
+
+// HeightOfMerkleRoot returns the height of the header with the merkle root, or ErrBlockNotFound
+func (f *FileHeaderStore) HeightOfMerkleRoot(merkleRoot string) (int64, error) {
+	return f.memory.HeightOfMerkleRoot(merkleRoot)
+}
+
+// Truncate removes the headers at the height and above from the file and memory
+func (f *FileHeaderStore) Truncate(height int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if height = max(height, 0); height > f.memory.Height() {
+		return nil
+	}
+	if err := f.file.Truncate(height * BlockHeaderSize); err != nil {
+		return err
+	}
+	if err := f.file.Sync(); err != nil {
+		return err
+	}
+	return f.memory.Truncate(height)
+}
+
+// load reads the headers from the file, dropping a trailing partial header
+func (f *FileHeaderStore) load() error {
+	data, err := io.ReadAll(f.file)
+	if err != nil {
+		return err
+	}
+	if partial := len(data) % BlockHeaderSize; partial != 0 {
+		data = data[:len(data)-partial]
+		if err = f.file.Truncate(int64(len(data))); err != nil {
+			return err
+		}
+	}
+
+	headers, err := ParseBlockHeaders(data)
+	if err != nil {
+		return err
+	}
+	if err = f.memory.Append(headers...); err != nil {
+		return fmt.Errorf("header file %s: %w", f.file.Name(), err)
+	}
+	return nil
+}
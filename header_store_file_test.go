@@ -0,0 +1,87 @@
+package whatsonchain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestFileHeaderStore opens a file header store in a temporary directory
+func newTestFileHeaderStore(t *testing.T, path string) *FileHeaderStore {
+	t.Helper()
+
+	store, err := NewFileHeaderStore(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	return store
+}
+
+// TestFileHeaderStore tests the file-backed header store
+func TestFileHeaderStore(t *testing.T) {
+	t.Parallel()
+
+	testHeaderStore(t, func(t *testing.T) HeaderStore {
+		return newTestFileHeaderStore(t, filepath.Join(t.TempDir(), "headers.bin"))
+	})
+
+	t.Run("reopen", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "headers.bin")
+		headers := testMineHeaders(t, nil, 6, 0)
+
+		store, err := NewFileHeaderStore(path)
+		require.NoError(t, err)
+		require.NoError(t, store.Append(headers...))
+		require.NoError(t, store.Truncate(4))
+		require.NoError(t, store.Close())
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4*BlockHeaderSize), info.Size())
+
+		reopened := newTestFileHeaderStore(t, path)
+		assert.Equal(t, int64(3), reopened.Height())
+		height, err := reopened.HeightOf(headers[3].Hash())
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), height)
+
+		require.NoError(t, reopened.Append(headers[4]))
+		assert.Equal(t, int64(4), reopened.Height())
+	})
+
+	t.Run("partial trailing header", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "headers.bin")
+		headers := testMineHeaders(t, nil, 3, 0)
+		data := append(headers[0].Bytes(), headers[1].Bytes()...)
+		data = append(data, headers[2].Bytes()[:30]...)
+		require.NoError(t, os.WriteFile(path, data, 0o600))
+
+		store := newTestFileHeaderStore(t, path)
+		assert.Equal(t, int64(1), store.Height())
+		require.NoError(t, store.Append(headers[2]))
+
+		written, err := os.ReadFile(path) //nolint:gosec // test file in a temporary directory
+		require.NoError(t, err)
+		assert.Len(t, written, 3*BlockHeaderSize)
+	})
+
+	t.Run("unlinked headers", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "headers.bin")
+		headers := testMineHeaders(t, nil, 3, 0)
+		require.NoError(t, os.WriteFile(path, append(headers[0].Bytes(), headers[2].Bytes()...), 0o600))
+
+		_, err := NewFileHeaderStore(path)
+		require.ErrorIs(t, err, ErrInvalidBlockHeader)
+	})
+
+	t.Run("invalid path", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewFileHeaderStore(t.TempDir())
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,138 @@
+package whatsonchain
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testHeaderStore runs the HeaderStore behavior shared by every implementation
+func testHeaderStore(t *testing.T, newStore func(t *testing.T) HeaderStore) {
+	t.Helper()
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+		store := newStore(t)
+		assert.Equal(t, int64(-1), store.Height())
+		_, err := store.HeaderAt(0)
+		require.ErrorIs(t, err, ErrBlockNotFound)
+		_, err = store.HeightOf(testBlockHashGenesis)
+		require.ErrorIs(t, err, ErrBlockNotFound)
+		require.NoError(t, store.Truncate(0))
+	})
+
+	t.Run("append and lookup", func(t *testing.T) {
+		t.Parallel()
+		store := newStore(t)
+		headers := testMineHeaders(t, nil, 5, 0)
+		require.NoError(t, store.Append(headers[:2]...))
+		require.NoError(t, store.Append(headers[2:]...))
+		assert.Equal(t, int64(4), store.Height())
+
+		for i, header := range headers {
+			stored, err := store.HeaderAt(int64(i))
+			require.NoError(t, err)
+			assert.Equal(t, header, stored)
+
+			height, err := store.HeightOf(header.Hash())
+			require.NoError(t, err)
+			assert.Equal(t, int64(i), height)
+
+			height, err = store.HeightOfMerkleRoot(header.MerkleRoot)
+			require.NoError(t, err)
+			assert.Equal(t, int64(i), height)
+		}
+
+		_, err := store.HeaderAt(5)
+		require.ErrorIs(t, err, ErrBlockNotFound)
+		_, err = store.HeaderAt(-1)
+		require.ErrorIs(t, err, ErrBlockNotFound)
+		_, err = store.HeightOf("zz")
+		require.ErrorIs(t, err, ErrBlockNotFound)
+		_, err = store.HeightOfMerkleRoot(testMerkleRoot170)
+		require.ErrorIs(t, err, ErrBlockNotFound)
+	})
+
+	t.Run("rejects unlinked headers", func(t *testing.T) {
+		t.Parallel()
+		store := newStore(t)
+		headers := testMineHeaders(t, nil, 3, 0)
+
+		require.ErrorIs(t, store.Append(headers[1]), ErrInvalidBlockHeader)
+		require.ErrorIs(t, store.Append(headers[0], headers[2]), ErrInvalidBlockHeader)
+		require.ErrorIs(t, store.Append(headers[0], nil), ErrInvalidBlockHeader)
+		assert.Equal(t, int64(-1), store.Height())
+
+		require.NoError(t, store.Append(headers[0]))
+		require.ErrorIs(t, store.Append(headers[0]), ErrInvalidBlockHeader)
+		assert.Equal(t, int64(0), store.Height())
+	})
+
+	t.Run("truncate", func(t *testing.T) {
+		t.Parallel()
+		store := newStore(t)
+		headers := testMineHeaders(t, nil, 5, 0)
+		require.NoError(t, store.Append(headers...))
+
+		require.NoError(t, store.Truncate(10))
+		assert.Equal(t, int64(4), store.Height())
+
+		require.NoError(t, store.Truncate(3))
+		assert.Equal(t, int64(2), store.Height())
+		_, err := store.HeightOf(headers[3].Hash())
+		require.ErrorIs(t, err, ErrBlockNotFound)
+		_, err = store.HeightOfMerkleRoot(headers[4].MerkleRoot)
+		require.ErrorIs(t, err, ErrBlockNotFound)
+
+		// A different branch can now be appended at height 3
+		fork := testMineHeaders(t, headers[2], 2, 1)
+		require.NoError(t, store.Append(fork...))
+		assert.Equal(t, int64(4), store.Height())
+		height, err := store.HeightOf(fork[1].Hash())
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), height)
+
+		require.NoError(t, store.Truncate(-1))
+		assert.Equal(t, int64(-1), store.Height())
+	})
+
+	t.Run("concurrent reads", func(t *testing.T) {
+		t.Parallel()
+		store := newStore(t)
+		headers := testMineHeaders(t, nil, 20, 0)
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, header := range headers {
+				assert.NoError(t, store.Append(header))
+			}
+		}()
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 50; j++ {
+					if height := store.Height(); height >= 0 {
+						_, err := store.HeaderAt(height)
+						assert.NoError(t, err)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int64(19), store.Height())
+	})
+}
+
+// TestMemoryHeaderStore tests the in-memory header store
+func TestMemoryHeaderStore(t *testing.T) {
+	t.Parallel()
+
+	testHeaderStore(t, func(_ *testing.T) HeaderStore {
+		return NewMemoryHeaderStore()
+	})
+}
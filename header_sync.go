@@ -0,0 +1,364 @@
+package whatsonchain
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	// chainTipStatusActive is the GetChainTips status of the tip of the active chain
+	chainTipStatusActive = "active"
+
+	// defaultMaxLatestHeaders is the default HeaderChainOptions.MaxLatestHeaders
+	defaultMaxLatestHeaders = 1000
+
+	// headerSyncReorgMargin is how many headers below the local tip a Sync requests first,
+	// so a shallow reorg can be resolved without a second request
+	headerSyncReorgMargin = 10
+)
+
+// HeaderChainOptions are the options for NewHeaderChain
+type HeaderChainOptions struct {
+	// MaxLatestHeaders is the most headers requested from GetLatestHeaderBytes in one Sync
+	// (default 1000). A store further behind the tip is first bootstrapped from the header files.
+	MaxLatestHeaders int
+}
+
+// HeaderSyncResult is the outcome of a HeaderChain.Sync
+type HeaderSyncResult struct {
+	Added   int    `json:"added"`   // headers appended
+	Height  int64  `json:"height"`  // height of the local tip after the sync
+	Removed int    `json:"removed"` // headers rolled back by a reorg
+	Tip     string `json:"tip"`     // hash of the local tip after the sync
+}
+
+// HeaderChain keeps a local, validated copy of the active chain's block headers in a HeaderStore,
+// for SPV lookups without an API request (e.g. checking a merkle root against its block).
+//
+// Sync bootstraps an empty (or far behind) store from the header files of GetHeaderBytesFileLinks,
+// then follows the active tip of GetChainTips using GetLatestHeaderBytes, rolling back headers that
+// are no longer on the active chain. Every header must link to its parent and meet the proof-of-work
+// target in its bits. Call Sync periodically to keep up.
+type HeaderChain struct {
+	client           ClientInterface
+	maxLatestHeaders int
+	mu               sync.Mutex // serializes Sync
+	store            HeaderStore
+	work             *big.Int   // chainwork up to workHeight
+	workHash         string     // hash of the block at workHeight (to detect a reorg)
+	workHeight       int64      // height work was computed to (-1 = none)
+	workMu           sync.Mutex // protects work, workHash and workHeight
+}
+
+// NewHeaderChain creates a header chain kept in the store, syncing through the client.
+// The store should only ever be used with one network.
+func NewHeaderChain(client ClientInterface, store HeaderStore, opts *HeaderChainOptions) *HeaderChain {
+	chain := &HeaderChain{
+		client:           client,
+		maxLatestHeaders: defaultMaxLatestHeaders,
+		store:            store,
+		work:             new(big.Int),
+		workHeight:       -1,
+	}
+	if opts != nil && opts.MaxLatestHeaders > 0 {
+		chain.maxLatestHeaders = opts.MaxLatestHeaders
+	}
+	return chain
+}
+
+// Sync brings the store up to the active chain tip. Headers stored before an error are kept,
+// so a failed Sync can be resumed by calling it again.
+func (h *HeaderChain) Sync(ctx context.Context) (*HeaderSyncResult, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	tip, err := activeChainTip(ctx, h.client)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &HeaderSyncResult{}
+	if h.store.Height() < tip.Height-int64(h.maxLatestHeaders) {
+		if err = h.bootstrap(ctx, tip, result); err != nil {
+			return nil, err
+		}
+	}
+	if err = h.follow(ctx, tip, result); err != nil {
+		return nil, err
+	}
+
+	result.Height = h.store.Height()
+	header, err := h.store.HeaderAt(result.Height)
+	if err != nil {
+		return nil, err
+	}
+	result.Tip = header.Hash()
+	return result, nil
+}
+
+// Height returns the height of the local tip, or -1 if no headers are stored
+func (h *HeaderChain) Height() int64 {
+	return h.store.Height()
+}
+
+// HeaderAt returns the header at the height, or ErrBlockNotFound
+func (h *HeaderChain) HeaderAt(height int64) (*BlockHeader, error) {
+	return h.store.HeaderAt(height)
+}
+
+// HashAt returns the hash of the block at the height, or ErrBlockNotFound
+func (h *HeaderChain) HashAt(height int64) (string, error) {
+	header, err := h.store.HeaderAt(height)
+	if err != nil {
+		return "", err
+	}
+	return header.Hash(), nil
+}
+
+// HeaderByHash returns the header with the hash and its height, or ErrBlockNotFound
+func (h *HeaderChain) HeaderByHash(hash string) (*BlockHeader, int64, error) {
+	return h.headerAtLookup(h.store.HeightOf(hash))
+}
+
+// HeaderByMerkleRoot returns the header with the merkle root and its height, or ErrBlockNotFound
+func (h *HeaderChain) HeaderByMerkleRoot(merkleRoot string) (*BlockHeader, int64, error) {
+	return h.headerAtLookup(h.store.HeightOfMerkleRoot(merkleRoot))
+}
+
+// ChainWork returns the total work of the stored headers (see BlockHeader.Work), to compare with
+// ChainInfo.ChainWork using ParseChainWork. The total is kept between calls, and only recomputed
+// from genesis after a reorg.
+func (h *HeaderChain) ChainWork() (*big.Int, error) {
+	h.workMu.Lock()
+	defer h.workMu.Unlock()
+
+	work, workHeight := h.work, h.workHeight
+	if workHeight >= 0 {
+		if hash, err := h.HashAt(workHeight); err != nil || hash != h.workHash {
+			work, workHeight = new(big.Int), -1
+		}
+	}
+
+	tip := h.store.Height()
+	if workHeight == tip {
+		return new(big.Int).Set(work), nil
+	}
+	work = new(big.Int).Set(work)
+	var header *BlockHeader
+	for height := workHeight + 1; height <= tip; height++ {
+		var err error
+		if header, err = h.store.HeaderAt(height); err != nil {
+			return nil, err
+		}
+		work.Add(work, header.Work())
+	}
+	h.work, h.workHash, h.workHeight = work, header.Hash(), tip
+	return new(big.Int).Set(work), nil
+}
+
+// IsValidRootForHeight reports whether the merkle root is the one of the block at the height.
+// It returns ErrBlockNotFound if the height is not stored (yet).
+func (h *HeaderChain) IsValidRootForHeight(merkleRoot string, height int64) (bool, error) {
+	header, err := h.store.HeaderAt(height)
+	if err != nil {
+		return false, err
+	}
+	return strings.EqualFold(header.MerkleRoot, merkleRoot), nil
+}
+
+// VerifyMerklePath checks a BUMP against the stored headers: the root it computes for the
+// transaction must be the merkle root of the block at its height.
+// It returns ErrMerkleRootMismatch if not, or ErrBlockNotFound if the height is not stored (yet).
+func (h *HeaderChain) VerifyMerklePath(path *MerklePath, txid string) error {
+	root, err := path.ComputeRoot(txid)
+	if err != nil {
+		return err
+	}
+	height := int64(path.BlockHeight) //nolint:gosec // heights above int64 are not stored
+	valid, err := h.IsValidRootForHeight(root, height)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return fmt.Errorf("%w: computed %s for block %d", ErrMerkleRootMismatch, root, height)
+	}
+	return nil
+}
+
+// headerAtLookup returns the header at the height returned by a store lookup
+func (h *HeaderChain) headerAtLookup(height int64, err error) (*BlockHeader, int64, error) {
+	if err != nil {
+		return nil, 0, err
+	}
+	header, err := h.store.HeaderAt(height)
+	if err != nil {
+		return nil, 0, err
+	}
+	return header, height, nil
+}
+
+// activeChainTip returns the tip of the active chain from GetChainTips
+func activeChainTip(ctx context.Context, client ClientInterface) (*ChainTip, error) {
+	tips, err := client.GetChainTips(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, tip := range tips {
+		if tip != nil && tip.Status == chainTipStatusActive {
+			return tip, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: no %q chain tip", ErrChainTipsNotFound, chainTipStatusActive)
+}
+
+// bootstrap appends the headers of the header files (in order, from genesis) until the store
+// is within MaxLatestHeaders of the tip
+func (h *HeaderChain) bootstrap(ctx context.Context, tip *ChainTip, result *HeaderSyncResult) error {
+	resource, err := h.client.GetHeaderBytesFileLinks(ctx)
+	if err != nil {
+		return err
+	}
+	for _, link := range resource.Links {
+		if h.store.Height() >= tip.Height-int64(h.maxLatestHeaders) {
+			return nil
+		}
+		data, err := h.client.GetHeaderBytesFile(ctx, link.URI)
+		if err != nil {
+			return err
+		}
+		headers, err := parseHeaderFile(data)
+		if err != nil {
+			return fmt.Errorf("header file %s: %w", link.URI, err)
+		}
+		if err = h.extend(headers, result); err != nil {
+			return fmt.Errorf("header file %s: %w", link.URI, err)
+		}
+	}
+	return nil
+}
+
+// extend appends the headers above the local tip from a run of consecutive headers,
+// checking the ones already stored match
+func (h *HeaderChain) extend(headers []*BlockHeader, result *HeaderSyncResult) error {
+	if len(headers) == 0 {
+		return nil
+	}
+
+	// Find the height of the first header from its parent
+	var start int64
+	if headers[0].PrevHash != zeroHash {
+		parent, err := h.store.HeightOf(headers[0].PrevHash)
+		if err != nil {
+			return fmt.Errorf("%w: the headers start after unknown block %s", ErrHeaderChainGap, headers[0].PrevHash)
+		}
+		start = parent + 1
+	}
+
+	// Skip the headers already stored
+	if stored := min(h.store.Height()-start+1, int64(len(headers))); stored > 0 {
+		local, err := h.store.HeaderAt(start + stored - 1)
+		if err != nil {
+			return err
+		}
+		if local.Hash() != headers[stored-1].Hash() {
+			return fmt.Errorf("%w: block %s at height %d conflicts with the local chain",
+				ErrInvalidBlockHeader, headers[stored-1].Hash(), start+stored-1)
+		}
+		headers = headers[stored:]
+	}
+
+	for _, header := range headers {
+		if err := header.CheckProofOfWork(); err != nil {
+			return err
+		}
+	}
+	if err := h.store.Append(headers...); err != nil {
+		return err
+	}
+	result.Added += len(headers)
+	return nil
+}
+
+// follow moves the local tip to the active tip using the latest headers, first requesting a
+// few below the local tip and then (if the fork is deeper) MaxLatestHeaders
+func (h *HeaderChain) follow(ctx context.Context, tip *ChainTip, result *HeaderSyncResult) error {
+	if h.store.Height() == tip.Height {
+		if local, err := h.store.HeaderAt(tip.Height); err == nil && strings.EqualFold(local.Hash(), tip.Hash) {
+			return nil
+		}
+	}
+
+	count := min(max(tip.Height-h.store.Height(), 0)+headerSyncReorgMargin, int64(h.maxLatestHeaders))
+	err := h.followLatest(ctx, tip, int(count), result)
+	if errors.Is(err, ErrHeaderChainGap) && count < int64(h.maxLatestHeaders) {
+		err = h.followLatest(ctx, tip, h.maxLatestHeaders, result)
+	}
+	return err
+}
+
+// followLatest walks back from the active tip through the latest headers to the local chain,
+// rolls back the local headers above the fork point and appends the active branch
+func (h *HeaderChain) followLatest(ctx context.Context, tip *ChainTip, count int, result *HeaderSyncResult) error {
+	headers, err := h.client.GetLatestBlockHeaders(ctx, count)
+	if err != nil {
+		return err
+	}
+	byHash := make(map[string]*BlockHeader, len(headers))
+	for _, header := range headers {
+		byHash[header.Hash()] = header
+	}
+
+	// Walk back to the fork point (-1 if the branch starts at genesis)
+	var branch []*BlockHeader
+	fork := int64(-1)
+	for hash := strings.ToLower(tip.Hash); hash != zeroHash; {
+		if height, lookupErr := h.store.HeightOf(hash); lookupErr == nil {
+			fork = height
+			break
+		}
+		header, ok := byHash[hash]
+		if !ok {
+			return fmt.Errorf("%w: block %s is not in the latest %d headers", ErrHeaderChainGap, hash, count)
+		}
+		branch = append(branch, header)
+		hash = header.PrevHash
+	}
+	slices.Reverse(branch)
+
+	// Validate the whole branch before changing the store
+	if height := fork + int64(len(branch)); height != tip.Height {
+		return fmt.Errorf("%w: chain tip %s is at height %d, its headers put it at %d",
+			ErrInvalidBlockHeader, tip.Hash, tip.Height, height)
+	}
+	for _, header := range branch {
+		if err = header.CheckProofOfWork(); err != nil {
+			return err
+		}
+	}
+
+	if removed := h.store.Height() - fork; removed > 0 {
+		if err = h.store.Truncate(fork + 1); err != nil {
+			return err
+		}
+		result.Removed += int(removed)
+	}
+	if err = h.store.Append(branch...); err != nil {
+		return err
+	}
+	result.Added += len(branch)
+	return nil
+}
+
+// parseHeaderFile parses a header file of concatenated headers, in binary or hex
+func parseHeaderFile(data []byte) ([]*BlockHeader, error) {
+	if raw, err := hex.DecodeString(strings.TrimSpace(string(data))); err == nil {
+		data = raw
+	}
+	return ParseBlockHeaders(data)
+}
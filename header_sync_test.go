@@ -0,0 +1,352 @@
+package whatsonchain
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockHeaderAPI serves chain tips, header files and the latest headers of a mutable active chain
+type mockHeaderAPI struct {
+	chain       []*BlockHeader    // the active chain, from genesis
+	files       map[string][]byte // header file uri -> contents
+	latestCalls []int             // counts requested from the latest headers endpoint
+	mu          sync.Mutex
+	tipHeight   int64 // overrides the active tip height if not zero
+}
+
+// setChain replaces the active chain
+func (m *mockHeaderAPI) setChain(chain []*BlockHeader) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.chain = chain
+}
+
+// addFile serves the headers (from height start) as a header file, in hex if asHex
+func (m *mockHeaderAPI) addFile(start, end int, asHex bool) {
+	var data []byte
+	for _, header := range m.chain[start:end] {
+		data = append(data, header.Bytes()...)
+	}
+	if asHex {
+		data = []byte(hex.EncodeToString(data) + "\n")
+	}
+	if m.files == nil {
+		m.files = make(map[string][]byte)
+	}
+	m.files["https://headers.example.com/"+strconv.Itoa(start)+"_"+strconv.Itoa(end-1)+"_headers.bin"] = data
+}
+
+// Do is a mock http request
+func (m *mockHeaderAPI) Do(req *http.Request) (*http.Response, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var body []byte
+	switch path := req.URL.Path; {
+	case m.files[req.URL.String()] != nil:
+		body = m.files[req.URL.String()]
+	case strings.HasSuffix(path, "/chain/tips"):
+		tipHeight := int64(len(m.chain)) - 1
+		if m.tipHeight != 0 {
+			tipHeight = m.tipHeight
+		}
+		body, _ = json.Marshal([]*ChainTip{
+			{Hash: testBlockHash170, Height: tipHeight + 1, BranchLen: 1, Status: "valid-headers"},
+			{Hash: m.chain[len(m.chain)-1].Hash(), Height: tipHeight, Status: chainTipStatusActive},
+		})
+	case strings.HasSuffix(path, "/block/headers/resources"):
+		resource := &HeaderBytesResource{}
+		for uri := range m.files {
+			resource.Links = append(resource.Links, HeaderBytesResourceLink{Format: "binary", URI: uri})
+		}
+		slices.SortFunc(resource.Links, func(a, b HeaderBytesResourceLink) int { // in order from genesis
+			return cmp.Compare(testHeaderFileStart(a.URI), testHeaderFileStart(b.URI))
+		})
+		body, _ = json.Marshal(resource)
+	case strings.HasSuffix(path, "/block/headers/latest"):
+		count, _ := strconv.Atoi(req.URL.Query().Get("count"))
+		m.latestCalls = append(m.latestCalls, count)
+		var latest bytes.Buffer
+		for _, header := range m.chain[max(0, len(m.chain)-count):] {
+			latest.WriteString(header.Hex())
+		}
+		body = latest.Bytes()
+	default:
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(body))}, nil
+}
+
+// testHeaderFileStart returns the first height in a mock header file uri
+func testHeaderFileStart(uri string) int {
+	start, _ := strconv.Atoi(strings.Split(strings.TrimPrefix(uri, "https://headers.example.com/"), "_")[0])
+	return start
+}
+
+// latest returns the counts requested from the latest headers endpoint
+func (m *mockHeaderAPI) latest() []int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]int(nil), m.latestCalls...)
+}
+
+// TestHeaderChain_Sync tests bootstrapping and following the active chain
+func TestHeaderChain_Sync(t *testing.T) {
+	t.Parallel()
+
+	t.Run("bootstrap from header files", func(t *testing.T) {
+		t.Parallel()
+		headers := testMineHeaders(t, nil, 250, 0)
+		mock := &mockHeaderAPI{chain: headers}
+		mock.addFile(0, 100, false)
+		mock.addFile(100, 200, true)
+		mock.addFile(200, 220, false)
+		chain := NewHeaderChain(newCachedMockClient(mock), NewMemoryHeaderStore(), &HeaderChainOptions{MaxLatestHeaders: 50})
+
+		result, err := chain.Sync(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, &HeaderSyncResult{Added: 250, Height: 249, Tip: headers[249].Hash()}, result)
+		assert.Equal(t, []int{50}, mock.latest()) // the third file was not needed
+
+		hash, err := chain.HashAt(123)
+		require.NoError(t, err)
+		assert.Equal(t, headers[123].Hash(), hash)
+
+		// Nothing to do at the tip
+		result, err = chain.Sync(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, &HeaderSyncResult{Height: 249, Tip: headers[249].Hash()}, result)
+		assert.Len(t, mock.latest(), 1)
+	})
+
+	t.Run("resume into a file store", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "headers.bin")
+		headers := testMineHeaders(t, nil, 60, 0)
+		mock := &mockHeaderAPI{chain: headers[:40]}
+		mock.addFile(0, 30, false)
+		client := newCachedMockClient(mock)
+
+		store := newTestFileHeaderStore(t, path)
+		_, err := NewHeaderChain(client, store, &HeaderChainOptions{MaxLatestHeaders: 20}).Sync(context.Background())
+		require.NoError(t, err)
+		require.NoError(t, store.Close())
+
+		// New blocks, and a file overlapping the stored headers
+		mock.setChain(headers)
+		mock.addFile(30, 45, true)
+		reopened := newTestFileHeaderStore(t, path)
+		assert.Equal(t, int64(39), reopened.Height())
+		chain := NewHeaderChain(client, reopened, &HeaderChainOptions{MaxLatestHeaders: 15})
+		result, err := chain.Sync(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 20, result.Added)
+		assert.Equal(t, int64(59), chain.Height())
+	})
+
+	t.Run("follow new blocks", func(t *testing.T) {
+		t.Parallel()
+		headers := testMineHeaders(t, nil, 40, 0)
+		mock := &mockHeaderAPI{chain: headers[:30]}
+		chain := NewHeaderChain(newCachedMockClient(mock), NewMemoryHeaderStore(), nil)
+
+		result, err := chain.Sync(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 30, result.Added)
+
+		mock.setChain(headers)
+		result, err = chain.Sync(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, &HeaderSyncResult{Added: 10, Height: 39, Tip: headers[39].Hash()}, result)
+		assert.Equal(t, []int{40, 20}, mock.latest())
+	})
+
+	t.Run("shallow reorg", func(t *testing.T) {
+		t.Parallel()
+		headers := testMineHeaders(t, nil, 30, 0)
+		mock := &mockHeaderAPI{chain: headers}
+		chain := NewHeaderChain(newCachedMockClient(mock), NewMemoryHeaderStore(), nil)
+		_, err := chain.Sync(context.Background())
+		require.NoError(t, err)
+
+		fork := testMineHeaders(t, headers[26], 5, 1)
+		mock.setChain(append(append([]*BlockHeader(nil), headers[:27]...), fork...))
+		result, err := chain.Sync(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, &HeaderSyncResult{Added: 5, Height: 31, Removed: 3, Tip: fork[4].Hash()}, result)
+		assert.Equal(t, []int{40, 12}, mock.latest())
+
+		hash, err := chain.HashAt(27)
+		require.NoError(t, err)
+		assert.Equal(t, fork[0].Hash(), hash)
+		_, _, err = chain.HeaderByHash(headers[27].Hash())
+		require.ErrorIs(t, err, ErrBlockNotFound)
+	})
+
+	t.Run("deep reorg to a shorter chain", func(t *testing.T) {
+		t.Parallel()
+		headers := testMineHeaders(t, nil, 30, 0)
+		mock := &mockHeaderAPI{chain: headers}
+		chain := NewHeaderChain(newCachedMockClient(mock), NewMemoryHeaderStore(), nil)
+		_, err := chain.Sync(context.Background())
+		require.NoError(t, err)
+
+		fork := testMineHeaders(t, headers[4], 20, 1)
+		mock.setChain(append(append([]*BlockHeader(nil), headers[:5]...), fork...))
+		result, err := chain.Sync(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, &HeaderSyncResult{Added: 20, Height: 24, Removed: 25, Tip: fork[19].Hash()}, result)
+		assert.Equal(t, []int{40, 10, defaultMaxLatestHeaders}, mock.latest())
+	})
+
+	t.Run("invalid proof-of-work", func(t *testing.T) {
+		t.Parallel()
+		headers := testMineHeaders(t, nil, 10, 0)
+		invalid := testMineHeaders(t, headers[9], 1, 0)[0]
+		for invalid.CheckProofOfWork() == nil {
+			invalid.Nonce++
+		}
+		mock := &mockHeaderAPI{chain: append(headers, invalid)}
+		store := NewMemoryHeaderStore()
+
+		_, err := NewHeaderChain(newCachedMockClient(mock), store, nil).Sync(context.Background())
+		require.ErrorIs(t, err, ErrInvalidBlockHeader)
+		assert.Equal(t, int64(-1), store.Height())
+	})
+
+	t.Run("tip height mismatch", func(t *testing.T) {
+		t.Parallel()
+		mock := &mockHeaderAPI{chain: testMineHeaders(t, nil, 10, 0), tipHeight: 12}
+		_, err := NewHeaderChain(newCachedMockClient(mock), NewMemoryHeaderStore(), nil).Sync(context.Background())
+		require.ErrorIs(t, err, ErrInvalidBlockHeader)
+	})
+
+	t.Run("gap", func(t *testing.T) {
+		t.Parallel()
+		mock := &mockHeaderAPI{chain: testMineHeaders(t, nil, 30, 0)}
+		mock.addFile(5, 10, false)
+		_, err := NewHeaderChain(newCachedMockClient(mock), NewMemoryHeaderStore(), &HeaderChainOptions{MaxLatestHeaders: 10}).
+			Sync(context.Background())
+		require.ErrorIs(t, err, ErrHeaderChainGap)
+
+		mock = &mockHeaderAPI{chain: testMineHeaders(t, nil, 30, 0)}
+		_, err = NewHeaderChain(newCachedMockClient(mock), NewMemoryHeaderStore(), &HeaderChainOptions{MaxLatestHeaders: 10}).
+			Sync(context.Background())
+		require.ErrorIs(t, err, ErrHeaderChainGap)
+	})
+
+	t.Run("no active tip", func(t *testing.T) {
+		t.Parallel()
+		client := newCachedMockClient(&mockHTTPCacheable{bodies: map[string]string{
+			"/chain/tips": `[{"height":1,"hash":"` + testBlockHash1 + `","status":"valid-fork"}]`,
+		}})
+		_, err := NewHeaderChain(client, NewMemoryHeaderStore(), nil).Sync(context.Background())
+		require.ErrorIs(t, err, ErrChainTipsNotFound)
+	})
+}
+
+// TestHeaderChain_Queries tests looking up synced headers
+func TestHeaderChain_Queries(t *testing.T) {
+	t.Parallel()
+
+	headers := testMineHeaders(t, nil, 10, 0)
+	chain := NewHeaderChain(newCachedMockClient(&mockHeaderAPI{chain: headers}), NewMemoryHeaderStore(), nil)
+	_, err := chain.Sync(context.Background())
+	require.NoError(t, err)
+
+	header, height, err := chain.HeaderByHash(strings.ToUpper(headers[7].Hash()))
+	require.NoError(t, err)
+	assert.Equal(t, headers[7], header)
+	assert.Equal(t, int64(7), height)
+
+	header, height, err = chain.HeaderByMerkleRoot(headers[3].MerkleRoot)
+	require.NoError(t, err)
+	assert.Equal(t, headers[3], header)
+	assert.Equal(t, int64(3), height)
+
+	_, _, err = chain.HeaderByMerkleRoot(testMerkleRoot170)
+	require.ErrorIs(t, err, ErrBlockNotFound)
+	_, err = chain.HashAt(10)
+	require.ErrorIs(t, err, ErrBlockNotFound)
+
+	valid, err := chain.IsValidRootForHeight(headers[5].MerkleRoot, 5)
+	require.NoError(t, err)
+	assert.True(t, valid)
+	valid, err = chain.IsValidRootForHeight(headers[5].MerkleRoot, 6)
+	require.NoError(t, err)
+	assert.False(t, valid)
+	_, err = chain.IsValidRootForHeight(headers[5].MerkleRoot, 100)
+	require.ErrorIs(t, err, ErrBlockNotFound)
+
+	// A block with a single transaction has the txid as its merkle root
+	txid := headers[4].MerkleRoot
+	path := &MerklePath{BlockHeight: 4, Path: [][]*MerklePathLeaf{{{Hash: txid, TxID: true}}}}
+	require.NoError(t, chain.VerifyMerklePath(path, txid))
+	path.BlockHeight = 5
+	require.ErrorIs(t, chain.VerifyMerklePath(path, txid), ErrMerkleRootMismatch)
+	require.ErrorIs(t, chain.VerifyMerklePath(path, testTxID1), ErrInvalidMerklePath)
+}
+
+// TestHeaderChain_ChainWork tests the chainwork of the stored headers is kept across syncs and reorgs
+func TestHeaderChain_ChainWork(t *testing.T) {
+	t.Parallel()
+
+	headers := testMineHeaders(t, nil, 20, 0)
+	mock := &mockHeaderAPI{chain: headers[:10]}
+	store := NewMemoryHeaderStore()
+	chain := NewHeaderChain(newCachedMockClient(mock), store, nil)
+
+	work, err := chain.ChainWork()
+	require.NoError(t, err)
+	assert.Zero(t, work.Sign())
+
+	_, err = chain.Sync(context.Background())
+	require.NoError(t, err)
+	work, err = chain.ChainWork()
+	require.NoError(t, err)
+	assert.Equal(t, ChainWork(nil, headers[:10]...), work)
+	assert.Equal(t, big.NewInt(20), work) // 2 per test header
+
+	// The returned value is a copy
+	work.SetInt64(0)
+	work, err = chain.ChainWork()
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(20), work)
+
+	mock.setChain(headers)
+	_, err = chain.Sync(context.Background())
+	require.NoError(t, err)
+	work, err = chain.ChainWork()
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(40), work)
+
+	// A reorg to a harder branch (with more work per block) is picked up
+	fork := testMineHeaders(t, headers[17], 1, 1)
+	fork[0].Bits = 0x2000ffff
+	for fork[0].CheckProofOfWork() != nil {
+		fork[0].Nonce++
+	}
+	mock.setChain(append(append([]*BlockHeader(nil), headers[:18]...), fork...))
+	result, err := chain.Sync(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Removed)
+	work, err = chain.ChainWork()
+	require.NoError(t, err)
+	assert.Equal(t, ChainWork(big.NewInt(36), fork[0]), work)
+	assert.Equal(t, FormatChainWork(ChainWork(nil, append(headers[:18:18], fork...)...)), FormatChainWork(work))
+}
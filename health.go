@@ -9,5 +9,5 @@ import (
 // For more information: https://docs.whatsonchain.com/#health
 func (c *Client) GetHealth(ctx context.Context) (string, error) {
 	url := c.buildURL("/woc")
-	return requestString(ctx, c, url)
+	return requestString(ctx, c, url, nil)
 }
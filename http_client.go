@@ -2,14 +2,21 @@ package whatsonchain
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
+	"errors"
 	"io"
 	"math"
 	"math/big"
 	"net/http"
+	"net/http/httptrace"
+	"sync/atomic"
 	"time"
 )
 
+// defaultMaxRetryAfter is the longest Retry-After delay the RetryableHTTPClient waits by default
+const defaultMaxRetryAfter = time.Minute
+
 // ExponentialBackoff provides exponential backoff functionality
 type ExponentialBackoff struct {
 	initialTimeout    time.Duration
@@ -58,9 +65,13 @@ func (eb *ExponentialBackoff) NextInterval(attempt int) time.Duration {
 
 // RetryableHTTPClient is a native Go HTTP client with retry capability
 type RetryableHTTPClient struct {
-	client     *http.Client
-	retryCount int
-	backoff    *ExponentialBackoff
+	client        *http.Client
+	retryCount    int
+	backoff       *ExponentialBackoff
+	breaker       *CircuitBreaker
+	hooks         []RetryHook
+	maxRetryAfter time.Duration
+	policy        RetryPolicy
 }
 
 // NewRetryableHTTPClient creates a new retryable HTTP client
@@ -70,20 +81,54 @@ func NewRetryableHTTPClient(httpClient *http.Client, retryCount int, backoff *Ex
 	}
 
 	return &RetryableHTTPClient{
-		client:     httpClient,
-		retryCount: retryCount,
-		backoff:    backoff,
+		client:        httpClient,
+		retryCount:    retryCount,
+		backoff:       backoff,
+		maxRetryAfter: defaultMaxRetryAfter,
+		policy:        DefaultRetryPolicy{},
 	}
 }
 
+// SetRetryPolicy sets the policy that decides which failed attempts are retried (nil restores DefaultRetryPolicy)
+func (r *RetryableHTTPClient) SetRetryPolicy(policy RetryPolicy) {
+	if policy == nil {
+		policy = DefaultRetryPolicy{}
+	}
+	r.policy = policy
+}
+
+// AddRetryHook adds a hook that is called after every attempt, including the first (even with no retries)
+func (r *RetryableHTTPClient) AddRetryHook(hook RetryHook) {
+	if hook != nil {
+		r.hooks = append(r.hooks, hook)
+	}
+}
+
+// SetMaxRetryAfter sets the longest Retry-After delay that is waited before retrying (zero or less restores the
+// default of 1 minute). A response asking for a longer wait is not retried but returned, so the caller can decide.
+func (r *RetryableHTTPClient) SetMaxRetryAfter(limit time.Duration) {
+	if limit <= 0 {
+		limit = defaultMaxRetryAfter
+	}
+	r.maxRetryAfter = limit
+}
+
+// SetCircuitBreaker sets the circuit breaker checked before every attempt (nil disables it)
+func (r *RetryableHTTPClient) SetCircuitBreaker(breaker *CircuitBreaker) {
+	r.breaker = breaker
+}
+
 // Do will execute an HTTP request with retry logic
 func (r *RetryableHTTPClient) Do(req *http.Request) (*http.Response, error) {
 	var lastResp *http.Response
 	var lastErr error
 
-	// If no retries configured, just execute once
-	if r.retryCount <= 0 {
-		return r.client.Do(req)
+	// Count attempts for error reporting (see APIError.Attempts) and wait on the client's rate limiter before retries
+	trace := requestTraceFromContext(req.Context())
+
+	// If no retries configured and no hooks to report to, just execute once
+	if r.retryCount <= 0 && len(r.hooks) == 0 {
+		return r.execute(req)
 	}
 
 	// Read and store the request body once so we can reuse it for retries
@@ -100,6 +145,23 @@ func (r *RetryableHTTPClient) Do(req *http.Request) (*http.Response, error) {
 	maxAttempts := r.retryCount + 1 // retryCount doesn't include the initial attempt
 
 	for attempt := 0; attempt < maxAttempts; attempt++ {
+		// A retry waits for a rate limit token like any other request (the first attempt already has one)
+		if attempt > 0 && trace != nil && trace.limiter != nil {
+			if err := trace.limiter.wait(req.Context()); err != nil {
+				return lastResp, err
+			}
+		}
+
+		// Track whether the full request was written (see RetryAttempt.RequestSent)
+		var sent atomic.Bool
+		attemptCtx := httptrace.WithClientTrace(req.Context(), &httptrace.ClientTrace{
+			WroteRequest: func(info httptrace.WroteRequestInfo) {
+				if info.Err == nil {
+					sent.Store(true)
+				}
+			},
+		})
+
 		// Create a new request for each attempt
 		var reqForAttempt *http.Request
 		var err error
@@ -107,7 +169,7 @@ func (r *RetryableHTTPClient) Do(req *http.Request) (*http.Response, error) {
 		if bodyBytes != nil {
 			// Create new request with fresh body
 			reqForAttempt, err = http.NewRequestWithContext( //nolint:gosec // G704: URL is controlled by this library, not user input
-				req.Context(),
+				attemptCtx,
 				req.Method,
 				req.URL.String(),
 				bytes.NewReader(bodyBytes),
@@ -115,7 +177,7 @@ func (r *RetryableHTTPClient) Do(req *http.Request) (*http.Response, error) {
 		} else {
 			// There is no "body", just clone the request
 			reqForAttempt, err = http.NewRequestWithContext( //nolint:gosec // G704: URL is controlled by this library, not user input
-				req.Context(),
+				attemptCtx,
 				req.Method,
 				req.URL.String(),
 				nil,
@@ -134,16 +196,33 @@ func (r *RetryableHTTPClient) Do(req *http.Request) (*http.Response, error) {
 		}
 
 		// Execute the request
+		if trace != nil {
+			trace.attempts = attempt + 1
+		}
 		var resp *http.Response
-		resp, err = r.client.Do(reqForAttempt) //nolint:gosec // G704: URL is controlled by this library, not user input
+		resp, err = r.execute(reqForAttempt)
 
-		// If this is the last attempt, return whatever we got
-		if attempt == maxAttempts-1 {
-			return resp, err
+		// The circuit breaker opened, stop retrying
+		if errors.Is(err, ErrCircuitOpen) {
+			return nil, err
+		}
+
+		// Check if we should retry (never after the last attempt)
+		outcome := RetryAttempt{
+			Attempt:     attempt + 1,
+			Err:         err,
+			Request:     reqForAttempt,
+			RequestSent: sent.Load(),
+			Response:    resp,
+		}
+		if attempt < maxAttempts-1 && r.policy.ShouldRetry(&outcome) {
+			if delay, ok := r.retryDelay(attempt, resp); ok {
+				outcome.WillRetry, outcome.Delay = true, delay
+			}
 		}
+		r.runHooks(outcome)
 
-		// Check if we should retry
-		if !r.shouldRetry(resp, err) {
+		if !outcome.WillRetry {
 			return resp, err
 		}
 
@@ -156,17 +235,8 @@ func (r *RetryableHTTPClient) Do(req *http.Request) (*http.Response, error) {
 			_ = resp.Body.Close()
 		}
 
-		// Calculate backoff duration
-		var backoffDuration time.Duration
-		if r.backoff != nil {
-			backoffDuration = r.backoff.NextInterval(attempt)
-		} else {
-			// Default exponential backoff if none provided
-			backoffDuration = time.Duration(math.Pow(2, float64(attempt))) * time.Millisecond * 100
-		}
-
 		// Wait before retrying, respecting context cancellation
-		timer := time.NewTimer(backoffDuration)
+		timer := time.NewTimer(outcome.Delay)
 		select {
 		case <-req.Context().Done():
 			timer.Stop()
@@ -179,26 +249,45 @@ func (r *RetryableHTTPClient) Do(req *http.Request) (*http.Response, error) {
 	return lastResp, lastErr
 }
 
-// shouldRetry determines if a request should be retried based on the response
-func (r *RetryableHTTPClient) shouldRetry(resp *http.Response, err error) bool {
-	// Retry on network errors
+// execute fires a single attempt, checking and updating the circuit breaker (if set)
+func (r *RetryableHTTPClient) execute(req *http.Request) (*http.Response, error) {
+	if r.breaker == nil {
+		return r.client.Do(req) //nolint:gosec // G704: URL is controlled by this library, not user input
+	}
+	probe, err := r.breaker.allow()
 	if err != nil {
-		return true
+		return nil, err
+	}
+	resp, err := r.client.Do(req) //nolint:gosec // G704: URL is controlled by this library, not user input
+	if errors.Is(err, context.Canceled) {
+		// The caller gave up, which says nothing about the API
+		r.breaker.release(probe)
+		return resp, err
 	}
+	r.breaker.record(probe, circuitFailure(resp, err))
+	return resp, err
+}
 
-	// Retry on server errors (5xx) and specific client errors
+// retryDelay returns how long to wait before the next attempt, and false when it is longer than maxRetryAfter.
+// A Retry-After header (delay-seconds or HTTP-date) overrides the computed backoff.
+func (r *RetryableHTTPClient) retryDelay(attempt int, resp *http.Response) (time.Duration, bool) {
 	if resp != nil {
-		switch resp.StatusCode {
-		case http.StatusInternalServerError, // 500
-			http.StatusBadGateway,         // 502
-			http.StatusServiceUnavailable, // 503
-			http.StatusGatewayTimeout,     // 504
-			http.StatusTooManyRequests:    // 429
-			return true
+		if delay := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); delay > 0 {
+			return delay, delay <= r.maxRetryAfter
 		}
 	}
+	if r.backoff != nil {
+		return r.backoff.NextInterval(attempt), true
+	}
+	// Default exponential backoff if none provided
+	return time.Duration(math.Pow(2, float64(attempt))) * time.Millisecond * 100, true
+}
 
-	return false
+// runHooks calls the retry hooks with the outcome of an attempt
+func (r *RetryableHTTPClient) runHooks(attempt RetryAttempt) {
+	for _, hook := range r.hooks {
+		hook(attempt)
+	}
 }
 
 // SimpleHTTPClient is a simple wrapper around http.Client for non-retry scenarios
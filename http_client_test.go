@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -121,10 +122,11 @@ func TestExponentialBackoff_NextInterval(t *testing.T) {
 	}
 }
 
-func TestRetryableHTTPClient_shouldRetry(t *testing.T) {
+func TestDefaultRetryPolicy_ShouldRetry(t *testing.T) {
 	t.Parallel()
 
-	client := NewRetryableHTTPClient(nil, 3, nil)
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://api.whatsonchain.com/v1/bsv/main/chain/info", nil)
+	require.NoError(t, err)
 
 	tests := []struct {
 		name        string
@@ -208,7 +210,7 @@ func TestRetryableHTTPClient_shouldRetry(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			result := client.shouldRetry(tt.resp, tt.err)
+			result := DefaultRetryPolicy{}.ShouldRetry(&RetryAttempt{Request: req, Response: tt.resp, Err: tt.err})
 			assert.Equal(t, tt.shouldRetry, result)
 		})
 	}
@@ -543,3 +545,112 @@ func TestRetryableHTTPClient_Do_DefaultBackoff(t *testing.T) {
 
 	defer func() { _ = resp.Body.Close() }()
 }
+
+func TestRetryableHTTPClient_retryDelay(t *testing.T) {
+	t.Parallel()
+
+	backoff := NewExponentialBackoff(10*time.Millisecond, 10*time.Millisecond, 1, 0)
+	client := NewRetryableHTTPClient(nil, 3, backoff)
+
+	// retryDelay returns the delay, failing the test if it is not allowed
+	retryDelay := func(resp *http.Response) time.Duration {
+		delay, ok := client.retryDelay(0, resp)
+		require.True(t, ok)
+		return delay
+	}
+
+	// No response or Retry-After header: computed backoff
+	assert.Equal(t, 10*time.Millisecond, retryDelay(nil))
+	assert.Equal(t, 10*time.Millisecond, retryDelay(&http.Response{Header: http.Header{}}))
+
+	// Retry-After in seconds overrides the backoff
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+	assert.Equal(t, 3*time.Second, retryDelay(resp))
+
+	// Retry-After as an HTTP-date overrides the backoff
+	resp = &http.Response{Header: http.Header{"Retry-After": []string{time.Now().Add(30 * time.Second).UTC().Format(http.TimeFormat)}}}
+	delay := retryDelay(resp)
+	assert.Greater(t, delay, 28*time.Second)
+	assert.LessOrEqual(t, delay, 30*time.Second)
+
+	// Invalid or past values fall back to the backoff
+	resp = &http.Response{Header: http.Header{"Retry-After": []string{"soon"}}}
+	assert.Equal(t, 10*time.Millisecond, retryDelay(resp))
+	resp = &http.Response{Header: http.Header{"Retry-After": []string{"Mon, 02 Jan 2006 15:04:05 GMT"}}}
+	assert.Equal(t, 10*time.Millisecond, retryDelay(resp))
+
+	// Longer than the limit (default 1 minute): not retried
+	resp = &http.Response{Header: http.Header{"Retry-After": []string{time.Now().Add(72 * time.Hour).UTC().Format(http.TimeFormat)}}}
+	_, ok := client.retryDelay(0, resp)
+	assert.False(t, ok)
+	resp = &http.Response{Header: http.Header{"Retry-After": []string{"3600"}}}
+	_, ok = client.retryDelay(0, resp)
+	assert.False(t, ok)
+
+	client.SetMaxRetryAfter(2 * time.Hour)
+	assert.Equal(t, time.Hour, retryDelay(resp))
+	client.SetMaxRetryAfter(0)
+	assert.Equal(t, defaultMaxRetryAfter, client.maxRetryAfter)
+}
+
+// TestClient_RetryAfterTooLong tests a Retry-After over the limit fails fast with an *APIError
+func TestClient_RetryAfterTooLong(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(),
+		WithBaseURL(server.URL),
+		WithRequestRetryCount(3),
+		WithMaxRetryAfter(time.Second),
+		WithRateLimit(100),
+	)
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = client.GetChainInfo(context.Background())
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+	assert.Equal(t, 2*time.Minute, apiErr.RetryAfter)
+	assert.Equal(t, 1, apiErr.Attempts)
+	assert.Equal(t, int32(1), requests.Load())
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRetryableHTTPClient_Do_HonorsRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requestCount++
+		if requestCount == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	backoff := NewExponentialBackoff(time.Millisecond, time.Millisecond, 1, 0)
+	client := NewRetryableHTTPClient(nil, 2, backoff)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, requestCount)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+}
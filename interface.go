@@ -2,6 +2,7 @@ package whatsonchain
 
 import (
 	"context"
+	"iter"
 	"time"
 )
 
@@ -23,25 +24,37 @@ type AddressService interface {
 	AddressUnspentTransactions(ctx context.Context, address string) (history AddressHistory, err error)
 	AddressUsed(ctx context.Context, address string) (used *AddressUsed, err error)
 	BulkAddressConfirmedBalance(ctx context.Context, list *AddressList) (balances AddressBalances, err error)
+	BulkAddressConfirmedBalanceProcessor(ctx context.Context, list *AddressList) (balances AddressBalances, err error)
 	BulkAddressConfirmedHistory(ctx context.Context, list *AddressList) (history BulkAddressHistoryResponse, err error)
+	BulkAddressConfirmedHistoryProcessor(ctx context.Context, list *AddressList) (history BulkAddressHistoryResponse, err error)
 	BulkAddressConfirmedUTXOs(ctx context.Context, list *AddressList) (response BulkUnspentResponse, err error)
+	BulkAddressConfirmedUTXOsProcessor(ctx context.Context, list *AddressList) (response BulkUnspentResponse, err error)
 	BulkAddressHistory(ctx context.Context, list *AddressList) (history BulkAddressHistoryResponse, err error)
+	BulkAddressHistoryProcessor(ctx context.Context, list *AddressList) (history BulkAddressHistoryResponse, err error)
 	BulkAddressUnconfirmedBalance(ctx context.Context, list *AddressList) (balances AddressBalances, err error)
+	BulkAddressUnconfirmedBalanceProcessor(ctx context.Context, list *AddressList) (balances AddressBalances, err error)
 	BulkAddressUnconfirmedHistory(ctx context.Context, list *AddressList) (history BulkAddressHistoryResponse, err error)
+	BulkAddressUnconfirmedHistoryProcessor(ctx context.Context, list *AddressList) (history BulkAddressHistoryResponse, err error)
 	BulkAddressUnconfirmedUTXOs(ctx context.Context, list *AddressList) (response BulkUnspentResponse, err error)
+	BulkAddressUnconfirmedUTXOsProcessor(ctx context.Context, list *AddressList) (response BulkUnspentResponse, err error)
 	// Deprecated: BulkBalance uses a combined endpoint no longer in the API. Use BulkAddressConfirmedBalance and BulkAddressUnconfirmedBalance.
 	BulkBalance(ctx context.Context, list *AddressList) (balances AddressBalances, err error)
 }
 
 // BlockService is the WhatsOnChain block related requests
 type BlockService interface {
+	BlockTransactionDetails(ctx context.Context, hash string, opts *BlockTxDetailsOptions) iter.Seq2[*TxInfo, error]
+	BlockTransactions(ctx context.Context, hash string) iter.Seq2[string, error]
 	GetBlockByHash(ctx context.Context, hash string) (blockInfo *BlockInfo, err error)
 	GetBlockByHeight(ctx context.Context, height int64) (blockInfo *BlockInfo, err error)
 	GetBlockPages(ctx context.Context, hash string, page int) (txList BlockPagesInfo, err error)
 	GetHeaderByHash(ctx context.Context, hash string) (headerInfo *BlockInfo, err error)
 	GetHeaders(ctx context.Context) (blockHeaders []*BlockInfo, err error)
+	GetHeaderBytesFile(ctx context.Context, uri string) (headerBytes []byte, err error)
 	GetHeaderBytesFileLinks(ctx context.Context) (resource *HeaderBytesResource, err error)
+	GetLatestBlockHeaders(ctx context.Context, count int) (headers []*BlockHeader, err error)
 	GetLatestHeaderBytes(ctx context.Context, count int) (headerBytes string, err error)
+	SubscribeBlocks(ctx context.Context, opts *BlockSubscriptionOptions) <-chan *BlockEvent
 }
 
 // ChainService is the WhatsOnChain chain info requests
@@ -49,6 +62,7 @@ type ChainService interface {
 	GetChainInfo(ctx context.Context) (chainInfo *ChainInfo, err error)
 	GetChainTips(ctx context.Context) (chainTips []*ChainTip, err error)
 	GetCirculatingSupply(ctx context.Context) (supply float64, err error)
+	GetCirculatingSupplySatoshis(ctx context.Context) (supply Satoshis, err error)
 	GetExchangeRate(ctx context.Context) (rate *ExchangeRate, err error)
 	GetHistoricalExchangeRate(ctx context.Context, from, to int64) (rates []*HistoricalExchangeRate, err error)
 	GetPeerInfo(ctx context.Context) (peerInfo []*PeerInfo, err error)
@@ -75,9 +89,13 @@ type MempoolService interface {
 // ScriptService is the WhatsOnChain script requests
 type ScriptService interface {
 	BulkScriptConfirmedHistory(ctx context.Context, list *ScriptsList) (response BulkScriptHistoryResponse, err error)
+	BulkScriptConfirmedHistoryProcessor(ctx context.Context, list *ScriptsList) (response BulkScriptHistoryResponse, err error)
 	BulkScriptConfirmedUTXOs(ctx context.Context, list *ScriptsList) (response BulkScriptUnspentResponse, err error)
+	BulkScriptConfirmedUTXOsProcessor(ctx context.Context, list *ScriptsList) (response BulkScriptUnspentResponse, err error)
 	BulkScriptUnconfirmedHistory(ctx context.Context, list *ScriptsList) (response BulkScriptHistoryResponse, err error)
+	BulkScriptUnconfirmedHistoryProcessor(ctx context.Context, list *ScriptsList) (response BulkScriptHistoryResponse, err error)
 	BulkScriptUnconfirmedUTXOs(ctx context.Context, list *ScriptsList) (response BulkScriptUnspentResponse, err error)
+	BulkScriptUnconfirmedUTXOsProcessor(ctx context.Context, list *ScriptsList) (response BulkScriptUnspentResponse, err error)
 	// Deprecated: BulkScriptUnspentTransactions uses a combined endpoint no longer in the API. Use BulkScriptConfirmedUTXOs and BulkScriptUnconfirmedUTXOs.
 	BulkScriptUnspentTransactions(ctx context.Context, list *ScriptsList) (response BulkScriptUnspentResponse, err error)
 	GetScriptConfirmedHistory(ctx context.Context, scriptHash string) (history ScriptList, err error)
@@ -128,19 +146,24 @@ type TransactionService interface {
 	BulkRawTransactionData(ctx context.Context, hashes *TxHashes) (txList TxList, err error)
 	BulkRawTransactionDataProcessor(ctx context.Context, hashes *TxHashes) (txList TxList, err error)
 	BulkRawTransactionOutputData(ctx context.Context, request *BulkRawOutputRequest) (responses []*BulkRawOutputResponse, err error)
+	BulkRawTransactionOutputDataProcessor(ctx context.Context, request *BulkRawOutputRequest) (responses []*BulkRawOutputResponse, err error)
 	BulkSpentOutputs(ctx context.Context, request *BulkSpentOutputRequest) (response BulkSpentOutputResponse, err error)
+	BulkSpentOutputsProcessor(ctx context.Context, request *BulkSpentOutputRequest) (response BulkSpentOutputResponse, err error)
 	BulkTransactionDetails(ctx context.Context, hashes *TxHashes) (txList TxList, err error)
 	BulkTransactionDetailsProcessor(ctx context.Context, hashes *TxHashes) (txList TxList, err error)
 	BulkTransactionStatus(ctx context.Context, hashes *TxHashes) (txStatusList TxStatusList, err error)
+	BulkTransactionStatusProcessor(ctx context.Context, hashes *TxHashes) (txStatusList TxStatusList, err error)
 	// Deprecated: BulkUnspentTransactions uses an endpoint no longer in the API. Use BulkAddressConfirmedUTXOs and BulkAddressUnconfirmedUTXOs.
 	BulkUnspentTransactions(ctx context.Context, list *AddressList) (response BulkUnspentResponse, err error)
 	// Deprecated: BulkUnspentTransactionsProcessor wraps BulkUnspentTransactions which is deprecated.
 	BulkUnspentTransactionsProcessor(ctx context.Context, list *AddressList) (response BulkUnspentResponse, err error)
+	BuildBEEF(ctx context.Context, txid string) (beef *BEEF, err error)
 	DecodeTransaction(ctx context.Context, txHex string) (txInfo *TxInfo, err error)
 	GetConfirmedSpentOutput(ctx context.Context, txHash string, index int) (spentOutput *SpentOutput, err error)
 	// Deprecated: GetMerkleProof uses a non-TSC endpoint no longer in the API. Use GetMerkleProofTSC instead.
 	GetMerkleProof(ctx context.Context, hash string) (merkleResults MerkleResults, err error)
 	GetMerkleProofTSC(ctx context.Context, hash string) (merkleResults MerkleTSCResults, err error)
+	GetMerklePath(ctx context.Context, txid string) (merklePath *MerklePath, err error)
 	GetRawTransactionData(ctx context.Context, hash string) (string, error)
 	GetRawTransactionOutputData(ctx context.Context, hash string, vOutIndex int) (string, error)
 	GetSpentOutput(ctx context.Context, txHash string, index int) (spentOutput *SpentOutput, err error)
@@ -148,6 +171,7 @@ type TransactionService interface {
 	GetTransactionPropagationStatus(ctx context.Context, hash string) (propagationStatus *PropagationStatus, err error)
 	GetTxByHash(ctx context.Context, hash string) (txInfo *TxInfo, err error)
 	GetUnconfirmedSpentOutput(ctx context.Context, txHash string, index int) (spentOutput *SpentOutput, err error)
+	VerifyMerkleProofTSC(ctx context.Context, txid string, proof *MerkleTSCInfo) (headerInfo *BlockInfo, err error)
 }
 
 // ClientInterface is the WhatsOnChain client interface
@@ -168,12 +192,18 @@ type ClientInterface interface {
 	// Getters
 	APIKey() string
 	BackoffConfig() (initialTimeout, maxTimeout time.Duration, exponentFactor float64, maxJitter time.Duration)
+	BaseURL() string
+	CacheStats() CacheStats
 	Chain() ChainType
+	CircuitState() CircuitState
 	DialerConfig() (keepAlive, timeout time.Duration)
+	EndpointHealth() []EndpointHealth
 	HTTPClient() HTTPInterface
 	LastRequest() *LastRequest
 	Network() NetworkType
+	RateBurst() int
 	RateLimit() int
+	RateLimitQueueDepth() int
 	RequestRetryCount() int
 	RequestTimeout() time.Duration
 	TransportConfig() (idleTimeout, tlsTimeout, expectContinueTimeout time.Duration, maxIdleConnections int)
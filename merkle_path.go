@@ -0,0 +1,473 @@
+package whatsonchain
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// bumpFlagData marks a leaf followed by its hash
+	bumpFlagData byte = 0x00
+
+	// bumpFlagDuplicate marks a leaf whose hash is a duplicate of its sibling (no hash follows)
+	bumpFlagDuplicate byte = 0x01
+
+	// bumpFlagTxID marks a leaf that is one of the transactions being proven (its txid follows)
+	bumpFlagTxID byte = 0x02
+
+	// bumpMaxTreeHeight is the largest tree height accepted when parsing (2^64 transactions)
+	bumpMaxTreeHeight = 64
+)
+
+// MerklePath is a BSV Unified Merkle Path (BUMP, BRC-74): the merkle proofs of one or more
+// transactions in the same block, sharing their common nodes.
+//
+// Path[0] holds the transactions and their siblings, Path[1] the level above, and so on.
+// Nodes that can be computed from the level below are omitted.
+type MerklePath struct {
+	BlockHeight uint64              `json:"blockHeight"`
+	Path        [][]*MerklePathLeaf `json:"path"`
+}
+
+// MerklePathLeaf is a hash at an offset in one level of a MerklePath
+type MerklePathLeaf struct {
+	Offset    uint64 `json:"offset"`
+	Hash      string `json:"hash,omitempty"`      // hex in display order, empty if Duplicate
+	TxID      bool   `json:"txid,omitempty"`      // true if this is one of the transactions being proven
+	Duplicate bool   `json:"duplicate,omitempty"` // true if the hash is a duplicate of its sibling
+}
+
+// ParseMerklePath parses a BUMP from its binary format.
+// It returns ErrInvalidMerklePath if the bytes are truncated, inconsistent or have trailing data.
+func ParseMerklePath(raw []byte) (*MerklePath, error) {
+	r := &txReader{data: raw, invalid: ErrInvalidMerklePath}
+	path := readMerklePath(r)
+	if r.err == nil && r.remaining() > 0 {
+		r.fail(fmt.Sprintf("%d trailing bytes", r.remaining()))
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	if err := path.validate(); err != nil {
+		return nil, err
+	}
+	return path, nil
+}
+
+// ParseMerklePathHex parses a BUMP from hex
+func ParseMerklePathHex(pathHex string) (*MerklePath, error) {
+	raw, err := hex.DecodeString(strings.TrimSpace(pathHex))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMerklePath, err)
+	}
+	return ParseMerklePath(raw)
+}
+
+// readMerklePath reads one BUMP, leaving any following data unread (check r.err)
+func readMerklePath(r *txReader) *MerklePath {
+	path := &MerklePath{BlockHeight: r.varInt()}
+	treeHeight := r.bytes(1)
+	if treeHeight == nil {
+		return nil
+	}
+	if treeHeight[0] == 0 || treeHeight[0] > bumpMaxTreeHeight {
+		r.fail(fmt.Sprintf("invalid tree height %d", treeHeight[0]))
+		return nil
+	}
+
+	path.Path = make([][]*MerklePathLeaf, treeHeight[0])
+	for level := range path.Path {
+		leaves := r.count(2) // offset and flags
+		path.Path[level] = make([]*MerklePathLeaf, 0, leaves)
+		for i := uint64(0); i < leaves && r.err == nil; i++ {
+			leaf := &MerklePathLeaf{Offset: r.varInt()}
+			flags := r.bytes(1)
+			if flags == nil {
+				break
+			}
+			switch flags[0] {
+			case bumpFlagDuplicate:
+				leaf.Duplicate = true
+			case bumpFlagData, bumpFlagTxID:
+				leaf.Hash = hex.EncodeToString(reverseBytes(r.bytes(32)))
+				leaf.TxID = flags[0] == bumpFlagTxID
+			default:
+				r.fail(fmt.Sprintf("unknown leaf flags 0x%02x", flags[0]))
+			}
+			path.Path[level] = append(path.Path[level], leaf)
+		}
+	}
+	return path
+}
+
+// Bytes returns the BUMP binary format, or ErrInvalidMerklePath if the path is inconsistent
+func (p *MerklePath) Bytes() ([]byte, error) {
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writeVarInt(&buf, p.BlockHeight)
+	buf.WriteByte(byte(len(p.Path)))
+	for _, level := range p.Path {
+		writeVarInt(&buf, uint64(len(level)))
+		for _, leaf := range level {
+			writeVarInt(&buf, leaf.Offset)
+			switch {
+			case leaf.Duplicate:
+				buf.WriteByte(bumpFlagDuplicate)
+				continue
+			case leaf.TxID:
+				buf.WriteByte(bumpFlagTxID)
+			default:
+				buf.WriteByte(bumpFlagData)
+			}
+			hash, _ := decodeHashHex(leaf.Hash) // checked by validate
+			buf.Write(hash)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// Hex returns the BUMP binary format as hex
+func (p *MerklePath) Hex() (string, error) {
+	raw, err := p.Bytes()
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
+
+// TxIDs returns the transactions proven by the path, in block order
+func (p *MerklePath) TxIDs() []string {
+	if len(p.Path) == 0 {
+		return nil
+	}
+	var txIDs []string
+	for _, leaf := range p.sortedLevel(0) {
+		if leaf.TxID {
+			txIDs = append(txIDs, leaf.Hash)
+		}
+	}
+	return txIDs
+}
+
+// Contains returns true if the path proves the transaction
+func (p *MerklePath) Contains(txid string) bool {
+	return slices.ContainsFunc(p.TxIDs(), func(id string) bool { return strings.EqualFold(id, txid) })
+}
+
+// ComputeRoot returns the merkle root committed to by the path for the transaction
+// (the first proven transaction if txid is empty)
+func (p *MerklePath) ComputeRoot(txid string) (string, error) {
+	if err := p.validate(); err != nil {
+		return "", err
+	}
+	if txid == "" {
+		txIDs := p.TxIDs()
+		if len(txIDs) == 0 {
+			return "", fmt.Errorf("%w: no transaction in path", ErrInvalidMerklePath)
+		}
+		txid = txIDs[0]
+	}
+
+	index := p.index()
+	leaf := p.findLeaf(txid)
+	if leaf == nil {
+		return "", fmt.Errorf("%w: %s is not in the path", ErrInvalidMerklePath, txid)
+	}
+
+	// A block with a single transaction: its txid is the merkle root
+	if p.isSingle() {
+		return strings.ToLower(txid), nil
+	}
+
+	current, err := decodeHashHex(leaf.Hash)
+	if err != nil {
+		return "", err
+	}
+	offset := leaf.Offset
+	for level := range p.Path {
+		sibling, err := index.hash(level, offset^1, current)
+		if err != nil {
+			return "", err
+		}
+		if offset&1 == 1 {
+			current = merkleParent(sibling, current)
+		} else {
+			current = merkleParent(current, sibling)
+		}
+		offset >>= 1
+	}
+	if offset != 0 {
+		return "", fmt.Errorf("%w: offset %d is out of range for tree height %d", ErrInvalidMerklePath, leaf.Offset, len(p.Path))
+	}
+	return hex.EncodeToString(reverseBytes(current)), nil
+}
+
+// TSCProofs converts the path back into one TSC proof per proven transaction
+// (the format of GetMerkleProofTSC), with blockHash as the Target
+func (p *MerklePath) TSCProofs(blockHash string) (MerkleTSCResults, error) {
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+
+	index := p.index()
+	single := p.isSingle()
+	var proofs MerkleTSCResults
+	for _, leaf := range p.sortedLevel(0) {
+		if !leaf.TxID {
+			continue
+		}
+		proof := &MerkleTSCInfo{Index: int(leaf.Offset), Target: blockHash, TxOrID: leaf.Hash, Nodes: []string{}} //nolint:gosec // offsets are bounded by the tree height
+		if single {
+			proofs = append(proofs, proof)
+			continue
+		}
+		offset := leaf.Offset
+		for level := range p.Path {
+			sibling := index.leaf(level, offset^1)
+			if sibling != nil && sibling.Duplicate {
+				proof.Nodes = append(proof.Nodes, tscDuplicateNode)
+			} else {
+				hash, err := index.hash(level, offset^1, nil)
+				if err != nil {
+					return nil, err
+				}
+				proof.Nodes = append(proof.Nodes, hex.EncodeToString(reverseBytes(hash)))
+			}
+			offset >>= 1
+		}
+		proofs = append(proofs, proof)
+	}
+	return proofs, nil
+}
+
+// Merge adds the proofs of another path for the same block into this one (a compound BUMP).
+// It returns ErrInvalidMerklePath if the paths are for different blocks or merkle roots.
+func (p *MerklePath) Merge(other *MerklePath) error {
+	if other == nil {
+		return nil
+	}
+	if p.BlockHeight != other.BlockHeight || len(p.Path) != len(other.Path) {
+		return fmt.Errorf("%w: cannot merge paths of different blocks", ErrInvalidMerklePath)
+	}
+	root, err := p.ComputeRoot("")
+	if err != nil {
+		return err
+	}
+	otherRoot, err := other.ComputeRoot("")
+	if err != nil {
+		return err
+	}
+	if root != otherRoot {
+		return fmt.Errorf("%w: merkle roots %s and %s differ", ErrInvalidMerklePath, root, otherRoot)
+	}
+
+	for level := range other.Path {
+		for _, leaf := range other.Path[level] {
+			p.addLeaf(level, leaf)
+		}
+	}
+	p.trim()
+	return nil
+}
+
+// NewMerklePathFromTSC converts TSC proofs (see GetMerkleProofTSC) of transactions in the
+// same block into a BUMP. Use GetMerklePath to fetch the proof and block height together.
+func NewMerklePathFromTSC(blockHeight uint64, proofs ...*MerkleTSCInfo) (*MerklePath, error) {
+	if len(proofs) == 0 {
+		return nil, fmt.Errorf("%w: no proofs", ErrInvalidMerkleProof)
+	}
+
+	var root string
+	path := &MerklePath{BlockHeight: blockHeight}
+	for i, proof := range proofs {
+		proofRoot, err := ComputeTSCMerkleRoot(proof, "")
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case i == 0:
+			root = proofRoot
+			path.Path = make([][]*MerklePathLeaf, max(len(proof.Nodes), 1))
+		case proofRoot != root || len(proof.Nodes) != len(proofs[0].Nodes) || proof.Target != proofs[0].Target:
+			return nil, fmt.Errorf("%w: proofs are for different blocks", ErrInvalidMerkleProof)
+		}
+
+		txid, _ := tscProofTxID(proof, "") // checked by ComputeTSCMerkleRoot
+		offset := uint64(proof.Index)      //nolint:gosec // checked by ComputeTSCMerkleRoot
+		path.addLeaf(0, &MerklePathLeaf{Offset: offset, Hash: strings.ToLower(txid), TxID: true})
+		for level, node := range proof.Nodes {
+			leaf := &MerklePathLeaf{Offset: offset ^ 1, Duplicate: node == tscDuplicateNode}
+			if !leaf.Duplicate {
+				leaf.Hash = strings.ToLower(node)
+			}
+			path.addLeaf(level, leaf)
+			offset >>= 1
+		}
+	}
+	path.trim()
+	return path, nil
+}
+
+// GetMerklePath fetches the TSC proof of a confirmed transaction, verifies it against the
+// block header (see VerifyMerkleProofTSC) and returns it as a BUMP.
+// It returns ErrTransactionNotFound if the transaction is unknown or not yet in a block.
+func (c *Client) GetMerklePath(ctx context.Context, txid string) (*MerklePath, error) {
+	proofs, err := c.GetMerkleProofTSC(ctx, txid)
+	if err != nil {
+		return nil, err
+	}
+	if len(proofs) == 0 {
+		return nil, ErrTransactionNotFound
+	}
+	header, err := c.VerifyMerkleProofTSC(ctx, txid, proofs[0])
+	if err != nil {
+		return nil, err
+	}
+	return NewMerklePathFromTSC(uint64(header.Height), proofs[0]) //nolint:gosec // block heights are never negative
+}
+
+// validate checks the shape of the path and that every hash is valid
+func (p *MerklePath) validate() error {
+	if p == nil || len(p.Path) == 0 || len(p.Path) > bumpMaxTreeHeight || len(p.Path[0]) == 0 {
+		return fmt.Errorf("%w: empty path", ErrInvalidMerklePath)
+	}
+	for level, leaves := range p.Path {
+		seen := make(map[uint64]bool, len(leaves))
+		for _, leaf := range leaves {
+			if leaf == nil {
+				return fmt.Errorf("%w: missing leaf at level %d", ErrInvalidMerklePath, level)
+			}
+			if seen[leaf.Offset] {
+				return fmt.Errorf("%w: duplicate offset %d at level %d", ErrInvalidMerklePath, leaf.Offset, level)
+			}
+			seen[leaf.Offset] = true
+
+			switch {
+			case leaf.Duplicate && (leaf.TxID || leaf.Hash != ""):
+				return fmt.Errorf("%w: duplicate leaf %d at level %d has a hash", ErrInvalidMerklePath, leaf.Offset, level)
+			case leaf.Duplicate && leaf.Offset&1 == 0:
+				return fmt.Errorf("%w: duplicate leaf %d at level %d is not a right sibling", ErrInvalidMerklePath, leaf.Offset, level)
+			case leaf.TxID && level > 0:
+				return fmt.Errorf("%w: txid above level 0", ErrInvalidMerklePath)
+			case !leaf.Duplicate:
+				if _, err := decodeHashHex(leaf.Hash); err != nil {
+					return fmt.Errorf("%w: leaf %d at level %d: %w", ErrInvalidMerklePath, leaf.Offset, level, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isSingle returns true if the path is for a block with a single transaction (no siblings)
+func (p *MerklePath) isSingle() bool {
+	return len(p.Path) == 1 && len(p.Path[0]) == 1 && p.Path[0][0].Offset == 0
+}
+
+// sortedLevel returns the leaves of a level sorted by offset
+func (p *MerklePath) sortedLevel(level int) []*MerklePathLeaf {
+	leaves := slices.Clone(p.Path[level])
+	slices.SortFunc(leaves, func(a, b *MerklePathLeaf) int { return cmp.Compare(a.Offset, b.Offset) })
+	return leaves
+}
+
+// findLeaf returns the level 0 leaf for the txid, or nil
+func (p *MerklePath) findLeaf(txid string) *MerklePathLeaf {
+	for _, leaf := range p.Path[0] {
+		if !leaf.Duplicate && strings.EqualFold(leaf.Hash, txid) {
+			return leaf
+		}
+	}
+	return nil
+}
+
+// addLeaf adds a leaf to a level, keeping the txid flag if the offset is already present
+func (p *MerklePath) addLeaf(level int, leaf *MerklePathLeaf) {
+	for _, existing := range p.Path[level] {
+		if existing.Offset == leaf.Offset {
+			existing.TxID = existing.TxID || leaf.TxID
+			return
+		}
+	}
+	leafCopy := *leaf
+	p.Path[level] = append(p.Path[level], &leafCopy)
+}
+
+// trim removes the leaves that can be computed from the level below and sorts every level
+func (p *MerklePath) trim() {
+	available := make(map[uint64]bool)
+	for _, leaf := range p.Path[0] {
+		available[leaf.Offset] = true
+	}
+	for level := 1; level < len(p.Path); level++ {
+		computable := make(map[uint64]bool)
+		for offset := range available {
+			if available[offset^1] {
+				computable[offset>>1] = true
+			}
+		}
+		p.Path[level] = slices.DeleteFunc(p.Path[level], func(leaf *MerklePathLeaf) bool { return computable[leaf.Offset] })
+		for _, leaf := range p.Path[level] {
+			computable[leaf.Offset] = true
+		}
+		available = computable
+	}
+	for level := range p.Path {
+		p.Path[level] = p.sortedLevel(level)
+	}
+}
+
+// merklePathIndex looks up leaves by level and offset, computing missing hashes from the level below
+type merklePathIndex []map[uint64]*MerklePathLeaf
+
+// index returns a lookup of the leaves by level and offset
+func (p *MerklePath) index() merklePathIndex {
+	index := make(merklePathIndex, len(p.Path))
+	for level, leaves := range p.Path {
+		index[level] = make(map[uint64]*MerklePathLeaf, len(leaves))
+		for _, leaf := range leaves {
+			index[level][leaf.Offset] = leaf
+		}
+	}
+	return index
+}
+
+// leaf returns the leaf at a level and offset, or nil
+func (index merklePathIndex) leaf(level int, offset uint64) *MerklePathLeaf {
+	return index[level][offset]
+}
+
+// hash returns the internal-order hash at a level and offset; sibling is the hash of
+// offset^1 (used if the leaf is a duplicate, computed if nil)
+func (index merklePathIndex) hash(level int, offset uint64, sibling []byte) ([]byte, error) {
+	if leaf := index.leaf(level, offset); leaf != nil {
+		if !leaf.Duplicate {
+			return decodeHashHex(leaf.Hash)
+		}
+		if sibling != nil {
+			return sibling, nil
+		}
+		return index.hash(level, offset^1, nil)
+	}
+	if level == 0 {
+		return nil, fmt.Errorf("%w: missing leaf %d at level 0", ErrInvalidMerklePath, offset)
+	}
+
+	left, err := index.hash(level-1, offset*2, nil)
+	if err != nil {
+		return nil, err
+	}
+	right, err := index.hash(level-1, offset*2+1, left)
+	if err != nil {
+		return nil, err
+	}
+	return merkleParent(left, right), nil
+}
@@ -0,0 +1,293 @@
+package whatsonchain
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// testBUMPHex is the example BUMP from BRC-74 (block 813706, two proven transactions)
+	testBUMPHex = "fe8a6a0c000c04fde80b0011774f01d26412f0d16ea3f0447be0b5ebec67b0782e321a7a01cbdf7f734e30fde90b02004e53753e3fe4667073063a17987292cfdea278824e9888e52180581d7188d8fdea0b025e441996fc53f0191d649e68a200e752fb5f39e0d5617083408fa179ddc5c998fdeb0b0102fdf405000671394f72237d08a4277f4435e5b6edf7adc272f25effef27cdfe805ce71a81fdf50500262bccabec6c4af3ed00cc7a7414edea9c5efa92fb8623dd6160a001450a528201fdfb020101fd7c010093b3efca9b77ddec914f8effac691ecb54e2c81d0ab81cbc4c4b93befe418e8501bf01015e005881826eb6973c54003a02118fe270f03d46d02681c8bc71cd44c613e86302f8012e00e07a2bb8bb75e5accff266022e1e5e6e7b4d6d943a04faadcf2ab4a22f796ff30116008120cafa17309c0bb0e0ffce835286b3a2dcae48e4497ae2d2b7ced4f051507d010a00502e59ac92f46543c23006bff855d96f5e648043f0fb87a7a5949e6a9bebae430104001ccd9f8f64f4d0489b30cc815351cf425e0e78ad79a589350e4341ac165dbe45010301010000af8764ce7e1cc132ab5ed2229a005c87201c9a5ee15c0f91dd53eab204e4e0f0"
+
+	testBUMPTxID1 = "d888711d588021e588984e8278a2decf927298173a06737066e43f3e75534e00"
+	testBUMPTxID2 = "98c9c5dd79a18f40837061d5e0395ffb52e700a2689e641d19f053fc9619445e"
+)
+
+// testProof170 returns the TSC proof of testTxIDBlock170
+func testProof170() *MerkleTSCInfo {
+	return &MerkleTSCInfo{Index: 1, Nodes: []string{testCoinbaseTxID170}, Target: testBlockHash170, TxOrID: testTxIDBlock170}
+}
+
+// TestParseMerklePathHex tests parsing and serializing the BRC-74 example
+func TestParseMerklePathHex(t *testing.T) {
+	t.Parallel()
+
+	path, err := ParseMerklePathHex(testBUMPHex)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(813706), path.BlockHeight)
+	assert.Len(t, path.Path, 12)
+	assert.Equal(t, []string{testBUMPTxID1, testBUMPTxID2}, path.TxIDs())
+	assert.True(t, path.Contains(testBUMPTxID2))
+	assert.False(t, path.Contains(testTxID1))
+
+	// Every proven transaction commits to the same root
+	root, err := path.ComputeRoot(testBUMPTxID1)
+	require.NoError(t, err)
+	root2, err := path.ComputeRoot(testBUMPTxID2)
+	require.NoError(t, err)
+	assert.Equal(t, root, root2)
+	first, err := path.ComputeRoot("")
+	require.NoError(t, err)
+	assert.Equal(t, root, first)
+
+	pathHex, err := path.Hex()
+	require.NoError(t, err)
+	assert.Equal(t, testBUMPHex, pathHex)
+
+	// The JSON form matches BRC-74
+	data, err := json.Marshal(path)
+	require.NoError(t, err)
+	var generic struct {
+		BlockHeight uint64             `json:"blockHeight"`
+		Path        [][]map[string]any `json:"path"`
+	}
+	require.NoError(t, json.Unmarshal(data, &generic))
+	assert.Equal(t, uint64(813706), generic.BlockHeight)
+	assert.Equal(t, map[string]any{"offset": float64(3048), "hash": "304e737fdfcb017a1a322e78b067ecebb5e07b44f0a36ed1f01264d2014f7711"}, generic.Path[0][0])
+	assert.Equal(t, true, generic.Path[0][1]["txid"])
+
+	var decoded MerklePath
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	decodedHex, err := decoded.Hex()
+	require.NoError(t, err)
+	assert.Equal(t, testBUMPHex, decodedHex)
+}
+
+// TestMerklePath_TSC tests converting between TSC proofs and BUMPs
+func TestMerklePath_TSC(t *testing.T) {
+	t.Parallel()
+
+	t.Run("BRC-74 example", func(t *testing.T) {
+		t.Parallel()
+		path, err := ParseMerklePathHex(testBUMPHex)
+		require.NoError(t, err)
+		root, err := path.ComputeRoot("")
+		require.NoError(t, err)
+
+		proofs, err := path.TSCProofs("target")
+		require.NoError(t, err)
+		require.Len(t, proofs, 2)
+		for _, proof := range proofs {
+			assert.Equal(t, "target", proof.Target)
+			assert.Len(t, proof.Nodes, 12)
+			require.NoError(t, VerifyTSCProof(proof, "", root))
+		}
+
+		// Converting back gives the same compound path, without the level 1 nodes
+		// that the example includes but can be computed from level 0
+		compound, err := NewMerklePathFromTSC(path.BlockHeight, proofs...)
+		require.NoError(t, err)
+		assert.Empty(t, compound.Path[1])
+		compoundHex, err := compound.Hex()
+		require.NoError(t, err)
+		level1 := "02fdf405000671394f72237d08a4277f4435e5b6edf7adc272f25effef27cdfe805ce71a81fdf50500262bccabec6c4af3ed00cc7a7414edea9c5efa92fb8623dd6160a001450a5282"
+		assert.Equal(t, strings.Replace(testBUMPHex, level1, "00", 1), compoundHex)
+		compoundRoot, err := compound.ComputeRoot(testBUMPTxID2)
+		require.NoError(t, err)
+		assert.Equal(t, root, compoundRoot)
+	})
+
+	t.Run("block 170", func(t *testing.T) {
+		t.Parallel()
+		path, err := NewMerklePathFromTSC(170, testProof170())
+		require.NoError(t, err)
+		assert.Equal(t, []string{testTxIDBlock170}, path.TxIDs())
+
+		root, err := path.ComputeRoot(testTxIDBlock170)
+		require.NoError(t, err)
+		assert.Equal(t, testMerkleRoot170, root)
+
+		proofs, err := path.TSCProofs(testBlockHash170)
+		require.NoError(t, err)
+		assert.Equal(t, MerkleTSCResults{testProof170()}, proofs)
+	})
+
+	t.Run("single transaction block", func(t *testing.T) {
+		t.Parallel()
+		path, err := NewMerklePathFromTSC(0, &MerkleTSCInfo{TxOrID: testTxIDGenesisCoinbase})
+		require.NoError(t, err)
+		root, err := path.ComputeRoot("")
+		require.NoError(t, err)
+		assert.Equal(t, testMerkleRootGenesis, root)
+
+		proofs, err := path.TSCProofs(testBlockHashGenesis)
+		require.NoError(t, err)
+		require.Len(t, proofs, 1)
+		assert.Empty(t, proofs[0].Nodes)
+		require.NoError(t, VerifyTSCProof(proofs[0], "", testMerkleRootGenesis))
+	})
+
+	t.Run("generated trees", func(t *testing.T) {
+		t.Parallel()
+		for _, leaves := range []int{2, 3, 5, 8, 11} {
+			root, proofs := buildTestMerkleTree(t, leaves)
+
+			// One compound path with every transaction
+			compound, err := NewMerklePathFromTSC(1, proofs...)
+			require.NoError(t, err)
+			assert.Len(t, compound.TxIDs(), leaves)
+			converted, err := compound.TSCProofs("")
+			require.NoError(t, err)
+			for i, proof := range converted {
+				assert.Equal(t, proofs[i].Nodes, proof.Nodes, "leaves %d index %d", leaves, i)
+				pathRoot, rootErr := compound.ComputeRoot(proof.TxOrID)
+				require.NoError(t, rootErr)
+				assert.Equal(t, root, pathRoot)
+			}
+
+			// Merging single paths gives the same compound path
+			merged, err := NewMerklePathFromTSC(1, proofs[0])
+			require.NoError(t, err)
+			for _, proof := range proofs[1:] {
+				single, singleErr := NewMerklePathFromTSC(1, proof)
+				require.NoError(t, singleErr)
+				require.NoError(t, merged.Merge(single))
+			}
+			assert.Equal(t, compound, merged, "leaves %d", leaves)
+		}
+	})
+}
+
+// TestMerklePath_Invalid tests malformed and inconsistent paths
+func TestMerklePath_Invalid(t *testing.T) {
+	t.Parallel()
+
+	t.Run("parse", func(t *testing.T) {
+		t.Parallel()
+		for _, pathHex := range []string{
+			"",
+			"zz",
+			"01",              // missing tree height
+			"0100",            // zero tree height
+			"0141",            // tree height above 64
+			"010101",          // truncated leaf
+			"0101010003",      // unknown flags
+			"01010100" + "00", // truncated hash
+			testBUMPHex[:len(testBUMPHex)-2],
+			testBUMPHex + "00",
+			"0101020001" + "0001", // duplicate offset
+		} {
+			_, err := ParseMerklePathHex(pathHex)
+			require.ErrorIs(t, err, ErrInvalidMerklePath, pathHex)
+		}
+	})
+
+	t.Run("compute", func(t *testing.T) {
+		t.Parallel()
+		path, err := NewMerklePathFromTSC(170, testProof170())
+		require.NoError(t, err)
+
+		_, err = path.ComputeRoot(testTxID1)
+		require.ErrorIs(t, err, ErrInvalidMerklePath)
+
+		path.Path[0] = path.Path[0][1:] // remove the sibling
+		_, err = path.ComputeRoot(testTxIDBlock170)
+		require.ErrorIs(t, err, ErrInvalidMerklePath)
+
+		_, err = (&MerklePath{}).ComputeRoot("")
+		require.ErrorIs(t, err, ErrInvalidMerklePath)
+
+		noTxID := &MerklePath{Path: [][]*MerklePathLeaf{{{Offset: 0, Hash: testTxID1}}}}
+		_, err = noTxID.ComputeRoot("")
+		require.ErrorIs(t, err, ErrInvalidMerklePath)
+
+		leftDuplicate := &MerklePath{Path: [][]*MerklePathLeaf{
+			{{Offset: 0, Hash: testTxID1, TxID: true}, {Offset: 1, Hash: testTxID1}},
+			{{Offset: 0, Duplicate: true}},
+		}}
+		_, err = leftDuplicate.TSCProofs("")
+		require.ErrorIs(t, err, ErrInvalidMerklePath)
+
+		badHash := &MerklePath{Path: [][]*MerklePathLeaf{{{Offset: 0, Hash: "abc", TxID: true}}}}
+		_, err = badHash.Hex()
+		require.ErrorIs(t, err, ErrInvalidMerklePath)
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		t.Parallel()
+		path, err := NewMerklePathFromTSC(170, testProof170())
+		require.NoError(t, err)
+		require.NoError(t, path.Merge(nil))
+
+		other, err := NewMerklePathFromTSC(171, testProof170())
+		require.NoError(t, err)
+		require.ErrorIs(t, path.Merge(other), ErrInvalidMerklePath)
+
+		_, proofs := buildTestMerkleTree(t, 2)
+		other, err = NewMerklePathFromTSC(170, proofs[0])
+		require.NoError(t, err)
+		require.ErrorIs(t, path.Merge(other), ErrInvalidMerklePath)
+	})
+
+	t.Run("from TSC", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewMerklePathFromTSC(170)
+		require.ErrorIs(t, err, ErrInvalidMerkleProof)
+
+		_, err = NewMerklePathFromTSC(170, &MerkleTSCInfo{Index: -1})
+		require.ErrorIs(t, err, ErrInvalidMerkleProof)
+
+		_, proofs := buildTestMerkleTree(t, 2)
+		_, err = NewMerklePathFromTSC(170, testProof170(), proofs[1])
+		require.ErrorIs(t, err, ErrInvalidMerkleProof)
+	})
+}
+
+// TestClient_GetMerklePath tests fetching a verified BUMP
+func TestClient_GetMerklePath(t *testing.T) {
+	t.Parallel()
+
+	proofJSON, err := json.Marshal(MerkleTSCResults{testProof170()})
+	require.NoError(t, err)
+	header := testHeaderJSON170(t)
+
+	t.Run("confirmed", func(t *testing.T) {
+		t.Parallel()
+		client := newMockClient(&mockHTTPCacheable{bodies: map[string]string{
+			"/tx/" + testTxIDBlock170 + "/proof/tsc": string(proofJSON),
+			"/block/" + testBlockHash170 + "/header": header,
+		}})
+		path, err := client.GetMerklePath(context.Background(), testTxIDBlock170)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(170), path.BlockHeight)
+		assert.Equal(t, []string{testTxIDBlock170}, path.TxIDs())
+	})
+
+	t.Run("unconfirmed", func(t *testing.T) {
+		t.Parallel()
+		client := newMockClient(&mockHTTPCacheable{bodies: map[string]string{
+			"/tx/" + testTxIDBlock170 + "/proof/tsc": "[]",
+		}})
+		_, err := client.GetMerklePath(context.Background(), testTxIDBlock170)
+		require.ErrorIs(t, err, ErrTransactionNotFound)
+	})
+
+	t.Run("proof does not match", func(t *testing.T) {
+		t.Parallel()
+		other := testProof170()
+		other.Nodes = []string{testTxIDGenesisCoinbase}
+		otherJSON, err := json.Marshal(MerkleTSCResults{other})
+		require.NoError(t, err)
+		client := newMockClient(&mockHTTPCacheable{bodies: map[string]string{
+			"/tx/" + testTxIDBlock170 + "/proof/tsc": string(otherJSON),
+			"/block/" + testBlockHash170 + "/header": header,
+		}})
+		_, err = client.GetMerklePath(context.Background(), testTxIDBlock170)
+		require.ErrorIs(t, err, ErrMerkleRootMismatch)
+	})
+}
@@ -0,0 +1,151 @@
+package whatsonchain
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	// tscDuplicateNode is the TSC node value meaning "duplicate the current hash" (odd number of leaves)
+	tscDuplicateNode = "*"
+
+	// hashHexLength is the length of a hex-encoded 32-byte hash (txid, block hash, merkle root)
+	hashHexLength = 64
+)
+
+// ComputeTSCMerkleRoot computes the merkle root committed to by a TSC proof (see GetMerkleProofTSC).
+//
+// The txid is taken from proof.TxOrID if txid is empty (TxOrID may be a txid or the full raw
+// transaction hex). Nodes equal to "*" duplicate the current hash. It returns ErrInvalidMerkleProof
+// if the proof is malformed or the txid does not match TxOrID.
+func ComputeTSCMerkleRoot(proof *MerkleTSCInfo, txid string) (string, error) {
+	if proof == nil {
+		return "", fmt.Errorf("%w: missing proof", ErrInvalidMerkleProof)
+	}
+	if proof.Index < 0 {
+		return "", fmt.Errorf("%w: negative index %d", ErrInvalidMerkleProof, proof.Index)
+	}
+
+	txid, err := tscProofTxID(proof, txid)
+	if err != nil {
+		return "", err
+	}
+	current, err := decodeHashHex(txid)
+	if err != nil {
+		return "", err
+	}
+
+	index := proof.Index
+	for i, node := range proof.Nodes {
+		sibling := current
+		if node != tscDuplicateNode {
+			if sibling, err = decodeHashHex(node); err != nil {
+				return "", fmt.Errorf("node %d: %w", i, err)
+			}
+		}
+
+		if index&1 == 1 {
+			current = merkleParent(sibling, current)
+		} else {
+			current = merkleParent(current, sibling)
+		}
+		index >>= 1
+	}
+
+	// Every bit of the index must have been used by a level of the tree
+	if index != 0 {
+		return "", fmt.Errorf("%w: index %d is out of range for %d nodes", ErrInvalidMerkleProof, proof.Index, len(proof.Nodes))
+	}
+	return hex.EncodeToString(reverseBytes(current)), nil
+}
+
+// VerifyTSCProof checks that the TSC proof commits txid to merkleRoot (both hex, in display order).
+// It returns nil if the proof is valid, ErrMerkleRootMismatch if it computes a different root,
+// or ErrInvalidMerkleProof if it is malformed.
+func VerifyTSCProof(proof *MerkleTSCInfo, txid, merkleRoot string) error {
+	root, err := ComputeTSCMerkleRoot(proof, txid)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(root, merkleRoot) {
+		return fmt.Errorf("%w: computed %s, expected %s", ErrMerkleRootMismatch, root, merkleRoot)
+	}
+	return nil
+}
+
+// VerifyMerkleProofTSC fetches the block header for proof.Target (a block hash) with GetHeaderByHash
+// and checks that the proof commits txid to the header's merkle root. It returns the header on success.
+//
+// The header is not taken on trust: its fields are serialized back into the 80-byte header, which
+// must hash to proof.Target and meet the proof-of-work target of its bits, or ErrInvalidBlockHeader
+// is returned. It does not check that the block is in the active chain; for that, look up the block
+// in a synced HeaderChain (see HeaderChain.VerifyMerklePath).
+func (c *Client) VerifyMerkleProofTSC(ctx context.Context, txid string, proof *MerkleTSCInfo) (*BlockInfo, error) {
+	// Check the proof itself before making a request
+	root, err := ComputeTSCMerkleRoot(proof, txid)
+	if err != nil {
+		return nil, err
+	}
+	if len(proof.Target) != hashHexLength {
+		return nil, fmt.Errorf("%w: target %q is not a block hash", ErrInvalidMerkleProof, proof.Target)
+	}
+
+	info, err := c.GetHeaderByHash(ctx, proof.Target)
+	if err != nil {
+		return nil, err
+	}
+	header, err := blockHeaderFromInfo(info)
+	if err != nil {
+		return nil, err
+	}
+	if hash := header.Hash(); !strings.EqualFold(hash, proof.Target) {
+		return nil, fmt.Errorf("%w: header of block %s hashes to %s", ErrInvalidBlockHeader, proof.Target, hash)
+	}
+	if err = header.CheckProofOfWork(); err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(root, header.MerkleRoot) {
+		return nil, fmt.Errorf("%w: computed %s, block %s has %s", ErrMerkleRootMismatch, root, proof.Target, header.MerkleRoot)
+	}
+	return info, nil
+}
+
+// tscProofTxID returns the txid proven by the proof (TxOrID is either a txid or a raw transaction)
+func tscProofTxID(proof *MerkleTSCInfo, txid string) (string, error) {
+	proofTxID := proof.TxOrID
+	if len(proofTxID) > hashHexLength {
+		tx, err := ParseTransactionHex(proofTxID)
+		if err != nil {
+			return "", fmt.Errorf("%w: txOrId: %w", ErrInvalidMerkleProof, err)
+		}
+		proofTxID = tx.TxID()
+	}
+
+	switch {
+	case txid == "" && proofTxID == "":
+		return "", fmt.Errorf("%w: missing txid", ErrInvalidMerkleProof)
+	case txid == "":
+		return proofTxID, nil
+	case proofTxID != "" && !strings.EqualFold(txid, proofTxID):
+		return "", fmt.Errorf("%w: proof is for %s, not %s", ErrInvalidMerkleProof, proofTxID, txid)
+	default:
+		return txid, nil
+	}
+}
+
+// decodeHashHex decodes a 32-byte hash from hex in display order into internal (reversed) byte order
+func decodeHashHex(hash string) ([]byte, error) {
+	b, err := hex.DecodeString(hash)
+	if err != nil || len(b) != hashHexLength/2 {
+		return nil, fmt.Errorf("%w: %q is not a 32-byte hex hash", ErrInvalidMerkleProof, hash)
+	}
+	return reverseBytes(b), nil
+}
+
+// merkleParent returns the parent of two internal-order hashes in a merkle tree
+func merkleParent(left, right []byte) []byte {
+	return doubleSHA256(bytes.Join([][]byte{left, right}, nil))
+}
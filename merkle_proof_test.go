@@ -0,0 +1,237 @@
+package whatsonchain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// Block 170 has two transactions: the coinbase and testTxIDBlock170
+	testBlockHash170      = "00000000d1145790a8694403d4063f323d499e655c83426834d4ce2f8dd4a2ee"
+	testMerkleRoot170     = "7dac2c5666815c17a3b36427de37bb9d2e2c5ccec3f8633eb91a4205cb4c10ff"
+	testCoinbaseTxID170   = "b1fea52486ce0c62bb442b530a3f0132b826c74e473d1f2c220bfa78111c5082"
+	testBlockHashGenesis  = "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"
+	testMerkleRootGenesis = testTxIDGenesisCoinbase
+	testHeaderBlock170    = "0100000055bd840a78798ad0da853f68974f3d183e2bd1db6a842c1feecf222a00000000ff104ccb05421ab93e63f8c3ce5c2c2e9dbb37de2764b3a3175c8166562cac7d51b96a49ffff001d283e9e70"
+)
+
+// testHeaderJSON returns a header as GetHeaderByHash returns it, with the hash given (a forged header may claim any)
+func testHeaderJSON(header *BlockHeader, hash string, height int64) string {
+	return fmt.Sprintf(
+		`{"bits":"%08x","hash":%q,"height":%d,"merkleroot":%q,"nonce":%d,"previousblockhash":%q,"time":%d,"version":%d}`,
+		header.Bits, hash, height, header.MerkleRoot, header.Nonce, header.PrevHash, header.Time, header.Version,
+	)
+}
+
+// testHeaderJSON170 returns the header of block 170 as GetHeaderByHash returns it
+func testHeaderJSON170(t *testing.T) string {
+	t.Helper()
+
+	header, err := ParseBlockHeaderHex(testHeaderBlock170)
+	require.NoError(t, err)
+	return testHeaderJSON(header, testBlockHash170, 170)
+}
+
+// buildTestMerkleTree returns the merkle root and a TSC proof for every leaf, built bottom-up
+// independently of ComputeTSCMerkleRoot (odd levels duplicate their last hash)
+func buildTestMerkleTree(t *testing.T, leaves int) (root string, proofs []*MerkleTSCInfo) {
+	t.Helper()
+
+	level := make([][]byte, leaves)
+	proofs = make([]*MerkleTSCInfo, leaves)
+	for i := range level {
+		sum := sha256.Sum256([]byte(fmt.Sprintf("leaf %d", i)))
+		level[i] = sum[:]
+		proofs[i] = &MerkleTSCInfo{Index: i, TxOrID: hex.EncodeToString(reverseBytes(sum[:]))}
+	}
+
+	positions := make([]int, leaves)
+	for i := range positions {
+		positions[i] = i
+	}
+	for len(level) > 1 {
+		for leaf, pos := range positions {
+			sibling := pos ^ 1
+			if sibling >= len(level) {
+				proofs[leaf].Nodes = append(proofs[leaf].Nodes, tscDuplicateNode)
+			} else {
+				proofs[leaf].Nodes = append(proofs[leaf].Nodes, hex.EncodeToString(reverseBytes(level[sibling])))
+			}
+			positions[leaf] = pos / 2
+		}
+
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			right := level[i]
+			if i+1 < len(level) {
+				right = level[i+1]
+			}
+			first := sha256.Sum256(append(append([]byte{}, level[i]...), right...))
+			second := sha256.Sum256(first[:])
+			next = append(next, second[:])
+		}
+		level = next
+	}
+	return hex.EncodeToString(reverseBytes(level[0])), proofs
+}
+
+// TestVerifyTSCProof tests verifying proofs against known and generated merkle roots
+func TestVerifyTSCProof(t *testing.T) {
+	t.Parallel()
+
+	t.Run("block 170", func(t *testing.T) {
+		t.Parallel()
+		proof := &MerkleTSCInfo{Index: 1, Nodes: []string{testCoinbaseTxID170}, Target: testBlockHash170, TxOrID: testTxIDBlock170}
+		require.NoError(t, VerifyTSCProof(proof, testTxIDBlock170, testMerkleRoot170))
+		require.NoError(t, VerifyTSCProof(proof, "", testMerkleRoot170))
+
+		coinbase := &MerkleTSCInfo{Index: 0, Nodes: []string{testTxIDBlock170}, TxOrID: testCoinbaseTxID170}
+		require.NoError(t, VerifyTSCProof(coinbase, testCoinbaseTxID170, testMerkleRoot170))
+
+		// The wrong side of the tree computes a different root
+		proof.Index = 0
+		require.ErrorIs(t, VerifyTSCProof(proof, testTxIDBlock170, testMerkleRoot170), ErrMerkleRootMismatch)
+	})
+
+	t.Run("raw transaction in txOrId", func(t *testing.T) {
+		t.Parallel()
+		proof := &MerkleTSCInfo{Index: 1, Nodes: []string{testCoinbaseTxID170}, TxOrID: testRawTxBlock170}
+		require.NoError(t, VerifyTSCProof(proof, "", testMerkleRoot170))
+		require.NoError(t, VerifyTSCProof(proof, testTxIDBlock170, testMerkleRoot170))
+	})
+
+	t.Run("single transaction block", func(t *testing.T) {
+		t.Parallel()
+		proof := &MerkleTSCInfo{TxOrID: testTxIDGenesisCoinbase, Target: testBlockHashGenesis}
+		require.NoError(t, VerifyTSCProof(proof, "", testMerkleRootGenesis))
+	})
+
+	t.Run("generated trees", func(t *testing.T) {
+		t.Parallel()
+		for _, leaves := range []int{2, 3, 5, 7, 8, 11} {
+			root, proofs := buildTestMerkleTree(t, leaves)
+			for _, proof := range proofs {
+				require.NoError(t, VerifyTSCProof(proof, "", root), "leaves %d index %d", leaves, proof.Index)
+			}
+		}
+
+		// The last leaf of an odd tree is proven with a duplicate node
+		_, proofs := buildTestMerkleTree(t, 5)
+		assert.Equal(t, tscDuplicateNode, proofs[4].Nodes[0])
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		valid := func() *MerkleTSCInfo {
+			return &MerkleTSCInfo{Index: 1, Nodes: []string{testCoinbaseTxID170}, TxOrID: testTxIDBlock170}
+		}
+
+		tests := map[string]struct {
+			proof *MerkleTSCInfo
+			txid  string
+			root  string
+			err   error
+		}{
+			"nil proof":       {nil, testTxIDBlock170, testMerkleRoot170, ErrInvalidMerkleProof},
+			"negative index":  {&MerkleTSCInfo{Index: -1, TxOrID: testTxIDBlock170}, "", testMerkleRoot170, ErrInvalidMerkleProof},
+			"index too large": {&MerkleTSCInfo{Index: 2, Nodes: []string{testCoinbaseTxID170}}, testTxIDBlock170, testMerkleRoot170, ErrInvalidMerkleProof},
+			"missing txid":    {&MerkleTSCInfo{}, "", testMerkleRoot170, ErrInvalidMerkleProof},
+			"bad txid":        {&MerkleTSCInfo{}, "abc", testMerkleRoot170, ErrInvalidMerkleProof},
+			"bad node":        {&MerkleTSCInfo{Index: 1, Nodes: []string{"zz"}}, testTxIDBlock170, testMerkleRoot170, ErrInvalidMerkleProof},
+			"bad raw tx":      {&MerkleTSCInfo{TxOrID: testRawTxBlock170 + "00"}, "", testMerkleRoot170, ErrInvalidMerkleProof},
+			"other txid":      {valid(), testCoinbaseTxID170, testMerkleRoot170, ErrInvalidMerkleProof},
+			"wrong root":      {valid(), testTxIDBlock170, testMerkleRootGenesis, ErrMerkleRootMismatch},
+		}
+		for name, test := range tests {
+			require.ErrorIs(t, VerifyTSCProof(test.proof, test.txid, test.root), test.err, name)
+		}
+	})
+}
+
+// TestClient_VerifyMerkleProofTSC tests verifying a proof against the fetched block header
+func TestClient_VerifyMerkleProofTSC(t *testing.T) {
+	t.Parallel()
+
+	newHeaderMock := func(hash, header string) *mockHTTPCacheable {
+		return &mockHTTPCacheable{bodies: map[string]string{"/block/" + hash + "/header": header}}
+	}
+	proof := testProof170()
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+		client := newMockClient(newHeaderMock(testBlockHash170, testHeaderJSON170(t)))
+		header, err := client.VerifyMerkleProofTSC(context.Background(), testTxIDBlock170, proof)
+		require.NoError(t, err)
+		assert.Equal(t, int64(170), header.Height)
+		assert.Equal(t, testBlockHash170, header.Hash)
+	})
+
+	t.Run("proof for another root", func(t *testing.T) {
+		t.Parallel()
+		client := newMockClient(newHeaderMock(testBlockHash170, testHeaderJSON170(t)))
+		other := testProof170()
+		other.Nodes = []string{testTxIDGenesisCoinbase}
+		header, err := client.VerifyMerkleProofTSC(context.Background(), testTxIDBlock170, other)
+		require.ErrorIs(t, err, ErrMerkleRootMismatch)
+		assert.Nil(t, header)
+	})
+
+	t.Run("header with another root", func(t *testing.T) {
+		t.Parallel()
+		forged, err := ParseBlockHeaderHex(testHeaderBlock170)
+		require.NoError(t, err)
+		forged.MerkleRoot = testMerkleRootGenesis
+		client := newMockClient(newHeaderMock(testBlockHash170, testHeaderJSON(forged, testBlockHash170, 170)))
+		header, err := client.VerifyMerkleProofTSC(context.Background(), testTxIDBlock170, proof)
+		require.ErrorIs(t, err, ErrInvalidBlockHeader, "the header does not hash to the target")
+		assert.Nil(t, header)
+	})
+
+	t.Run("header without proof of work", func(t *testing.T) {
+		t.Parallel()
+		forged, err := ParseBlockHeaderHex(testHeaderBlock170)
+		require.NoError(t, err)
+		forged.Nonce++
+		client := newMockClient(newHeaderMock(forged.Hash(), testHeaderJSON(forged, forged.Hash(), 170)))
+		forgedProof := testProof170()
+		forgedProof.Target = forged.Hash()
+		_, err = client.VerifyMerkleProofTSC(context.Background(), testTxIDBlock170, forgedProof)
+		require.ErrorIs(t, err, ErrInvalidBlockHeader)
+	})
+
+	t.Run("header without bits", func(t *testing.T) {
+		t.Parallel()
+		header := fmt.Sprintf(`{"hash":%q,"height":170,"merkleroot":%q}`, testBlockHash170, testMerkleRoot170)
+		client := newMockClient(newHeaderMock(testBlockHash170, header))
+		_, err := client.VerifyMerkleProofTSC(context.Background(), testTxIDBlock170, proof)
+		require.ErrorIs(t, err, ErrInvalidBlockHeader)
+	})
+
+	t.Run("invalid proof does not fetch the header", func(t *testing.T) {
+		t.Parallel()
+		mock := newHeaderMock(testBlockHash170, testHeaderJSON170(t))
+		client := newMockClient(mock)
+
+		_, err := client.VerifyMerkleProofTSC(context.Background(), testTxIDBlock170, nil)
+		require.ErrorIs(t, err, ErrInvalidMerkleProof)
+
+		noTarget := *proof
+		noTarget.Target = ""
+		_, err = client.VerifyMerkleProofTSC(context.Background(), testTxIDBlock170, &noTarget)
+		require.ErrorIs(t, err, ErrInvalidMerkleProof)
+		assert.Equal(t, int64(0), mock.calls.Load())
+	})
+
+	t.Run("header not found", func(t *testing.T) {
+		t.Parallel()
+		client := newMockClient(&mockHTTPCacheable{})
+		_, err := client.VerifyMerkleProofTSC(context.Background(), testTxIDBlock170, proof)
+		require.ErrorIs(t, err, ErrBlockNotFound)
+	})
+}
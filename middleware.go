@@ -0,0 +1,90 @@
+package whatsonchain
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// RequestHandler executes a fully-built request and returns the response body, status code and any error.
+// The innermost handler fires the request using the client's HTTPInterface (simple or retryable).
+type RequestHandler func(req *http.Request) (body []byte, statusCode int, err error)
+
+// Middleware wraps a RequestHandler to add behavior before and/or after a request,
+// e.g. logging, metrics, header injection or auth rotation.
+type Middleware func(next RequestHandler) RequestHandler
+
+// WithMiddleware appends middleware to the request pipeline.
+// Middleware runs in the order given: the first one is the outermost and sees the
+// request first and the response last. Retries happen beneath the chain, so each
+// middleware sees one call per API request regardless of the retry count.
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(c *clientOptions) {
+		for _, m := range middleware {
+			if m != nil {
+				c.middleware = append(c.middleware, m)
+			}
+		}
+	}
+}
+
+// chainMiddleware wraps the handler with the middleware, first middleware outermost
+func chainMiddleware(handler RequestHandler, middleware []Middleware) RequestHandler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+	return handler
+}
+
+// LoggingMiddleware logs every request with structured log/slog attributes:
+// method, url, status, response size, duration and error (if any).
+// Successful requests are logged at Debug, HTTP errors at Warn and transport errors at Error.
+// If logger is nil, slog.Default() is used. The API key header is never logged.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return func(next RequestHandler) RequestHandler {
+		return func(req *http.Request) ([]byte, int, error) {
+			start := time.Now()
+			body, statusCode, err := next(req)
+
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("url", req.URL.String()),
+				slog.Int("status", statusCode),
+				slog.Int("bytes", len(body)),
+				slog.Duration("duration", time.Since(start)),
+			}
+
+			level := slog.LevelDebug
+			switch {
+			case err != nil:
+				level = slog.LevelError
+				attrs = append(attrs, slog.String("error", err.Error()))
+			case statusCode != http.StatusOK:
+				level = slog.LevelWarn
+			}
+
+			logger.LogAttrs(req.Context(), level, "whatsonchain request", attrs...)
+			return body, statusCode, err
+		}
+	}
+}
+
+// HeaderMiddleware sets the given headers on every request, overriding any existing values
+// (including the default User-Agent and API key headers)
+func HeaderMiddleware(headers http.Header) Middleware {
+	headers = headers.Clone()
+	return func(next RequestHandler) RequestHandler {
+		return func(req *http.Request) ([]byte, int, error) {
+			for key, values := range headers {
+				req.Header.Del(key)
+				for _, value := range values {
+					req.Header.Add(key, value)
+				}
+			}
+			return next(req)
+		}
+	}
+}
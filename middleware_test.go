@@ -0,0 +1,235 @@
+package whatsonchain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTripperFunc adapts a function into an http.RoundTripper for testing
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// mockHTTPFunc adapts a function into an HTTPInterface for testing
+type mockHTTPFunc struct {
+	fn func(req *http.Request) (*http.Response, error)
+}
+
+// Do is a mock http request
+func (m *mockHTTPFunc) Do(req *http.Request) (*http.Response, error) {
+	return m.fn(req)
+}
+
+// recordingMiddleware records the order it was called in
+func recordingMiddleware(name string, calls *[]string) Middleware {
+	return func(next RequestHandler) RequestHandler {
+		return func(req *http.Request) ([]byte, int, error) {
+			*calls = append(*calls, name+":before")
+			body, statusCode, err := next(req)
+			*calls = append(*calls, name+":after")
+			return body, statusCode, err
+		}
+	}
+}
+
+// TestWithMiddleware tests the WithMiddleware option
+func TestWithMiddleware(t *testing.T) {
+	t.Parallel()
+
+	opts := defaultClientOptions()
+	WithMiddleware(nil, HeaderMiddleware(nil))(opts)
+	WithMiddleware(LoggingMiddleware(nil))(opts)
+	assert.Len(t, opts.middleware, 2)
+}
+
+// TestMiddleware_Order tests that middleware runs outermost-first
+func TestMiddleware_Order(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	client, err := NewClient(
+		context.Background(),
+		WithHTTPClient(&mockHTTPMempoolValid{}),
+		WithMiddleware(recordingMiddleware("first", &calls), recordingMiddleware("second", &calls)),
+	)
+	require.NoError(t, err)
+
+	_, err = client.GetMempoolInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first:before", "second:before", "second:after", "first:after"}, calls)
+}
+
+// TestMiddleware_ModifyResponse tests that middleware can see and replace the status and body
+func TestMiddleware_ModifyResponse(t *testing.T) {
+	t.Parallel()
+
+	var seenStatus int
+	var seenBody string
+	rewrite := func(next RequestHandler) RequestHandler {
+		return func(req *http.Request) ([]byte, int, error) {
+			body, statusCode, err := next(req)
+			seenStatus, seenBody = statusCode, string(body)
+			return []byte(`{"size": 1}`), http.StatusOK, err
+		}
+	}
+
+	client, err := NewClient(
+		context.Background(),
+		WithHTTPClient(&mockHTTPMempoolValid{}),
+		WithMiddleware(rewrite),
+	)
+	require.NoError(t, err)
+
+	info, err := client.GetMempoolInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), info.Size)
+	assert.Equal(t, http.StatusOK, seenStatus)
+	assert.Contains(t, seenBody, `"size": 520`)
+	assert.Equal(t, http.StatusOK, client.LastRequest().StatusCode)
+}
+
+// TestHeaderMiddleware tests injecting headers into every request
+func TestHeaderMiddleware(t *testing.T) {
+	t.Parallel()
+
+	var captured http.Header
+	capture := &mockHTTPFunc{fn: func(req *http.Request) (*http.Response, error) {
+		captured = req.Header.Clone()
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+	}}
+
+	headers := http.Header{}
+	headers.Set("X-Request-Source", "unit-test")
+	headers.Set(apiHeaderKey, "rotated-key")
+
+	client, err := NewClient(
+		context.Background(),
+		WithAPIKey("original-key"),
+		WithHTTPClient(capture),
+		WithMiddleware(HeaderMiddleware(headers)),
+	)
+	require.NoError(t, err)
+
+	// Mutating the original headers after creation has no effect
+	headers.Set("X-Request-Source", "changed")
+
+	_, err = client.GetMempoolInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "unit-test", captured.Get("X-Request-Source"))
+	assert.Equal(t, "rotated-key", captured.Get(apiHeaderKey))
+	assert.Equal(t, defaultUserAgent, captured.Get("User-Agent"))
+}
+
+// TestLoggingMiddleware tests structured request logging
+func TestLoggingMiddleware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		httpClient    HTTPInterface
+		expectLevel   string
+		expectStatus  float64
+		expectErrAttr bool
+	}{
+		{"success", &mockHTTPStatusCode{statusCode: http.StatusOK, body: `{"size":1}`}, "DEBUG", 200, false},
+		{"http error", &mockHTTPStatusCode{statusCode: http.StatusTooManyRequests, body: `slow down`}, "WARN", 429, false},
+		{"transport error", &mockHTTPMempoolInvalid{}, "ERROR", 400, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			client, err := NewClient(
+				context.Background(),
+				WithAPIKey("secret-key"),
+				WithHTTPClient(tt.httpClient),
+				WithMiddleware(LoggingMiddleware(logger)),
+			)
+			require.NoError(t, err)
+
+			_, _ = client.GetMempoolInfo(context.Background())
+
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+			assert.Equal(t, "whatsonchain request", entry["msg"])
+			assert.Equal(t, tt.expectLevel, entry["level"])
+			assert.Equal(t, http.MethodGet, entry["method"])
+			assert.Contains(t, entry["url"], "/mempool/info")
+			assert.InDelta(t, tt.expectStatus, entry["status"], 0)
+			assert.Contains(t, entry, "duration")
+			_, hasErr := entry["error"]
+			assert.Equal(t, tt.expectErrAttr, hasErr)
+			assert.NotContains(t, buf.String(), "secret-key")
+		})
+	}
+}
+
+// TestMiddleware_ComposesWithRetryableClient tests that retries happen beneath the middleware chain
+func TestMiddleware_ComposesWithRetryableClient(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int64
+	transport := roundTripperFunc(func(_ *http.Request) (*http.Response, error) {
+		if attempts.Add(1) < 3 {
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: io.NopCloser(strings.NewReader(""))}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"size":3}`))}, nil
+	})
+	retryable := NewRetryableHTTPClient(
+		&http.Client{Transport: transport},
+		3,
+		NewExponentialBackoff(time.Millisecond, 2*time.Millisecond, 2, 0),
+	)
+
+	var middlewareCalls int
+	var finalStatus int
+	counter := func(next RequestHandler) RequestHandler {
+		return func(req *http.Request) ([]byte, int, error) {
+			middlewareCalls++
+			body, statusCode, err := next(req)
+			finalStatus = statusCode
+			return body, statusCode, err
+		}
+	}
+
+	client, err := NewClient(context.Background(), WithHTTPClient(retryable), WithMiddleware(counter))
+	require.NoError(t, err)
+
+	info, err := client.GetMempoolInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), info.Size)
+	assert.Equal(t, int64(3), attempts.Load())
+	assert.Equal(t, 1, middlewareCalls)
+	assert.Equal(t, http.StatusOK, finalStatus)
+}
+
+// TestChainMiddleware_Empty tests that an empty chain returns the handler untouched
+func TestChainMiddleware_Empty(t *testing.T) {
+	t.Parallel()
+
+	handler := func(_ *http.Request) ([]byte, int, error) { return []byte("ok"), http.StatusOK, nil }
+	chained := chainMiddleware(handler, nil)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://localhost", nil)
+	require.NoError(t, err)
+	body, statusCode, err := chained(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, []byte("ok"), body)
+}
@@ -0,0 +1,624 @@
+package whatsonchain
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// responseCase is a single endpoint in the not-found/empty/malformed harness
+type responseCase struct {
+	name      string                                       // ClientInterface method name
+	call      func(context.Context, ClientInterface) error // fires the method and returns the error
+	notFound  error                                        // sentinel expected for HTTP 404 (nil = *APIError only)
+	empty     error                                        // sentinel expected for an empty 200 body (nil = not a "not found")
+	malformed bool                                         // true if a malformed 200 body returns an error
+}
+
+// responseCases returns a case for every ClientInterface method that fires a request
+func responseCases() []responseCase {
+	addresses := &AddressList{Addresses: []string{testAddress1}}
+	scripts := &ScriptsList{Scripts: []string{testTxID1}}
+	hashes := &TxHashes{TxIDs: []string{testTxID1}}
+	outputs := &BulkRawOutputRequest{TxIDs: []BulkRawOutputTxID{{TxID: testTxID1, Vouts: []int{0}}}}
+	spent := &BulkSpentOutputRequest{UTXOs: []BulkSpentUTXO{{TxID: testTxID1, Vout: 0}}}
+
+	// jsonCase is the common case: 404 and empty both map to the sentinel, malformed JSON fails to decode
+	jsonCase := func(name string, sentinel error, call func(context.Context, ClientInterface) error) responseCase {
+		return responseCase{name: name, call: call, notFound: sentinel, empty: sentinel, malformed: true}
+	}
+
+	// rawCase is for endpoints returning raw (non-JSON) strings, a malformed body is returned as-is
+	rawCase := func(name string, sentinel error, call func(context.Context, ClientInterface) error) responseCase {
+		return responseCase{name: name, call: call, notFound: sentinel, empty: sentinel}
+	}
+
+	return []responseCase{
+		// Addresses
+		jsonCase("AddressBalance", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.AddressBalance(ctx, testAddress1)
+			return err
+		}),
+		jsonCase("AddressConfirmedBalance", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.AddressConfirmedBalance(ctx, testAddress1)
+			return err
+		}),
+		jsonCase("AddressConfirmedHistory", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.AddressConfirmedHistory(ctx, testAddress1)
+			return err
+		}),
+		jsonCase("AddressConfirmedUTXOs", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.AddressConfirmedUTXOs(ctx, testAddress1)
+			return err
+		}),
+		jsonCase("AddressHistory", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.AddressHistory(ctx, testAddress1)
+			return err
+		}),
+		jsonCase("AddressInfo", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.AddressInfo(ctx, testAddress1)
+			return err
+		}),
+		jsonCase("AddressScripts", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.AddressScripts(ctx, testAddress1)
+			return err
+		}),
+		jsonCase("AddressUnconfirmedBalance", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.AddressUnconfirmedBalance(ctx, testAddress1)
+			return err
+		}),
+		jsonCase("AddressUnconfirmedHistory", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.AddressUnconfirmedHistory(ctx, testAddress1)
+			return err
+		}),
+		jsonCase("AddressUnconfirmedUTXOs", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.AddressUnconfirmedUTXOs(ctx, testAddress1)
+			return err
+		}),
+		jsonCase("AddressUnspentTransactionDetails", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.AddressUnspentTransactionDetails(ctx, testAddress1, 0)
+			return err
+		}),
+		jsonCase("AddressUnspentTransactions", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.AddressUnspentTransactions(ctx, testAddress1)
+			return err
+		}),
+		jsonCase("AddressUsed", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.AddressUsed(ctx, testAddress1)
+			return err
+		}),
+		jsonCase("BulkAddressConfirmedBalance", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkAddressConfirmedBalance(ctx, addresses)
+			return err
+		}),
+		jsonCase("BulkAddressConfirmedBalanceProcessor", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkAddressConfirmedBalanceProcessor(ctx, addresses)
+			return err
+		}),
+		jsonCase("BulkAddressConfirmedHistory", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkAddressConfirmedHistory(ctx, addresses)
+			return err
+		}),
+		jsonCase("BulkAddressConfirmedHistoryProcessor", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkAddressConfirmedHistoryProcessor(ctx, addresses)
+			return err
+		}),
+		jsonCase("BulkAddressConfirmedUTXOs", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkAddressConfirmedUTXOs(ctx, addresses)
+			return err
+		}),
+		jsonCase("BulkAddressConfirmedUTXOsProcessor", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkAddressConfirmedUTXOsProcessor(ctx, addresses)
+			return err
+		}),
+		jsonCase("BulkAddressHistory", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkAddressHistory(ctx, addresses)
+			return err
+		}),
+		jsonCase("BulkAddressHistoryProcessor", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkAddressHistoryProcessor(ctx, addresses)
+			return err
+		}),
+		jsonCase("BulkAddressUnconfirmedBalance", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkAddressUnconfirmedBalance(ctx, addresses)
+			return err
+		}),
+		jsonCase("BulkAddressUnconfirmedBalanceProcessor", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkAddressUnconfirmedBalanceProcessor(ctx, addresses)
+			return err
+		}),
+		jsonCase("BulkAddressUnconfirmedHistory", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkAddressUnconfirmedHistory(ctx, addresses)
+			return err
+		}),
+		jsonCase("BulkAddressUnconfirmedHistoryProcessor", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkAddressUnconfirmedHistoryProcessor(ctx, addresses)
+			return err
+		}),
+		jsonCase("BulkAddressUnconfirmedUTXOs", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkAddressUnconfirmedUTXOs(ctx, addresses)
+			return err
+		}),
+		jsonCase("BulkAddressUnconfirmedUTXOsProcessor", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkAddressUnconfirmedUTXOsProcessor(ctx, addresses)
+			return err
+		}),
+		jsonCase("BulkBalance", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkBalance(ctx, addresses)
+			return err
+		}),
+		jsonCase("BulkUnspentTransactions", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkUnspentTransactions(ctx, addresses)
+			return err
+		}),
+		jsonCase("BulkUnspentTransactionsProcessor", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkUnspentTransactionsProcessor(ctx, addresses)
+			return err
+		}),
+		rawCase("DownloadStatement", ErrAddressNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.DownloadStatement(ctx, testAddress1)
+			return err
+		}),
+
+		// Blocks
+		jsonCase("BlockTransactionDetails", ErrBlockNotFound, func(ctx context.Context, c ClientInterface) error {
+			for _, err := range c.BlockTransactionDetails(ctx, testTxID1, nil) {
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}),
+		jsonCase("BlockTransactions", ErrBlockNotFound, func(ctx context.Context, c ClientInterface) error {
+			for _, err := range c.BlockTransactions(ctx, testTxID1) {
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}),
+		jsonCase("GetBlockByHash", ErrBlockNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetBlockByHash(ctx, testTxID1)
+			return err
+		}),
+		jsonCase("GetBlockByHeight", ErrBlockNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetBlockByHeight(ctx, 1)
+			return err
+		}),
+		jsonCase("GetBlockPages", ErrBlockNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetBlockPages(ctx, testTxID1, 1)
+			return err
+		}),
+		jsonCase("GetHeaderByHash", ErrBlockNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetHeaderByHash(ctx, testTxID1)
+			return err
+		}),
+		rawCase("GetHeaderBytesFile", ErrHeadersNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetHeaderBytesFile(ctx, "https://example.com/headers/0_9999_headers.bin")
+			return err
+		}),
+		jsonCase("GetHeaderBytesFileLinks", ErrHeadersNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetHeaderBytesFileLinks(ctx)
+			return err
+		}),
+		jsonCase("GetHeaders", ErrHeadersNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetHeaders(ctx)
+			return err
+		}),
+		jsonCase("GetLatestBlockHeaders", ErrHeadersNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetLatestBlockHeaders(ctx, 1)
+			return err
+		}),
+		rawCase("GetLatestHeaderBytes", ErrHeadersNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetLatestHeaderBytes(ctx, 1)
+			return err
+		}),
+		jsonCase("SubscribeBlocks", ErrChainInfoNotFound, func(ctx context.Context, c ClientInterface) error {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			event := <-c.SubscribeBlocks(ctx, nil)
+			return event.Err
+		}),
+
+		// Chain info
+		jsonCase("GetChainInfo", ErrChainInfoNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetChainInfo(ctx)
+			return err
+		}),
+		jsonCase("GetChainTips", ErrChainTipsNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetChainTips(ctx)
+			return err
+		}),
+		jsonCase("GetCirculatingSupply", ErrChainInfoNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetCirculatingSupply(ctx)
+			return err
+		}),
+		jsonCase("GetCirculatingSupplySatoshis", ErrChainInfoNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetCirculatingSupplySatoshis(ctx)
+			return err
+		}),
+		jsonCase("GetExchangeRate", ErrExchangeRateNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetExchangeRate(ctx)
+			return err
+		}),
+		jsonCase("GetHistoricalExchangeRate", ErrExchangeRateNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetHistoricalExchangeRate(ctx, 1, 2)
+			return err
+		}),
+		jsonCase("GetPeerInfo", ErrPeerInfoNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetPeerInfo(ctx)
+			return err
+		}),
+
+		// General
+		jsonCase("GetExplorerLinks", ErrChainInfoNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetExplorerLinks(ctx, testTxID1)
+			return err
+		}),
+		{name: "GetHealth", call: func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetHealth(ctx)
+			return err
+		}},
+
+		// Mempool
+		jsonCase("GetMempoolInfo", ErrMempoolInfoNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetMempoolInfo(ctx)
+			return err
+		}),
+		jsonCase("GetMempoolTransactions", ErrMempoolInfoNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetMempoolTransactions(ctx)
+			return err
+		}),
+
+		// Scripts
+		jsonCase("BulkScriptConfirmedHistory", ErrScriptNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkScriptConfirmedHistory(ctx, scripts)
+			return err
+		}),
+		jsonCase("BulkScriptConfirmedHistoryProcessor", ErrScriptNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkScriptConfirmedHistoryProcessor(ctx, scripts)
+			return err
+		}),
+		jsonCase("BulkScriptConfirmedUTXOs", ErrScriptNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkScriptConfirmedUTXOs(ctx, scripts)
+			return err
+		}),
+		jsonCase("BulkScriptConfirmedUTXOsProcessor", ErrScriptNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkScriptConfirmedUTXOsProcessor(ctx, scripts)
+			return err
+		}),
+		jsonCase("BulkScriptUnconfirmedHistory", ErrScriptNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkScriptUnconfirmedHistory(ctx, scripts)
+			return err
+		}),
+		jsonCase("BulkScriptUnconfirmedHistoryProcessor", ErrScriptNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkScriptUnconfirmedHistoryProcessor(ctx, scripts)
+			return err
+		}),
+		jsonCase("BulkScriptUnconfirmedUTXOs", ErrScriptNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkScriptUnconfirmedUTXOs(ctx, scripts)
+			return err
+		}),
+		jsonCase("BulkScriptUnconfirmedUTXOsProcessor", ErrScriptNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkScriptUnconfirmedUTXOsProcessor(ctx, scripts)
+			return err
+		}),
+		jsonCase("BulkScriptUnspentTransactions", ErrScriptNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkScriptUnspentTransactions(ctx, scripts)
+			return err
+		}),
+		jsonCase("GetScriptConfirmedHistory", ErrScriptNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetScriptConfirmedHistory(ctx, testTxID1)
+			return err
+		}),
+		jsonCase("GetScriptHistory", ErrScriptNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetScriptHistory(ctx, testTxID1)
+			return err
+		}),
+		jsonCase("GetScriptUnconfirmedHistory", ErrScriptNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetScriptUnconfirmedHistory(ctx, testTxID1)
+			return err
+		}),
+		jsonCase("GetScriptUnspentTransactions", ErrScriptNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetScriptUnspentTransactions(ctx, testTxID1)
+			return err
+		}),
+		rawCase("GetScriptUsed", ErrScriptNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetScriptUsed(ctx, testTxID1)
+			return err
+		}),
+		jsonCase("ScriptConfirmedUTXOs", ErrScriptNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.ScriptConfirmedUTXOs(ctx, testTxID1)
+			return err
+		}),
+		jsonCase("ScriptUnconfirmedUTXOs", ErrScriptNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.ScriptUnconfirmedUTXOs(ctx, testTxID1)
+			return err
+		}),
+
+		// Stats
+		jsonCase("GetBlockStats", ErrStatsNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetBlockStats(ctx, 1)
+			return err
+		}),
+		jsonCase("GetBlockStatsByHash", ErrStatsNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetBlockStatsByHash(ctx, testTxID1)
+			return err
+		}),
+		jsonCase("GetMinerBlocksStats", ErrStatsNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetMinerBlocksStats(ctx, 1)
+			return err
+		}),
+		jsonCase("GetMinerFeesStats", ErrStatsNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetMinerFeesStats(ctx, 1, 2)
+			return err
+		}),
+		jsonCase("GetMinerSummaryStats", ErrStatsNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetMinerSummaryStats(ctx, 1)
+			return err
+		}),
+		jsonCase("GetTagCountByHeight", ErrStatsNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetTagCountByHeight(ctx, 1)
+			return err
+		}),
+
+		// Tokens
+		jsonCase("GetAddressTokenBalance", ErrTokenNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetAddressTokenBalance(ctx, testAddress1)
+			return err
+		}),
+		jsonCase("GetAllSTASTokens", ErrTokenNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetAllSTASTokens(ctx)
+			return err
+		}),
+		jsonCase("GetOneSatOrdinalByOrigin", ErrTokenNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetOneSatOrdinalByOrigin(ctx, testTxID1+"_0")
+			return err
+		}),
+		jsonCase("GetOneSatOrdinalByOutpoint", ErrTokenNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetOneSatOrdinalByOutpoint(ctx, testTxID1+"_0")
+			return err
+		}),
+		jsonCase("GetOneSatOrdinalContent", ErrTokenNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetOneSatOrdinalContent(ctx, testTxID1+"_0")
+			return err
+		}),
+		jsonCase("GetOneSatOrdinalHistory", ErrTokenNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetOneSatOrdinalHistory(ctx, testTxID1+"_0")
+			return err
+		}),
+		jsonCase("GetOneSatOrdinalLatest", ErrTokenNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetOneSatOrdinalLatest(ctx, testTxID1+"_0")
+			return err
+		}),
+		jsonCase("GetOneSatOrdinalsByTxID", ErrTokenNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetOneSatOrdinalsByTxID(ctx, testTxID1)
+			return err
+		}),
+		jsonCase("GetOneSatOrdinalsStats", ErrTokenNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetOneSatOrdinalsStats(ctx)
+			return err
+		}),
+		jsonCase("GetSTASStats", ErrTokenNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetSTASStats(ctx)
+			return err
+		}),
+		jsonCase("GetSTASTokenByID", ErrTokenNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetSTASTokenByID(ctx, testTxID1, "TEST")
+			return err
+		}),
+		jsonCase("GetTokenTransactions", ErrTokenNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetTokenTransactions(ctx, testTxID1, "TEST")
+			return err
+		}),
+		jsonCase("GetTokenUTXOsForAddress", ErrTokenNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetTokenUTXOsForAddress(ctx, testAddress1)
+			return err
+		}),
+
+		// Transactions
+		{name: "BroadcastTx", notFound: ErrBroadcastFailed, call: func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BroadcastTx(ctx, testTxID1)
+			return err
+		}},
+		{name: "BulkBroadcastTx", notFound: ErrBroadcastFailed, malformed: true, call: func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkBroadcastTx(ctx, []string{testTxID1}, true)
+			return err
+		}},
+		jsonCase("BulkRawTransactionData", ErrTransactionNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkRawTransactionData(ctx, hashes)
+			return err
+		}),
+		jsonCase("BulkRawTransactionDataProcessor", ErrTransactionNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkRawTransactionDataProcessor(ctx, hashes)
+			return err
+		}),
+		jsonCase("BulkRawTransactionOutputData", ErrTransactionNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkRawTransactionOutputData(ctx, outputs)
+			return err
+		}),
+		jsonCase("BulkRawTransactionOutputDataProcessor", ErrTransactionNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkRawTransactionOutputDataProcessor(ctx, outputs)
+			return err
+		}),
+		jsonCase("BulkSpentOutputs", ErrTransactionNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkSpentOutputs(ctx, spent)
+			return err
+		}),
+		jsonCase("BulkSpentOutputsProcessor", ErrTransactionNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkSpentOutputsProcessor(ctx, spent)
+			return err
+		}),
+		jsonCase("BulkTransactionDetails", ErrTransactionNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkTransactionDetails(ctx, hashes)
+			return err
+		}),
+		jsonCase("BulkTransactionDetailsProcessor", ErrTransactionNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkTransactionDetailsProcessor(ctx, hashes)
+			return err
+		}),
+		jsonCase("BulkTransactionStatus", ErrTransactionNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkTransactionStatus(ctx, hashes)
+			return err
+		}),
+		jsonCase("BulkTransactionStatusProcessor", ErrTransactionNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BulkTransactionStatusProcessor(ctx, hashes)
+			return err
+		}),
+		jsonCase("BuildBEEF", ErrTransactionNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.BuildBEEF(ctx, testTxID1)
+			return err
+		}),
+		jsonCase("DecodeTransaction", ErrTransactionNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.DecodeTransaction(ctx, testTxID1)
+			return err
+		}),
+		rawCase("DownloadReceipt", ErrTransactionNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.DownloadReceipt(ctx, testTxID1)
+			return err
+		}),
+		jsonCase("GetConfirmedSpentOutput", ErrTransactionNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetConfirmedSpentOutput(ctx, testTxID1, 0)
+			return err
+		}),
+		jsonCase("GetMerkleProof", ErrTransactionNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetMerkleProof(ctx, testTxID1)
+			return err
+		}),
+		jsonCase("GetMerkleProofTSC", ErrTransactionNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetMerkleProofTSC(ctx, testTxID1)
+			return err
+		}),
+		jsonCase("GetMerklePath", ErrTransactionNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetMerklePath(ctx, testTxID1)
+			return err
+		}),
+		rawCase("GetOpReturnData", ErrTransactionNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetOpReturnData(ctx, testTxID1)
+			return err
+		}),
+		rawCase("GetRawTransactionData", ErrTransactionNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetRawTransactionData(ctx, testTxID1)
+			return err
+		}),
+		rawCase("GetRawTransactionOutputData", ErrTransactionNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetRawTransactionOutputData(ctx, testTxID1, 0)
+			return err
+		}),
+		jsonCase("GetSpentOutput", ErrTransactionNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetSpentOutput(ctx, testTxID1, 0)
+			return err
+		}),
+		rawCase("GetTransactionAsBinary", ErrTransactionNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetTransactionAsBinary(ctx, testTxID1)
+			return err
+		}),
+		jsonCase("GetTransactionPropagationStatus", ErrTransactionNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetTransactionPropagationStatus(ctx, testTxID1)
+			return err
+		}),
+		jsonCase("GetTxByHash", ErrTransactionNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetTxByHash(ctx, testTxID1)
+			return err
+		}),
+		jsonCase("GetUnconfirmedSpentOutput", ErrTransactionNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.GetUnconfirmedSpentOutput(ctx, testTxID1, 0)
+			return err
+		}),
+		jsonCase("VerifyMerkleProofTSC", ErrBlockNotFound, func(ctx context.Context, c ClientInterface) error {
+			_, err := c.VerifyMerkleProofTSC(ctx, testTxID1, &MerkleTSCInfo{Target: testTxID1, TxOrID: testTxID1})
+			return err
+		}),
+	}
+}
+
+// TestResponseCases_Complete makes sure every request method on ClientInterface is in the harness
+func TestResponseCases_Complete(t *testing.T) {
+	t.Parallel()
+
+	covered := make(map[string]bool)
+	for _, tc := range responseCases() {
+		assert.False(t, covered[tc.name], "duplicate case: %s", tc.name)
+		covered[tc.name] = true
+	}
+
+	ctxType := reflect.TypeFor[context.Context]()
+	iface := reflect.TypeFor[ClientInterface]()
+	for i := 0; i < iface.NumMethod(); i++ {
+		method := iface.Method(i)
+		if method.Type.NumIn() == 0 || method.Type.In(0) != ctxType {
+			continue // getters and setters do not fire requests
+		}
+		assert.True(t, covered[method.Name], "missing case: %s", method.Name)
+	}
+}
+
+// TestClient_NotFoundResponses tests that every endpoint maps HTTP 404 to its sentinel error
+func TestClient_NotFoundResponses(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range responseCases() {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newMockClient(&mockHTTPStatusHeader{statusCode: http.StatusNotFound, body: `{"error":"not found"}`})
+			err := tc.call(context.Background(), client)
+			require.Error(t, err)
+
+			// The *APIError always stays in the chain
+			var apiErr *APIError
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+			assert.True(t, IsNotFound(err))
+			require.ErrorIs(t, err, ErrRequestFailed)
+
+			if tc.notFound != nil {
+				require.ErrorIs(t, err, tc.notFound)
+			}
+		})
+	}
+}
+
+// TestClient_EmptyResponses tests that every endpoint handles an empty 200 body deterministically
+func TestClient_EmptyResponses(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range responseCases() {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newMockClient(&mockHTTPStatusHeader{statusCode: http.StatusOK})
+			err := tc.call(context.Background(), client)
+			if tc.empty == nil {
+				assert.False(t, IsNotFound(err), "empty body reported as not found: %v", err)
+				return
+			}
+			require.ErrorIs(t, err, tc.empty)
+			assert.True(t, IsNotFound(err))
+		})
+	}
+}
+
+// TestClient_MalformedResponses tests that every endpoint returns an error (not a zero value) for malformed JSON
+func TestClient_MalformedResponses(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range responseCases() {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newMockClient(&mockHTTPStatusHeader{statusCode: http.StatusOK, body: `{"malformed":`})
+			err := tc.call(context.Background(), client)
+			if !tc.malformed {
+				// Raw endpoints return the body as-is (GetCirculatingSupply/GetScriptUsed parse it)
+				return
+			}
+			require.Error(t, err)
+			assert.False(t, IsNotFound(err), "malformed body reported as not found: %v", err)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package whatsonchain
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// rateLimiter is a context-aware token bucket shared by every request fired through a Client.
+//
+// Tokens refill continuously at rate tokens per second up to burst. Each call to wait
+// reserves one token; when the bucket is empty the caller sleeps until its reserved
+// token becomes available or the context is canceled, whichever comes first.
+type rateLimiter struct {
+	mu      sync.Mutex   // protects all fields below except waiting
+	rate    float64      // tokens added per second
+	burst   float64      // maximum number of tokens the bucket can hold
+	tokens  float64      // current number of tokens (negative when reservations are outstanding)
+	last    time.Time    // last time tokens were refilled
+	waiting atomic.Int64 // number of callers currently blocked in wait
+}
+
+// newRateLimiter creates a new token bucket with the given rate and burst.
+// Values less than 1 are clamped to 1; the bucket starts full.
+func newRateLimiter(rate, burst int) *rateLimiter {
+	if rate < 1 {
+		rate = 1
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:   float64(rate),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// refill adds the tokens accumulated since the last refill (caller must hold mu)
+func (l *rateLimiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens += elapsed.Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+}
+
+// wait blocks until a token is available or the context is done
+func (l *rateLimiter) wait(ctx context.Context) error {
+	// Fail fast if the context is already done
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Reserve a token and work out how long until it is ours
+	l.mu.Lock()
+	l.refill(time.Now())
+	l.tokens--
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	// Token was immediately available
+	if delay <= 0 {
+		return nil
+	}
+
+	// Wait for the reservation, respecting context cancellation
+	l.waiting.Add(1)
+	defer l.waiting.Add(-1)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		// Give the reserved token back so other callers are not penalized
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// setLimit updates the rate and burst in place; pending reservations keep their place in line
+func (l *rateLimiter) setLimit(rate, burst int) {
+	if rate < 1 {
+		rate = 1
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(time.Now())
+	l.rate = float64(rate)
+	l.burst = float64(burst)
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
+
+// queueDepth returns the number of callers currently waiting for a token
+func (l *rateLimiter) queueDepth() int {
+	return int(l.waiting.Load())
+}
@@ -0,0 +1,230 @@
+package whatsonchain
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewRateLimiter tests creating a new rate limiter
+func TestNewRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid values", func(t *testing.T) {
+		l := newRateLimiter(5, 10)
+		assert.InDelta(t, 5.0, l.rate, 0.0001)
+		assert.InDelta(t, 10.0, l.burst, 0.0001)
+		assert.InDelta(t, 10.0, l.tokens, 0.0001)
+		assert.Equal(t, 0, l.queueDepth())
+	})
+
+	t.Run("values are clamped to 1", func(t *testing.T) {
+		l := newRateLimiter(0, -5)
+		assert.InDelta(t, 1.0, l.rate, 0.0001)
+		assert.InDelta(t, 1.0, l.burst, 0.0001)
+	})
+}
+
+// TestRateLimiter_Wait tests the token bucket waiting behavior
+func TestRateLimiter_Wait(t *testing.T) {
+	t.Parallel()
+
+	t.Run("burst is immediate", func(t *testing.T) {
+		l := newRateLimiter(1, 3)
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			require.NoError(t, l.wait(context.Background()))
+		}
+		assert.Less(t, time.Since(start), 100*time.Millisecond)
+	})
+
+	t.Run("waits once bucket is empty", func(t *testing.T) {
+		l := newRateLimiter(20, 1)
+		require.NoError(t, l.wait(context.Background()))
+
+		start := time.Now()
+		require.NoError(t, l.wait(context.Background()))
+		assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+	})
+
+	t.Run("canceled context returns immediately", func(t *testing.T) {
+		l := newRateLimiter(1, 1)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		require.ErrorIs(t, l.wait(ctx), context.Canceled)
+	})
+
+	t.Run("context timeout while waiting returns the token", func(t *testing.T) {
+		l := newRateLimiter(1, 1)
+		require.NoError(t, l.wait(context.Background()))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, l.wait(ctx), context.DeadlineExceeded)
+
+		l.mu.Lock()
+		tokens := l.tokens
+		l.mu.Unlock()
+		assert.Greater(t, tokens, -1.0)
+	})
+}
+
+// TestRateLimiter_QueueDepth tests the queue depth reporting
+func TestRateLimiter_QueueDepth(t *testing.T) {
+	t.Parallel()
+
+	l := newRateLimiter(1, 1)
+	require.NoError(t, l.wait(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = l.wait(ctx)
+		}()
+	}
+
+	assert.Eventually(t, func() bool { return l.queueDepth() == 3 }, time.Second, 5*time.Millisecond)
+	cancel()
+	wg.Wait()
+	assert.Equal(t, 0, l.queueDepth())
+}
+
+// TestRateLimiter_SetLimit tests live reconfiguration
+func TestRateLimiter_SetLimit(t *testing.T) {
+	t.Parallel()
+
+	l := newRateLimiter(1, 5)
+	l.setLimit(50, 2)
+
+	l.mu.Lock()
+	assert.InDelta(t, 50.0, l.rate, 0.0001)
+	assert.InDelta(t, 2.0, l.burst, 0.0001)
+	assert.LessOrEqual(t, l.tokens, 2.0)
+	l.mu.Unlock()
+
+	l.setLimit(0, 0)
+	l.mu.Lock()
+	assert.InDelta(t, 1.0, l.rate, 0.0001)
+	assert.InDelta(t, 1.0, l.burst, 0.0001)
+	l.mu.Unlock()
+}
+
+// TestClient_RateLimitEnforced tests that every request goes through the shared rate limiter
+func TestClient_RateLimitEnforced(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewClient(
+		context.Background(),
+		WithNetwork(NetworkTest),
+		WithHTTPClient(&mockHTTPMempoolValid{}),
+		WithRateLimit(10),
+		WithRateBurst(1),
+	)
+	require.NoError(t, err)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err = client.GetMempoolInfo(context.Background())
+		require.NoError(t, err)
+	}
+
+	// Burst of 1 at 10/s means the 2nd and 3rd requests wait ~100ms each
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+}
+
+// TestClient_RateLimitEveryAttempt tests that retries and failovers wait for a token like the first attempt
+func TestClient_RateLimitEveryAttempt(t *testing.T) {
+	t.Parallel()
+
+	t.Run("retries", func(t *testing.T) {
+		t.Parallel()
+		server, count := newStatusServer(t, http.StatusServiceUnavailable)
+		client, err := NewClient(
+			context.Background(),
+			WithBaseURL(server.URL),
+			WithBackoff(time.Millisecond, time.Millisecond, 1, 0),
+			WithRequestRetryCount(3),
+			WithRateLimit(20),
+			WithRateBurst(1),
+		)
+		require.NoError(t, err)
+
+		start := time.Now()
+		_, err = client.GetChainInfo(context.Background())
+		require.Error(t, err)
+		assert.Equal(t, int32(4), count.Load())
+
+		// Burst of 1 at 20/s means each of the 3 retries waits ~50ms
+		assert.GreaterOrEqual(t, time.Since(start), 140*time.Millisecond)
+	})
+
+	t.Run("failover", func(t *testing.T) {
+		t.Parallel()
+		var urls []string
+		for i := 0; i < 3; i++ {
+			server, _ := newStatusServer(t, http.StatusServiceUnavailable)
+			urls = append(urls, server.URL)
+		}
+		client, err := NewClient(
+			context.Background(),
+			WithEndpoints(urls...),
+			WithRequestRetryCount(0),
+			WithRateLimit(20),
+			WithRateBurst(1),
+		)
+		require.NoError(t, err)
+
+		start := time.Now()
+		_, err = client.GetChainInfo(context.Background())
+		require.Error(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+	})
+}
+
+// TestClient_RateLimitContextCanceled tests that a canceled context aborts a throttled request
+func TestClient_RateLimitContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewClient(
+		context.Background(),
+		WithNetwork(NetworkTest),
+		WithHTTPClient(&mockHTTPMempoolValid{}),
+		WithRateLimit(1),
+		WithRateBurst(1),
+	)
+	require.NoError(t, err)
+
+	_, err = client.GetMempoolInfo(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = client.GetMempoolInfo(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 0, client.RateLimitQueueDepth())
+}
+
+// TestClient_SetRateLimitReconfiguresLimiter tests that SetRateLimit updates the live limiter
+func TestClient_SetRateLimitReconfiguresLimiter(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewClient(context.Background(), WithRateLimit(1))
+	require.NoError(t, err)
+
+	client.SetRateLimit(25)
+	c, ok := client.(*Client)
+	require.True(t, ok)
+
+	c.limiter.mu.Lock()
+	defer c.limiter.mu.Unlock()
+	assert.InDelta(t, 25.0, c.limiter.rate, 0.0001)
+	assert.InDelta(t, 25.0, c.limiter.burst, 0.0001)
+}
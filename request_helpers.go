@@ -3,84 +3,95 @@ package whatsonchain
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 )
 
-// checkStatusCode returns an error if the HTTP status code indicates failure.
-// This prevents non-JSON error bodies (e.g. from 401, 429, 5xx) from being silently
+// checkStatusCode returns an *APIError if the HTTP status code indicates failure.
+// This prevents non-JSON error bodies (e.g. from 401, 404, 429, 5xx) from being silently
 // passed to json.Unmarshal, which would produce a confusing parse error.
 //
-// HTTP 404 is excluded because the API uses it for "resource not found" responses,
-// which are handled by each endpoint's emptyErr parameter with domain-specific errors.
-func checkStatusCode(status int, resp []byte) error {
-	if status == http.StatusOK || status == http.StatusNotFound {
+// HTTP 404 is mapped to each endpoint's domain-specific sentinel error by notFoundError.
+func checkStatusCode(request *http.Request, status int, resp []byte, trace *requestTrace) error {
+	if status == http.StatusOK {
 		return nil
 	}
-	if len(resp) == 0 {
-		return fmt.Errorf("%w: HTTP %d", ErrRequestFailed, status)
+	return newAPIError(request.Method, request.URL.String(), status, resp, trace.header, trace.attempts)
+}
+
+// notFoundError maps an HTTP 404 *APIError to the endpoint's sentinel error (e.g. ErrTransactionNotFound).
+// The *APIError stays in the chain so errors.As and IsNotFound still work. Other errors are returned as-is.
+func notFoundError(err, sentinel error) error {
+	var apiErr *APIError
+	if sentinel != nil && errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %w", sentinel, err)
 	}
-	return fmt.Errorf("%w: HTTP %d: %s", ErrRequestFailed, status, string(resp))
+	return err
 }
 
 // requestAndUnmarshal is a generic helper that performs a request and will unmarshal the response
-// into a pointer to the specified type T
+// into a pointer to the specified type T. HTTP 404 and empty responses both return emptyErr.
 func requestAndUnmarshal[T any](ctx context.Context, c *Client, url, method string, payload []byte, emptyErr error) (*T, error) {
-	resp, statusCode, err := c.request(ctx, url, method, payload)
+	resp, _, err := c.request(ctx, url, method, payload)
 	if err != nil {
-		return nil, err
+		return nil, notFoundError(err, emptyErr)
 	}
+	return unmarshalResponse[T](resp, emptyErr)
+}
 
-	if err = checkStatusCode(statusCode, resp); err != nil {
-		return nil, err
+// requestAndUnmarshalSlice is a generic helper that performs a request and unmarshals the response
+// into a slice of the specified type T. HTTP 404 and empty responses both return emptyErr.
+func requestAndUnmarshalSlice[T any](ctx context.Context, c *Client, url, method string, payload []byte, emptyErr error) ([]T, error) {
+	resp, _, err := c.request(ctx, url, method, payload)
+	if err != nil {
+		return nil, notFoundError(err, emptyErr)
 	}
+	return unmarshalSliceResponse[T](resp, emptyErr)
+}
+
+// requestString is a helper that performs a GET request and returns the raw string response.
+// HTTP 404 and empty responses both return emptyErr (if nil, an empty response is returned as "").
+func requestString(ctx context.Context, c *Client, url string, emptyErr error) (string, error) {
+	resp, _, err := c.request(ctx, url, "GET", nil)
+	if err != nil {
+		return "", notFoundError(err, emptyErr)
+	}
+	return stringResponse(resp, emptyErr)
+}
 
+// unmarshalResponse unmarshals the response into a pointer to T
+func unmarshalResponse[T any](resp []byte, emptyErr error) (*T, error) {
 	if len(resp) == 0 {
 		return nil, emptyErr
 	}
 
 	var result T
-	if err = json.Unmarshal(resp, &result); err != nil {
+	if err := json.Unmarshal(resp, &result); err != nil {
 		return nil, err
 	}
 
 	return &result, nil
 }
 
-// requestAndUnmarshalSlice is a generic helper that performs a request and unmarshals the response
-// into a slice of the specified type T
-func requestAndUnmarshalSlice[T any](ctx context.Context, c *Client, url, method string, payload []byte, emptyErr error) ([]T, error) {
-	resp, statusCode, err := c.request(ctx, url, method, payload)
-	if err != nil {
-		return nil, err
-	}
-
-	if err = checkStatusCode(statusCode, resp); err != nil {
-		return nil, err
-	}
-
+// unmarshalSliceResponse unmarshals the response into a slice of T
+func unmarshalSliceResponse[T any](resp []byte, emptyErr error) ([]T, error) {
 	if len(resp) == 0 {
 		return nil, emptyErr
 	}
 
 	var result []T
-	if err = json.Unmarshal(resp, &result); err != nil {
+	if err := json.Unmarshal(resp, &result); err != nil {
 		return nil, err
 	}
 
 	return result, nil
 }
 
-// requestString is a helper that performs a GET request and returns the raw string response
-func requestString(ctx context.Context, c *Client, url string) (string, error) {
-	resp, statusCode, err := c.request(ctx, url, "GET", nil)
-	if err != nil {
-		return "", err
-	}
-
-	if err = checkStatusCode(statusCode, resp); err != nil {
-		return "", err
+// stringResponse returns the raw string response, or emptyErr if the response is empty
+func stringResponse(resp []byte, emptyErr error) (string, error) {
+	if len(resp) == 0 && emptyErr != nil {
+		return "", emptyErr
 	}
-
 	return string(resp), nil
 }
@@ -0,0 +1,97 @@
+package whatsonchain
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RetryAttempt describes the outcome of a single attempt made by the RetryableHTTPClient
+type RetryAttempt struct {
+	Attempt     int            // attempt number, starting at 1
+	Delay       time.Duration  // wait before the next attempt (only set for hooks when WillRetry is true)
+	Err         error          // transport error (nil if a response was received)
+	Request     *http.Request  // the request sent for this attempt
+	RequestSent bool           // true if the full request (including the body) was written to the server
+	Response    *http.Response // response received (nil on a transport error), the body may already be closed
+	WillRetry   bool           // true if another attempt will be made (only set for hooks)
+}
+
+// RetryPolicy decides if a failed attempt should be retried
+type RetryPolicy interface {
+	ShouldRetry(attempt *RetryAttempt) bool
+}
+
+// RetryPolicyFunc is an adapter to allow the use of ordinary functions as a RetryPolicy
+type RetryPolicyFunc func(attempt *RetryAttempt) bool
+
+// ShouldRetry calls f(attempt)
+func (f RetryPolicyFunc) ShouldRetry(attempt *RetryAttempt) bool {
+	return f(attempt)
+}
+
+// RetryHook is called after every attempt, e.g. for logging each retry
+type RetryHook func(attempt RetryAttempt)
+
+// DefaultRetryPolicy retries transport errors and 500/502/503/504/429 responses.
+//
+// Broadcast requests (POST /tx/raw and /tx/broadcast) are not idempotent, so they are
+// never retried once the server has received the body: only a transport error that
+// happened before the request was written (e.g. a failed dial) is retried.
+type DefaultRetryPolicy struct{}
+
+// ShouldRetry returns true if the attempt should be retried
+func (DefaultRetryPolicy) ShouldRetry(attempt *RetryAttempt) bool {
+	if isBroadcastRequest(attempt.Request) && (attempt.RequestSent || attempt.Response != nil) {
+		return false
+	}
+
+	// Retry on network errors
+	if attempt.Err != nil {
+		return true
+	}
+
+	// Retry on server errors (5xx) and specific client errors
+	if attempt.Response != nil {
+		switch attempt.Response.StatusCode {
+		case http.StatusInternalServerError, // 500
+			http.StatusBadGateway,         // 502
+			http.StatusServiceUnavailable, // 503
+			http.StatusGatewayTimeout,     // 504
+			http.StatusTooManyRequests:    // 429
+			return true
+		}
+	}
+
+	return false
+}
+
+// isBroadcastRequest returns true if the request broadcasts transactions (BroadcastTx or BulkBroadcastTx)
+func isBroadcastRequest(req *http.Request) bool {
+	if req == nil || req.Method != http.MethodPost || req.URL == nil {
+		return false
+	}
+	return strings.HasSuffix(req.URL.Path, "/tx/raw") || strings.HasSuffix(req.URL.Path, "/tx/broadcast")
+}
+
+// WithRetryPolicy sets the policy that decides which failed attempts are retried (default: DefaultRetryPolicy).
+// The policy chooses among the WithRequestRetryCount retries, so with a retry count of 0 it is never asked.
+// It has no effect when a custom HTTP client is set with WithHTTPClient.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *clientOptions) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithRetryHook adds hooks that are called after every attempt made by the retryable HTTP client,
+// including the first, so they also see requests made with a retry count of 0.
+// It has no effect when a custom HTTP client is set with WithHTTPClient.
+func WithRetryHook(hooks ...RetryHook) ClientOption {
+	return func(c *clientOptions) {
+		for _, hook := range hooks {
+			if hook != nil {
+				c.retryHooks = append(c.retryHooks, hook)
+			}
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package whatsonchain
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStatusServer returns a test server that always responds with statusCode (and testChainInfoBody
+// on a 200) and counts requests
+func newStatusServer(t *testing.T, statusCode int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	count := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		count.Add(1)
+		if req.Method == http.MethodPost {
+			_, _ = io.Copy(io.Discard, req.Body)
+		}
+		w.WriteHeader(statusCode)
+		if statusCode == http.StatusOK {
+			_, _ = w.Write([]byte(testChainInfoBody))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, count
+}
+
+// doPost sends a POST request with a body through the client
+func doPost(t *testing.T, client HTTPInterface, url string) (*http.Response, error) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, strings.NewReader(`{"txhex":"00"}`))
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	return resp, err
+}
+
+// TestIsBroadcastRequest tests the broadcast request detection
+func TestIsBroadcastRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		method   string
+		url      string
+		expected bool
+	}{
+		{http.MethodPost, "https://api.whatsonchain.com/v1/bsv/main/tx/raw", true},
+		{http.MethodPost, "https://api.whatsonchain.com/v1/bsv/main/tx/broadcast?feedback=true", true},
+		{http.MethodGet, "https://api.whatsonchain.com/v1/bsv/main/tx/raw", false},
+		{http.MethodPost, "https://api.whatsonchain.com/v1/bsv/main/txs", false},
+		{http.MethodPost, "https://api.whatsonchain.com/v1/bsv/main/tx/decode", false},
+	}
+
+	for _, test := range tests {
+		req, err := http.NewRequestWithContext(context.Background(), test.method, test.url, nil)
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, isBroadcastRequest(req), "%s %s", test.method, test.url)
+	}
+	assert.False(t, isBroadcastRequest(nil))
+}
+
+// TestDefaultRetryPolicy_Broadcast tests that broadcasts are never retried once the server has the body
+func TestDefaultRetryPolicy_Broadcast(t *testing.T) {
+	t.Parallel()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "https://api.whatsonchain.com/v1/bsv/main/tx/raw", nil)
+	require.NoError(t, err)
+
+	policy := DefaultRetryPolicy{}
+	assert.False(t, policy.ShouldRetry(&RetryAttempt{Request: req, Response: &http.Response{StatusCode: http.StatusServiceUnavailable}}))
+	assert.False(t, policy.ShouldRetry(&RetryAttempt{Request: req, RequestSent: true, Err: errNetworkError}))
+	assert.True(t, policy.ShouldRetry(&RetryAttempt{Request: req, Err: errNetworkError}))
+}
+
+// TestRetryableHTTPClient_Do_BroadcastNotRetried tests a broadcast POST hitting a failing server
+func TestRetryableHTTPClient_Do_BroadcastNotRetried(t *testing.T) {
+	t.Parallel()
+
+	server, count := newStatusServer(t, http.StatusServiceUnavailable)
+	client := NewRetryableHTTPClient(nil, 3, NewExponentialBackoff(time.Millisecond, time.Millisecond, 1, 0))
+
+	// Broadcast: the server received the body, so no retry
+	resp, err := doPost(t, client, server.URL+"/v1/bsv/main/tx/raw")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(1), count.Load())
+
+	// Any other POST is retried
+	count.Store(0)
+	resp, err = doPost(t, client, server.URL+"/v1/bsv/main/txs")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(4), count.Load())
+}
+
+// TestRetryableHTTPClient_Do_BroadcastDialError tests that a broadcast is retried if it never reached the server
+func TestRetryableHTTPClient_Do_BroadcastDialError(t *testing.T) {
+	t.Parallel()
+
+	// A closed server refuses connections before the request is written
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL + "/v1/bsv/main/tx/raw"
+	server.Close()
+
+	var attempts []RetryAttempt
+	client := NewRetryableHTTPClient(nil, 2, NewExponentialBackoff(time.Millisecond, time.Millisecond, 1, 0))
+	client.AddRetryHook(func(attempt RetryAttempt) {
+		attempts = append(attempts, attempt)
+	})
+
+	_, err := doPost(t, client, url)
+	require.Error(t, err)
+	require.Len(t, attempts, 3)
+	for _, attempt := range attempts {
+		assert.False(t, attempt.RequestSent)
+		assert.Error(t, attempt.Err)
+	}
+}
+
+// TestRetryableHTTPClient_RetryHooks tests that hooks see every attempt
+func TestRetryableHTTPClient_RetryHooks(t *testing.T) {
+	t.Parallel()
+
+	server, count := newStatusServer(t, http.StatusBadGateway)
+	client := NewRetryableHTTPClient(nil, 2, NewExponentialBackoff(time.Millisecond, time.Millisecond, 1, 0))
+
+	var mu sync.Mutex
+	var attempts []RetryAttempt
+	client.AddRetryHook(nil) // ignored
+	client.AddRetryHook(func(attempt RetryAttempt) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts = append(attempts, attempt)
+	})
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, int32(3), count.Load())
+	require.Len(t, attempts, 3)
+	for i, attempt := range attempts {
+		assert.Equal(t, i+1, attempt.Attempt)
+		assert.Equal(t, http.StatusBadGateway, attempt.Response.StatusCode)
+		assert.True(t, attempt.RequestSent)
+	}
+	assert.True(t, attempts[0].WillRetry)
+	assert.Equal(t, time.Millisecond, attempts[0].Delay)
+	assert.True(t, attempts[1].WillRetry)
+	assert.False(t, attempts[2].WillRetry)
+	assert.Zero(t, attempts[2].Delay)
+}
+
+// TestRetryableHTTPClient_SetRetryPolicy tests a custom retry policy
+func TestRetryableHTTPClient_SetRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	server, count := newStatusServer(t, http.StatusNotFound)
+	client := NewRetryableHTTPClient(nil, 2, NewExponentialBackoff(time.Millisecond, time.Millisecond, 1, 0))
+
+	// Retry 404s
+	client.SetRetryPolicy(RetryPolicyFunc(func(attempt *RetryAttempt) bool {
+		return attempt.Response != nil && attempt.Response.StatusCode == http.StatusNotFound
+	}))
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, int32(3), count.Load())
+
+	// nil restores the default policy
+	client.SetRetryPolicy(nil)
+	assert.IsType(t, DefaultRetryPolicy{}, client.policy)
+}
+
+// TestClient_BroadcastTx_NotRetried tests the default policy end-to-end through the client
+func TestClient_BroadcastTx_NotRetried(t *testing.T) {
+	t.Parallel()
+
+	server, count := newStatusServer(t, http.StatusInternalServerError)
+
+	var hooked atomic.Int32
+	client, err := NewClient(
+		context.Background(),
+		WithRequestRetryCount(3),
+		WithBackoff(time.Millisecond, time.Millisecond, 1, 0),
+		WithRetryHook(func(RetryAttempt) { hooked.Add(1) }),
+	)
+	require.NoError(t, err)
+
+	retryable, ok := client.HTTPClient().(*RetryableHTTPClient)
+	require.True(t, ok)
+	assert.Len(t, retryable.hooks, 1)
+
+	_, err = doPost(t, client.HTTPClient(), server.URL+"/v1/bsv/main/tx/raw")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), count.Load())
+	assert.Equal(t, int32(1), hooked.Load())
+}
+
+// TestWithRetryPolicy tests the client option
+func TestWithRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicyFunc(func(*RetryAttempt) bool { return false })
+	client, err := NewClient(context.Background(), WithRetryPolicy(policy))
+	require.NoError(t, err)
+
+	retryable, ok := client.HTTPClient().(*RetryableHTTPClient)
+	require.True(t, ok)
+	assert.NotNil(t, retryable.policy)
+	_, isDefault := retryable.policy.(DefaultRetryPolicy)
+	assert.False(t, isDefault)
+}
+
+// TestRetryOptions_NoRetries tests a retry policy or hook without retries still builds the retryable client
+func TestRetryOptions_NoRetries(t *testing.T) {
+	t.Parallel()
+
+	t.Run("policy only", func(t *testing.T) {
+		t.Parallel()
+		var asked atomic.Int32
+		policy := RetryPolicyFunc(func(*RetryAttempt) bool { asked.Add(1); return true })
+		client, err := NewClient(context.Background(), WithRequestRetryCount(0), WithRetryPolicy(policy))
+		require.NoError(t, err)
+
+		retryable, ok := client.HTTPClient().(*RetryableHTTPClient)
+		require.True(t, ok)
+		assert.Zero(t, retryable.retryCount)
+
+		server, count := newStatusServer(t, http.StatusBadGateway)
+		_, err = doPost(t, client.HTTPClient(), server.URL)
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), count.Load())
+		assert.Zero(t, asked.Load(), "no retries to decide on")
+	})
+
+	t.Run("hook only", func(t *testing.T) {
+		t.Parallel()
+		var attempts []RetryAttempt
+		client, err := NewClient(context.Background(),
+			WithRequestRetryCount(0),
+			WithRetryHook(func(attempt RetryAttempt) { attempts = append(attempts, attempt) }),
+		)
+		require.NoError(t, err)
+
+		server, count := newStatusServer(t, http.StatusBadGateway)
+		_, err = doPost(t, client.HTTPClient(), server.URL)
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), count.Load())
+		require.Len(t, attempts, 1)
+		assert.Equal(t, 1, attempts[0].Attempt)
+		assert.Equal(t, http.StatusBadGateway, attempts[0].Response.StatusCode)
+		assert.False(t, attempts[0].WillRetry)
+	})
+
+	t.Run("neither", func(t *testing.T) {
+		t.Parallel()
+		client, err := NewClient(context.Background(), WithRequestRetryCount(0))
+		require.NoError(t, err)
+		assert.IsType(t, &SimpleHTTPClient{}, client.HTTPClient())
+	})
+}
@@ -14,6 +14,10 @@ import (
 //
 // For more information: https://docs.whatsonchain.com/#get-script-history
 func (c *Client) GetScriptHistory(ctx context.Context, scriptHash string) (ScriptList, error) {
+	if err := c.validateHash("scriptHash", scriptHash); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/script/%s/history", scriptHash)
 	return requestAndUnmarshalSlice[*ScriptRecord](ctx, c, url, http.MethodGet, nil, ErrScriptNotFound)
 }
@@ -22,6 +26,10 @@ func (c *Client) GetScriptHistory(ctx context.Context, scriptHash string) (Scrip
 //
 // For more information: https://docs.whatsonchain.com/#get-script-unspent-transactions
 func (c *Client) GetScriptUnspentTransactions(ctx context.Context, scriptHash string) (ScriptList, error) {
+	if err := c.validateHash("scriptHash", scriptHash); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/script/%s/unspent/all", scriptHash)
 	return requestAndUnmarshalSlice[*ScriptRecord](ctx, c, url, http.MethodGet, nil, ErrScriptNotFound)
 }
@@ -40,6 +48,9 @@ func (c *Client) BulkScriptUnspentTransactions(ctx context.Context, list *Script
 	if len(list.Scripts) > MaxScriptsForLookup {
 		return nil, fmt.Errorf("%w: %d scripts requested, max is %d", ErrMaxScriptsExceeded, len(list.Scripts), MaxScriptsForLookup)
 	}
+	if err := c.validateHashes("scripts", list.Scripts); err != nil {
+		return nil, err
+	}
 
 	postData, err := json.Marshal(list)
 	if err != nil {
@@ -54,6 +65,10 @@ func (c *Client) BulkScriptUnspentTransactions(ctx context.Context, list *Script
 //
 // For more information: https://docs.whatsonchain.com/#get-unconfirmed-script-utxos
 func (c *Client) ScriptUnconfirmedUTXOs(ctx context.Context, scriptHash string) (ScriptList, error) {
+	if err := c.validateHash("scriptHash", scriptHash); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/script/%s/unconfirmed/unspent", scriptHash)
 	return requestAndUnmarshalSlice[*ScriptRecord](ctx, c, url, http.MethodGet, nil, ErrScriptNotFound)
 }
@@ -69,6 +84,9 @@ func (c *Client) BulkScriptUnconfirmedUTXOs(ctx context.Context, list *ScriptsLi
 	if len(list.Scripts) > MaxScriptsForLookup {
 		return nil, fmt.Errorf("%w: %d scripts requested, max is %d", ErrMaxScriptsExceeded, len(list.Scripts), MaxScriptsForLookup)
 	}
+	if err := c.validateHashes("scripts", list.Scripts); err != nil {
+		return nil, err
+	}
 
 	postData, err := json.Marshal(list)
 	if err != nil {
@@ -79,10 +97,20 @@ func (c *Client) BulkScriptUnconfirmedUTXOs(ctx context.Context, list *ScriptsLi
 	return requestAndUnmarshalSlice[*BulkScriptResponseRecord](ctx, c, url, http.MethodPost, postData, ErrScriptNotFound)
 }
 
+// BulkScriptUnconfirmedUTXOsProcessor retrieves unconfirmed UTXOs for any number of script hashes in batches of 20
+// See: BulkScriptUnconfirmedUTXOs()
+func (c *Client) BulkScriptUnconfirmedUTXOsProcessor(ctx context.Context, list *ScriptsList) (BulkScriptUnspentResponse, error) {
+	return bulkScripts(ctx, c, "BulkScriptUnconfirmedUTXOs", list, c.BulkScriptUnconfirmedUTXOs)
+}
+
 // ScriptConfirmedUTXOs retrieves confirmed UTXOs for a script
 //
 // For more information: https://docs.whatsonchain.com/#get-confirmed-script-utxos
 func (c *Client) ScriptConfirmedUTXOs(ctx context.Context, scriptHash string) (ScriptList, error) {
+	if err := c.validateHash("scriptHash", scriptHash); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/script/%s/confirmed/unspent", scriptHash)
 	return requestAndUnmarshalSlice[*ScriptRecord](ctx, c, url, http.MethodGet, nil, ErrScriptNotFound)
 }
@@ -98,6 +126,9 @@ func (c *Client) BulkScriptConfirmedUTXOs(ctx context.Context, list *ScriptsList
 	if len(list.Scripts) > MaxScriptsForLookup {
 		return nil, fmt.Errorf("%w: %d scripts requested, max is %d", ErrMaxScriptsExceeded, len(list.Scripts), MaxScriptsForLookup)
 	}
+	if err := c.validateHashes("scripts", list.Scripts); err != nil {
+		return nil, err
+	}
 
 	postData, err := json.Marshal(list)
 	if err != nil {
@@ -108,18 +139,25 @@ func (c *Client) BulkScriptConfirmedUTXOs(ctx context.Context, list *ScriptsList
 	return requestAndUnmarshalSlice[*BulkScriptResponseRecord](ctx, c, url, http.MethodPost, postData, ErrScriptNotFound)
 }
 
+// BulkScriptConfirmedUTXOsProcessor retrieves confirmed UTXOs for any number of script hashes in batches of 20
+// See: BulkScriptConfirmedUTXOs()
+func (c *Client) BulkScriptConfirmedUTXOsProcessor(ctx context.Context, list *ScriptsList) (BulkScriptUnspentResponse, error) {
+	return bulkScripts(ctx, c, "BulkScriptConfirmedUTXOs", list, c.BulkScriptConfirmedUTXOs)
+}
+
 // GetScriptUsed this endpoint determines if a script has been used in any transaction
 //
 // For more information: https://docs.whatsonchain.com/api/script#get-script-usage
 func (c *Client) GetScriptUsed(ctx context.Context, scriptHash string) (bool, error) {
+	if err := c.validateHash("scriptHash", scriptHash); err != nil {
+		return false, err
+	}
+
 	url := c.buildURL("/script/%s/used", scriptHash)
-	resp, err := requestString(ctx, c, url)
+	resp, err := requestString(ctx, c, url, ErrScriptNotFound)
 	if err != nil {
 		return false, err
 	}
-	if len(resp) == 0 {
-		return false, ErrScriptNotFound
-	}
 	// The response is a simple boolean string "true" or "false"
 	return resp == "true", nil
 }
@@ -128,6 +166,10 @@ func (c *Client) GetScriptUsed(ctx context.Context, scriptHash string) (bool, er
 //
 // For more information: https://docs.whatsonchain.com/api/script#get-unconfirmed-script-history
 func (c *Client) GetScriptUnconfirmedHistory(ctx context.Context, scriptHash string) (ScriptList, error) {
+	if err := c.validateHash("scriptHash", scriptHash); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/script/%s/unconfirmed/history", scriptHash)
 	return requestAndUnmarshalSlice[*ScriptRecord](ctx, c, url, http.MethodGet, nil, ErrScriptNotFound)
 }
@@ -143,6 +185,9 @@ func (c *Client) BulkScriptUnconfirmedHistory(ctx context.Context, list *Scripts
 	if len(list.Scripts) > MaxScriptsForLookup {
 		return nil, fmt.Errorf("%w: %d scripts requested, max is %d", ErrMaxScriptsExceeded, len(list.Scripts), MaxScriptsForLookup)
 	}
+	if err := c.validateHashes("scripts", list.Scripts); err != nil {
+		return nil, err
+	}
 
 	postData, err := json.Marshal(list)
 	if err != nil {
@@ -153,10 +198,20 @@ func (c *Client) BulkScriptUnconfirmedHistory(ctx context.Context, list *Scripts
 	return requestAndUnmarshalSlice[*BulkScriptHistoryRecord](ctx, c, url, http.MethodPost, postData, ErrScriptNotFound)
 }
 
+// BulkScriptUnconfirmedHistoryProcessor retrieves unconfirmed history for any number of script hashes in batches of 20
+// See: BulkScriptUnconfirmedHistory()
+func (c *Client) BulkScriptUnconfirmedHistoryProcessor(ctx context.Context, list *ScriptsList) (BulkScriptHistoryResponse, error) {
+	return bulkScripts(ctx, c, "BulkScriptUnconfirmedHistory", list, c.BulkScriptUnconfirmedHistory)
+}
+
 // GetScriptConfirmedHistory this endpoint retrieves confirmed script transactions
 //
 // For more information: https://docs.whatsonchain.com/api/script#get-confirmed-script-history
 func (c *Client) GetScriptConfirmedHistory(ctx context.Context, scriptHash string) (ScriptList, error) {
+	if err := c.validateHash("scriptHash", scriptHash); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/script/%s/confirmed/history", scriptHash)
 	return requestAndUnmarshalSlice[*ScriptRecord](ctx, c, url, http.MethodGet, nil, ErrScriptNotFound)
 }
@@ -172,6 +227,9 @@ func (c *Client) BulkScriptConfirmedHistory(ctx context.Context, list *ScriptsLi
 	if len(list.Scripts) > MaxScriptsForLookup {
 		return nil, fmt.Errorf("%w: %d scripts requested, max is %d", ErrMaxScriptsExceeded, len(list.Scripts), MaxScriptsForLookup)
 	}
+	if err := c.validateHashes("scripts", list.Scripts); err != nil {
+		return nil, err
+	}
 
 	postData, err := json.Marshal(list)
 	if err != nil {
@@ -181,3 +239,9 @@ func (c *Client) BulkScriptConfirmedHistory(ctx context.Context, list *ScriptsLi
 	url := c.buildURL("/scripts/confirmed/history")
 	return requestAndUnmarshalSlice[*BulkScriptHistoryRecord](ctx, c, url, http.MethodPost, postData, ErrScriptNotFound)
 }
+
+// BulkScriptConfirmedHistoryProcessor retrieves confirmed history for any number of script hashes in batches of 20
+// See: BulkScriptConfirmedHistory()
+func (c *Client) BulkScriptConfirmedHistoryProcessor(ctx context.Context, list *ScriptsList) (BulkScriptHistoryResponse, error) {
+	return bulkScripts(ctx, c, "BulkScriptConfirmedHistory", list, c.BulkScriptConfirmedHistory)
+}
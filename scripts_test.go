@@ -40,13 +40,13 @@ func (m *mockHTTPScript) Do(req *http.Request) (*http.Response, error) {
 	}
 
 	// Invalid (any endpoint with invalidTx)
-	if strings.Contains(req.URL.String(), "script/invalidTx/") {
+	if strings.Contains(req.URL.String(), "script/"+testHashInvalid+"/") {
 		resp.Body = io.NopCloser(bytes.NewBufferString(""))
 		return resp, errScriptBadRequest
 	}
 
 	// Not found (any endpoint with notFound)
-	if strings.Contains(req.URL.String(), "script/notFound/") {
+	if strings.Contains(req.URL.String(), "script/"+testHashNotFound+"/") {
 		resp.StatusCode = http.StatusNotFound
 		resp.Body = io.NopCloser(bytes.NewBufferString(""))
 		return resp, nil
@@ -65,13 +65,13 @@ func (m *mockHTTPScript) Do(req *http.Request) (*http.Response, error) {
 	}
 
 	// Invalid
-	if strings.Contains(req.URL.String(), "script/invalidTx/unspent/all") {
+	if strings.Contains(req.URL.String(), "script/"+testHashInvalid+"/unspent/all") {
 		resp.Body = io.NopCloser(bytes.NewBufferString(""))
 		return resp, errScriptBadRequest
 	}
 
 	// Not found
-	if strings.Contains(req.URL.String(), "script/notFound/unspent/all") {
+	if strings.Contains(req.URL.String(), "script/"+testHashNotFound+"/unspent/all") {
 		resp.StatusCode = http.StatusNotFound
 		resp.Body = io.NopCloser(bytes.NewBufferString(""))
 		return resp, nil
@@ -222,8 +222,8 @@ func TestClient_GetScriptHistory(t *testing.T) {
 		statusCode    int
 	}{
 		{testScriptHash1, 620539, "52dfceb815ad129a0fd946e3d665f44fa61f068135b9f38b05d3c697e11bad48", false, http.StatusOK},
-		{"invalidTx", 0, "", true, http.StatusBadRequest},
-		{testMockNotFound, 0, "", true, http.StatusNotFound},
+		{testHashInvalid, 0, "", true, http.StatusBadRequest},
+		{testHashNotFound, 0, "", true, http.StatusNotFound},
 	}
 
 	// Test all
@@ -274,8 +274,8 @@ func TestClient_GetScriptUnspentTransactions(t *testing.T) {
 	}{
 		{"92cf18576a49ddad3e18f4af23b85d8d8218e03ce3b7533aced3fdd286f7e6cb", 640558, "5c6ac3a685be0791aa6e6eedb03d48cbf76046ea499e0a9cefbdc0fb3969ad13", false, http.StatusOK},
 		{testScriptHash1, 0, "", true, http.StatusOK}, // Empty response should error
-		{"invalidTx", 0, "", true, http.StatusBadRequest},
-		{testMockNotFound, 0, "", true, http.StatusNotFound},
+		{testHashInvalid, 0, "", true, http.StatusBadRequest},
+		{testHashNotFound, 0, "", true, http.StatusNotFound},
 	}
 
 	// Test all
@@ -369,7 +369,7 @@ func TestClient_BulkScriptUnspentTransactions(t *testing.T) {
 		client := newMockClient(&mockHTTPScriptNotFound{})
 		ctx := context.Background()
 		balances, err := client.BulkScriptUnspentTransactions(ctx, &ScriptsList{Scripts: []string{
-			testMockNotFound,
+			testHashNotFound,
 		}})
 		require.Error(t, err)
 		assert.Nil(t, balances)
@@ -393,8 +393,8 @@ func TestClient_GetScriptUsed(t *testing.T) {
 	}{
 		{testScriptHash1, true, false, http.StatusOK},
 		{"92cf18576a49ddad3e18f4af23b85d8d8218e03ce3b7533aced3fdd286f7e6cb", false, false, http.StatusOK},
-		{"invalidTx", false, true, http.StatusBadRequest},
-		{testMockNotFound, false, true, http.StatusNotFound},
+		{testHashInvalid, false, true, http.StatusBadRequest},
+		{testHashNotFound, false, true, http.StatusNotFound},
 	}
 
 	// Test all
@@ -439,8 +439,8 @@ func TestClient_GetScriptUnconfirmedHistory(t *testing.T) {
 		statusCode    int
 	}{
 		{testScriptHash1, 620539, "52dfceb815ad129a0fd946e3d665f44fa61f068135b9f38b05d3c697e11bad48", false, http.StatusOK},
-		{"invalidTx", 0, "", true, http.StatusBadRequest},
-		{testMockNotFound, 0, "", true, http.StatusNotFound},
+		{testHashInvalid, 0, "", true, http.StatusBadRequest},
+		{testHashNotFound, 0, "", true, http.StatusNotFound},
 	}
 
 	// Test all
@@ -490,8 +490,8 @@ func TestClient_GetScriptConfirmedHistory(t *testing.T) {
 		statusCode    int
 	}{
 		{testScriptHash1, 620539, "4ec3b63d764558303eda720e8e51f69bbcfe81376075657313fb587306f8a9b0", false, http.StatusOK},
-		{"invalidTx", 0, "", true, http.StatusBadRequest},
-		{testMockNotFound, 0, "", true, http.StatusNotFound},
+		{testHashInvalid, 0, "", true, http.StatusBadRequest},
+		{testHashNotFound, 0, "", true, http.StatusNotFound},
 	}
 
 	// Test all
@@ -622,8 +622,8 @@ func TestClient_ScriptUnconfirmedUTXOs(t *testing.T) {
 	}{
 		{testScriptHash1, 0, "abc123", false, http.StatusOK},
 		{"92cf18576a49ddad3e18f4af23b85d8d8218e03ce3b7533aced3fdd286f7e6cb", 0, "", true, http.StatusOK}, // Empty response should error
-		{"invalidTx", 0, "", true, http.StatusBadRequest},
-		{testMockNotFound, 0, "", true, http.StatusNotFound},
+		{testHashInvalid, 0, "", true, http.StatusBadRequest},
+		{testHashNotFound, 0, "", true, http.StatusNotFound},
 	}
 
 	// Test all
@@ -715,8 +715,8 @@ func TestClient_ScriptConfirmedUTXOs(t *testing.T) {
 	}{
 		{testScriptHash1, 620539, "def456", false, http.StatusOK},
 		{"92cf18576a49ddad3e18f4af23b85d8d8218e03ce3b7533aced3fdd286f7e6cb", 0, "", true, http.StatusOK}, // Empty response should error
-		{"invalidTx", 0, "", true, http.StatusBadRequest},
-		{testMockNotFound, 0, "", true, http.StatusNotFound},
+		{testHashInvalid, 0, "", true, http.StatusBadRequest},
+		{testHashNotFound, 0, "", true, http.StatusNotFound},
 	}
 
 	// Test all
@@ -796,7 +796,7 @@ func TestClient_GetScriptUsed_EmptyResponse(t *testing.T) {
 
 	client := newMockClient(&mockHTTPScriptNotFound{})
 	ctx := context.Background()
-	used, err := client.GetScriptUsed(ctx, testMockNotFound)
+	used, err := client.GetScriptUsed(ctx, testHashNotFound)
 	require.Error(t, err)
 	require.ErrorIs(t, err, ErrScriptNotFound)
 	assert.False(t, used)
@@ -17,6 +17,10 @@ func (c *Client) GetBlockStats(ctx context.Context, height int64) (*BlockStats,
 //
 // For more information: https://developers.whatsonchain.com/#block-stats
 func (c *Client) GetBlockStatsByHash(ctx context.Context, hash string) (*BlockStats, error) {
+	if err := c.validateHash("hash", hash); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/block/hash/%s/stats", hash)
 	return requestAndUnmarshal[BlockStats](ctx, c, url, http.MethodGet, nil, ErrStatsNotFound)
 }
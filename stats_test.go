@@ -285,7 +285,7 @@ func TestStatsEndpoints_ValidChains(t *testing.T) {
 				t.Errorf("GetBlockStats failed for %s: %s", tc.chain, err.Error())
 			}
 
-			_, err = client.GetBlockStatsByHash(context.Background(), "testHash")
+			_, err = client.GetBlockStatsByHash(context.Background(), testBlockHashGenesis)
 			if err != nil {
 				t.Errorf("GetBlockStatsByHash failed for %s: %s", tc.chain, err.Error())
 			}
@@ -318,7 +318,7 @@ func TestStatsEndpoints_ValidChains(t *testing.T) {
 			}
 
 			mockClient.SetResponse(func(_ *http.Request) (*http.Response, error) {
-				resp := newHTTPResponse(`{"height":762291,"hash":"test","tag_counts":{}}`)
+				resp := newHTTPResponse(`{"height":762291,"hash":"0000000000000000052a4f8e39ce22eb94e0ac16b1ddbba1ea2e18e8d2c1aa4b","tag_counts":{}}`)
 				resp.StatusCode = http.StatusOK
 				return resp, nil
 			})
@@ -0,0 +1,371 @@
+package whatsonchain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// StreamChannelBlockHeaders is the channel of new block headers (decode with StreamMessage.Block)
+	StreamChannelBlockHeaders = "woc:blockHeaders"
+
+	// StreamChannelMempool is the channel of new mempool transactions (decode with StreamMessage.Tx)
+	StreamChannelMempool = "woc:mempool"
+
+	// streamAddressChannelPrefix is the prefix of the channel of an address's transactions
+	streamAddressChannelPrefix = "woc:address:"
+
+	// streamURLMain is the streaming endpoint of mainnet
+	streamURLMain = "wss://socket-v2.whatsonchain.com/websocket"
+
+	// streamURLTest is the streaming endpoint of testnet
+	streamURLTest = "wss://socket-v2-testnet.whatsonchain.com/websocket"
+
+	// defaultStreamBuffer is the default StreamOptions.Buffer
+	defaultStreamBuffer = 256
+
+	// defaultStreamMinBackoff is the default StreamOptions.MinBackoff
+	defaultStreamMinBackoff = time.Second
+
+	// defaultStreamMaxBackoff is the default StreamOptions.MaxBackoff
+	defaultStreamMaxBackoff = time.Minute
+
+	// streamBackoffJitter is the most random time added to each reconnect delay
+	streamBackoffJitter = 500 * time.Millisecond
+)
+
+// StreamAddressChannel returns the channel of the transactions of an address (decode with StreamMessage.Tx)
+func StreamAddressChannel(address string) string {
+	return streamAddressChannelPrefix + address
+}
+
+// StreamMessage is a message published on a subscribed channel
+type StreamMessage struct {
+	Channel string          `json:"channel"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Block decodes the message as a block (StreamChannelBlockHeaders)
+func (m *StreamMessage) Block() (*BlockInfo, error) {
+	var block BlockInfo
+	if err := json.Unmarshal(m.Data, &block); err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+// Tx decodes the message as a transaction (StreamChannelMempool and address channels)
+func (m *StreamMessage) Tx() (*TxInfo, error) {
+	var tx TxInfo
+	if err := json.Unmarshal(m.Data, &tx); err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+// StreamOptions are the options for NewStream
+type StreamOptions struct {
+	Buffer      int             // size of the message channel (default 256)
+	DialTimeout time.Duration   // limit on the dial and handshake (default: the client's dialer timeout)
+	Header      http.Header     // extra handshake headers (the client's API key and user agent are always sent)
+	MaxBackoff  time.Duration   // longest delay between reconnects (default 1m)
+	MinBackoff  time.Duration   // first delay between reconnects, doubled after each failure (default 1s)
+	OnConnect   func()          // called after each (re)connect, once the subscriptions are sent
+	OnError     func(err error) // called with connection errors and rejected commands
+	URL         string          // the ws:// or wss:// endpoint (default: the WhatsOnChain endpoint of the client's network)
+}
+
+// Stream is a streaming client for the WhatsOnChain live feeds.
+//
+// Run connects to the WebSocket endpoint, subscribes to the channels and sends every published
+// message to Messages. When the connection drops it reconnects with exponential backoff and
+// subscribes again. Channels can be subscribed and unsubscribed at any time, connected or not.
+//
+// The feed speaks the JSON protocol of the Centrifugo server WhatsOnChain runs: connect, subscribe
+// and unsubscribe commands, publications pushed per channel and empty-object pings.
+type Stream struct {
+	backoff   *ExponentialBackoff
+	chain     ChainType
+	channels  map[string]bool // subscribed channels, sent again on every connect
+	conn      *wsConn         // the current connection, nil while disconnected
+	header    http.Header
+	messages  chan *StreamMessage
+	mu        sync.Mutex // protects channels, conn and nextID
+	network   NetworkType
+	nextID    int
+	onConnect func()
+	onError   func(err error)
+	timeout   time.Duration
+	url       string
+}
+
+// NewStream creates a streaming client with the client's network, API key and user agent.
+// Without StreamOptions.URL only the BSV mainnet and testnet feeds are known.
+func NewStream(client ClientInterface, opts *StreamOptions) (*Stream, error) {
+	if opts == nil {
+		opts = &StreamOptions{}
+	}
+
+	streamURL := opts.URL
+	if streamURL == "" {
+		if client.Chain() != ChainBSV {
+			return nil, ErrBSVChainRequired
+		}
+		switch client.Network() {
+		case NetworkMain:
+			streamURL = streamURLMain
+		case NetworkTest:
+			streamURL = streamURLTest
+		default:
+			return nil, fmt.Errorf("%w: no stream endpoint for %s", ErrInvalidNetwork, client.Network())
+		}
+	}
+
+	header := opts.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	if apiKey := client.APIKey(); apiKey != "" {
+		header.Set(apiHeaderKey, apiKey)
+	}
+	header.Set("User-Agent", client.UserAgent())
+
+	s := &Stream{
+		chain:     client.Chain(),
+		channels:  make(map[string]bool),
+		header:    header,
+		network:   client.Network(),
+		onConnect: opts.OnConnect,
+		onError:   opts.OnError,
+		timeout:   opts.DialTimeout,
+		url:       streamURL,
+	}
+	if s.timeout <= 0 {
+		_, s.timeout = client.DialerConfig()
+	}
+	minBackoff, maxBackoff := opts.MinBackoff, opts.MaxBackoff
+	if minBackoff <= 0 {
+		minBackoff = defaultStreamMinBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = max(defaultStreamMaxBackoff, minBackoff)
+	}
+	s.backoff = NewExponentialBackoff(minBackoff, maxBackoff, 2, min(streamBackoffJitter, minBackoff))
+	buffer := opts.Buffer
+	if buffer <= 0 {
+		buffer = defaultStreamBuffer
+	}
+	s.messages = make(chan *StreamMessage, buffer)
+	return s, nil
+}
+
+// Messages returns the channel Run sends messages to. It is closed when Run returns.
+func (s *Stream) Messages() <-chan *StreamMessage {
+	return s.messages
+}
+
+// Subscribe adds channels; when connected, they are subscribed right away.
+// The error is from sending the commands; the channels are subscribed on the next connect regardless.
+func (s *Stream) Subscribe(channels ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var added []string
+	for _, channel := range channels {
+		if !s.channels[channel] {
+			s.channels[channel] = true
+			added = append(added, channel)
+		}
+	}
+	return s.sendCommands("subscribe", added)
+}
+
+// SubscribeAddresses subscribes to the transactions of addresses (see StreamAddressChannel).
+// It returns an *InvalidInputError, and subscribes to none, if an address is not valid for the client's
+// chain and network.
+func (s *Stream) SubscribeAddresses(addresses ...string) error {
+	channels := make([]string, 0, len(addresses))
+	for i, address := range addresses {
+		if err := checkAddress(fmt.Sprintf("addresses[%d]", i), address, s.chain, s.network); err != nil {
+			return err
+		}
+		channels = append(channels, StreamAddressChannel(address))
+	}
+	return s.Subscribe(channels...)
+}
+
+// Unsubscribe removes channels; when connected, they are unsubscribed right away
+func (s *Stream) Unsubscribe(channels ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var removed []string
+	for _, channel := range channels {
+		if s.channels[channel] {
+			delete(s.channels, channel)
+			removed = append(removed, channel)
+		}
+	}
+	return s.sendCommands("unsubscribe", removed)
+}
+
+// UnsubscribeAddresses unsubscribes from the transactions of addresses
+func (s *Stream) UnsubscribeAddresses(addresses ...string) error {
+	channels := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		channels = append(channels, StreamAddressChannel(address))
+	}
+	return s.Unsubscribe(channels...)
+}
+
+// Subscriptions returns the subscribed channels, sorted
+func (s *Stream) Subscriptions() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sortedKeys(s.channels)
+}
+
+// Run connects and streams until the context is canceled, reconnecting with exponential backoff
+// (reset after every successful connect) and passing the errors to OnError.
+// Run returns the context's error and closes the message channel, so it can only be called once.
+func (s *Stream) Run(ctx context.Context) error {
+	defer close(s.messages)
+
+	attempt := 0
+	for {
+		connected, err := s.session(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if connected {
+			attempt = 0
+		}
+		if s.onError != nil {
+			s.onError(err)
+		}
+
+		select {
+		case <-time.After(s.backoff.NextInterval(attempt)):
+			attempt++
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// session connects, subscribes and reads messages until the connection fails.
+// connected reports whether the handshake and the subscriptions went through.
+func (s *Stream) session(ctx context.Context) (connected bool, err error) {
+	conn, err := dialWebSocket(ctx, s.url, s.header, s.timeout)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = conn.Close() }()
+	stop := context.AfterFunc(ctx, func() { _ = conn.conn.Close() })
+	defer stop()
+
+	s.mu.Lock()
+	s.conn = conn
+	err = s.sendCommand("connect", map[string]string{"name": "go-whatsonchain"})
+	if err == nil {
+		err = s.sendCommands("subscribe", sortedKeys(s.channels))
+	}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.conn = nil
+		s.mu.Unlock()
+	}()
+	if err != nil {
+		return false, err
+	}
+	if s.onConnect != nil {
+		s.onConnect()
+	}
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return true, err
+		}
+		// The server may batch several replies in one message, one per line
+		for _, line := range bytes.Split(data, []byte("\n")) {
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+			if err = s.handle(ctx, conn, line); err != nil {
+				return true, err
+			}
+		}
+	}
+}
+
+// streamReply is a reply or push from the streaming server
+type streamReply struct {
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+	ID   int `json:"id"`
+	Push *struct {
+		Channel string `json:"channel"`
+		Pub     *struct {
+			Data json.RawMessage `json:"data"`
+		} `json:"pub"`
+	} `json:"push"`
+}
+
+// handle processes one reply: pings are answered, publications sent to Messages and errors to OnError
+func (s *Stream) handle(ctx context.Context, conn *wsConn, line []byte) error {
+	var reply streamReply
+	if err := json.Unmarshal(line, &reply); err != nil {
+		return fmt.Errorf("%w: %w", ErrWebSocketProtocol, err)
+	}
+
+	switch {
+	case reply.Error != nil:
+		if s.onError != nil {
+			s.onError(fmt.Errorf("%w: command %d: %s (code %d)", ErrStreamCommand, reply.ID, reply.Error.Message, reply.Error.Code))
+		}
+	case reply.Push != nil:
+		if reply.Push.Pub == nil {
+			return nil // join, leave and other channel events
+		}
+		select {
+		case s.messages <- &StreamMessage{Channel: reply.Push.Channel, Data: reply.Push.Pub.Data}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	case reply.ID == 0:
+		return conn.WriteMessage(wsOpText, []byte("{}")) // ping
+	}
+	return nil
+}
+
+// sendCommands sends a subscribe or unsubscribe command per channel when connected (s.mu must be held)
+func (s *Stream) sendCommands(method string, channels []string) error {
+	var errs []error
+	for _, channel := range channels {
+		errs = append(errs, s.sendCommand(method, map[string]string{"channel": channel}))
+	}
+	return errors.Join(errs...)
+}
+
+// sendCommand sends a command when connected (s.mu must be held)
+func (s *Stream) sendCommand(method string, params map[string]string) error {
+	if s.conn == nil {
+		return nil
+	}
+	s.nextID++
+	command, err := json.Marshal(map[string]any{"id": s.nextID, method: params})
+	if err != nil {
+		return err
+	}
+	return s.conn.WriteMessage(wsOpText, command)
+}
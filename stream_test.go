@@ -0,0 +1,222 @@
+package whatsonchain
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// streamCommand is a command received by the fake streaming server
+type streamCommand struct {
+	ID          int               `json:"id"`
+	Connect     map[string]string `json:"connect"`
+	Subscribe   map[string]string `json:"subscribe"`
+	Unsubscribe map[string]string `json:"unsubscribe"`
+}
+
+// readStreamCommand reads the next command sent to the fake server, formatted as "subscribe:woc:mempool"
+func readStreamCommand(t *testing.T, server *wsConn) string {
+	t.Helper()
+
+	_ = server.conn.SetReadDeadline(time.Now().Add(time.Second))
+	_, data, err := server.ReadMessage()
+	require.NoError(t, err)
+	var command streamCommand
+	require.NoError(t, json.Unmarshal(data, &command))
+	require.Positive(t, command.ID)
+	switch {
+	case command.Connect != nil:
+		return "connect"
+	case command.Subscribe != nil:
+		return "subscribe:" + command.Subscribe["channel"]
+	case command.Unsubscribe != nil:
+		return "unsubscribe:" + command.Unsubscribe["channel"]
+	}
+	return string(data)
+}
+
+// acceptStream waits for the stream to connect to the fake server
+func acceptStream(t *testing.T, conns <-chan *wsConn) *wsConn {
+	t.Helper()
+
+	select {
+	case server := <-conns:
+		t.Cleanup(func() { _ = server.conn.Close() })
+		return server
+	case <-time.After(time.Second):
+		require.Fail(t, "the stream did not connect")
+		return nil
+	}
+}
+
+// runStream runs the stream until the test ends
+func runStream(t *testing.T, stream *Stream) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- stream.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		require.ErrorIs(t, <-done, context.Canceled)
+	})
+}
+
+// TestNewStream tests the stream endpoint and handshake headers
+func TestNewStream(t *testing.T) {
+	t.Parallel()
+
+	t.Run("endpoints", func(t *testing.T) {
+		t.Parallel()
+		stream, err := NewStream(newMockClient(&mockHTTPFunc{}), nil)
+		require.NoError(t, err)
+		assert.Equal(t, streamURLMain, stream.url)
+		assert.Equal(t, testKey, stream.header.Get(apiHeaderKey))
+		assert.NotEmpty(t, stream.header.Get("User-Agent"))
+
+		stream, err = NewStream(newCachedMockClient(&mockHTTPFunc{}, WithNetwork(NetworkTest)), nil)
+		require.NoError(t, err)
+		assert.Equal(t, streamURLTest, stream.url)
+
+		stream, err = NewStream(newMockClient(&mockHTTPFunc{}), &StreamOptions{URL: "ws://localhost:1234/feed"})
+		require.NoError(t, err)
+		assert.Equal(t, "ws://localhost:1234/feed", stream.url)
+	})
+
+	t.Run("no known endpoint", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewStream(newMockClientBTC(&mockHTTPFunc{}), nil)
+		require.ErrorIs(t, err, ErrBSVChainRequired)
+
+		_, err = NewStream(newCachedMockClient(&mockHTTPFunc{}, WithNetwork(NetworkStn)), nil)
+		require.ErrorIs(t, err, ErrInvalidNetwork)
+	})
+}
+
+// TestStream_Subscriptions tests subscribing and unsubscribing while disconnected
+func TestStream_Subscriptions(t *testing.T) {
+	t.Parallel()
+
+	stream, err := NewStream(newCachedMockClient(&mockHTTPFunc{}), nil)
+	require.NoError(t, err)
+
+	require.NoError(t, stream.Subscribe(StreamChannelMempool, StreamChannelBlockHeaders, StreamChannelMempool))
+	require.NoError(t, stream.SubscribeAddresses(testAddress1))
+	requireInvalidInput(t, stream.SubscribeAddresses(testAddress2, "not-an-address"), "addresses[1]")
+	assert.Equal(t, []string{StreamAddressChannel(testAddress1), StreamChannelBlockHeaders, StreamChannelMempool}, stream.Subscriptions())
+
+	require.NoError(t, stream.Unsubscribe(StreamChannelBlockHeaders))
+	require.NoError(t, stream.UnsubscribeAddresses(testAddress1))
+	assert.Equal(t, []string{StreamChannelMempool}, stream.Subscriptions())
+}
+
+// TestStream_Run tests connecting, subscribing and receiving publications
+func TestStream_Run(t *testing.T) {
+	t.Parallel()
+
+	wsURL, conns := newFakeWebSocketServer(t)
+	errs := make(chan error, 10)
+	stream, err := NewStream(newMockClient(&mockHTTPFunc{}), &StreamOptions{
+		OnError: func(err error) { errs <- err },
+		URL:     wsURL,
+	})
+	require.NoError(t, err)
+	require.NoError(t, stream.Subscribe(StreamChannelMempool))
+	runStream(t, stream)
+
+	server := acceptStream(t, conns)
+	assert.Equal(t, "connect", readStreamCommand(t, server))
+	assert.Equal(t, "subscribe:"+StreamChannelMempool, readStreamCommand(t, server))
+
+	t.Run("publications", func(t *testing.T) {
+		require.NoError(t, server.WriteMessage(wsOpText, []byte(
+			`{"id":1,"connect":{"client":"abc"}}`+"\n"+
+				`{"push":{"channel":"woc:mempool","pub":{"data":{"txid":"`+testTxID1+`","size":225}}}}`)))
+		select {
+		case message := <-stream.Messages():
+			assert.Equal(t, StreamChannelMempool, message.Channel)
+			tx, err := message.Tx()
+			require.NoError(t, err)
+			assert.Equal(t, testTxID1, tx.TxID)
+			assert.Equal(t, int64(225), tx.Size)
+		case <-time.After(time.Second):
+			require.Fail(t, "no message")
+		}
+	})
+
+	t.Run("ping", func(t *testing.T) {
+		require.NoError(t, server.WriteMessage(wsOpText, []byte(`{}`)))
+		_ = server.conn.SetReadDeadline(time.Now().Add(time.Second))
+		_, data, err := server.ReadMessage()
+		require.NoError(t, err)
+		assert.JSONEq(t, `{}`, string(data))
+	})
+
+	t.Run("subscribe while connected", func(t *testing.T) {
+		require.NoError(t, stream.Subscribe(StreamChannelBlockHeaders))
+		assert.Equal(t, "subscribe:"+StreamChannelBlockHeaders, readStreamCommand(t, server))
+		require.NoError(t, stream.Unsubscribe(StreamChannelMempool))
+		assert.Equal(t, "unsubscribe:"+StreamChannelMempool, readStreamCommand(t, server))
+
+		require.NoError(t, server.WriteMessage(wsOpText, []byte(
+			`{"push":{"channel":"woc:blockHeaders","pub":{"data":{"hash":"`+testBlockHashGenesis+`","height":0}}}}`)))
+		message := <-stream.Messages()
+		block, err := message.Block()
+		require.NoError(t, err)
+		assert.Equal(t, testBlockHashGenesis, block.Hash)
+	})
+
+	t.Run("rejected command", func(t *testing.T) {
+		require.NoError(t, server.WriteMessage(wsOpText, []byte(`{"id":7,"error":{"code":103,"message":"permission denied"}}`)))
+		select {
+		case err := <-errs:
+			require.ErrorIs(t, err, ErrStreamCommand)
+			assert.Contains(t, err.Error(), "permission denied")
+		case <-time.After(time.Second):
+			require.Fail(t, "no error")
+		}
+	})
+}
+
+// TestStream_Reconnect tests the stream reconnects and subscribes again when the connection drops
+func TestStream_Reconnect(t *testing.T) {
+	t.Parallel()
+
+	wsURL, conns := newFakeWebSocketServer(t)
+	var connects atomic.Int64
+	stream, err := NewStream(newMockClient(&mockHTTPFunc{}), &StreamOptions{
+		MinBackoff: 10 * time.Millisecond,
+		OnConnect:  func() { connects.Add(1) },
+		URL:        wsURL,
+	})
+	require.NoError(t, err)
+	require.NoError(t, stream.Subscribe(StreamChannelMempool))
+	runStream(t, stream)
+
+	server := acceptStream(t, conns)
+	assert.Equal(t, "connect", readStreamCommand(t, server))
+	assert.Equal(t, "subscribe:"+StreamChannelMempool, readStreamCommand(t, server))
+
+	// Subscribed while the connection is down: sent on the next connect
+	require.NoError(t, server.writeFrame(wsOpClose, []byte{0x03, 0xE9}))
+	require.Eventually(t, func() bool {
+		stream.mu.Lock()
+		defer stream.mu.Unlock()
+		return stream.conn == nil
+	}, time.Second, 5*time.Millisecond)
+	require.NoError(t, stream.Subscribe(StreamChannelBlockHeaders))
+
+	server = acceptStream(t, conns)
+	assert.Equal(t, "connect", readStreamCommand(t, server))
+	assert.ElementsMatch(t, []string{
+		"subscribe:" + StreamChannelBlockHeaders, "subscribe:" + StreamChannelMempool,
+	}, []string{readStreamCommand(t, server), readStreamCommand(t, server)})
+	assert.Eventually(t, func() bool { return connects.Load() == 2 }, time.Second, 5*time.Millisecond)
+}
@@ -13,6 +13,10 @@ func (c *Client) GetOneSatOrdinalByOrigin(ctx context.Context, origin string) (*
 		return nil, ErrBSVChainRequired
 	}
 
+	if err := c.validateOutpoint("origin", origin); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/token/1satordinals/%s/origin", origin)
 	return requestAndUnmarshal[OneSatOrdinalToken](ctx, c, url, http.MethodGet, nil, ErrTokenNotFound)
 }
@@ -25,6 +29,10 @@ func (c *Client) GetOneSatOrdinalByOutpoint(ctx context.Context, outpoint string
 		return nil, ErrBSVChainRequired
 	}
 
+	if err := c.validateOutpoint("outpoint", outpoint); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/token/1satordinals/%s", outpoint)
 	return requestAndUnmarshal[OneSatOrdinalToken](ctx, c, url, http.MethodGet, nil, ErrTokenNotFound)
 }
@@ -37,6 +45,10 @@ func (c *Client) GetOneSatOrdinalContent(ctx context.Context, outpoint string) (
 		return nil, ErrBSVChainRequired
 	}
 
+	if err := c.validateOutpoint("outpoint", outpoint); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/token/1satordinals/%s/content", outpoint)
 	return requestAndUnmarshal[OneSatOrdinalContent](ctx, c, url, http.MethodGet, nil, ErrTokenNotFound)
 }
@@ -49,6 +61,10 @@ func (c *Client) GetOneSatOrdinalLatest(ctx context.Context, outpoint string) (*
 		return nil, ErrBSVChainRequired
 	}
 
+	if err := c.validateOutpoint("outpoint", outpoint); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/token/1satordinals/%s/latest", outpoint)
 	return requestAndUnmarshal[OneSatOrdinalLatest](ctx, c, url, http.MethodGet, nil, ErrTokenNotFound)
 }
@@ -61,6 +77,10 @@ func (c *Client) GetOneSatOrdinalHistory(ctx context.Context, outpoint string) (
 		return nil, ErrBSVChainRequired
 	}
 
+	if err := c.validateOutpoint("outpoint", outpoint); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/token/1satordinals/%s/history", outpoint)
 	return requestAndUnmarshalSlice[*OneSatOrdinalHistory](ctx, c, url, http.MethodGet, nil, ErrTokenNotFound)
 }
@@ -73,6 +93,10 @@ func (c *Client) GetOneSatOrdinalsByTxID(ctx context.Context, txid string) ([]*O
 		return nil, ErrBSVChainRequired
 	}
 
+	if err := c.validateHash("txid", txid); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/token/1satordinals/tx/%s", txid)
 	return requestAndUnmarshalSlice[*OneSatOrdinalToken](ctx, c, url, http.MethodGet, nil, ErrTokenNotFound)
 }
@@ -121,6 +145,10 @@ func (c *Client) GetTokenUTXOsForAddress(ctx context.Context, address string) ([
 		return nil, ErrBSVChainRequired
 	}
 
+	if err := c.validateAddress("address", address); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/address/%s/tokens/unspent", address)
 	return requestAndUnmarshalSlice[*STASTokenUTXO](ctx, c, url, http.MethodGet, nil, ErrTokenNotFound)
 }
@@ -133,6 +161,10 @@ func (c *Client) GetAddressTokenBalance(ctx context.Context, address string) (*S
 		return nil, ErrBSVChainRequired
 	}
 
+	if err := c.validateAddress("address", address); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/address/%s/tokens", address)
 	return requestAndUnmarshal[STASTokenBalance](ctx, c, url, http.MethodGet, nil, ErrTokenNotFound)
 }
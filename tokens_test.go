@@ -26,8 +26,8 @@ func (m *mockHTTPTokensValid) Do(req *http.Request) (*http.Response, error) {
 
 	// 1Sat Ordinals endpoints
 	if strings.Contains(req.URL.String(), "/bsv/") && strings.Contains(req.URL.String(), "/token/1satordinals/") {
-		// Check for "invalid" to trigger 404 with empty body
-		if strings.Contains(req.URL.String(), "/invalid/") {
+		// Check for an unknown origin to trigger 404 with empty body
+		if strings.Contains(req.URL.String(), testTxIDUnknown) {
 			resp.StatusCode = http.StatusNotFound
 			resp.Body = io.NopCloser(bytes.NewBufferString(``))
 			return resp, nil
@@ -38,8 +38,8 @@ func (m *mockHTTPTokensValid) Do(req *http.Request) (*http.Response, error) {
 		if strings.Contains(req.URL.String(), "/origin") {
 			// Mock GetOneSatOrdinalByOrigin
 			mockToken := OneSatOrdinalToken{
-				Outpoint: "test_outpoint",
-				Origin:   "827748:753:0",
+				Outpoint: testTxID1 + "_0",
+				Origin:   testTxID1 + "_0",
 				Height:   123456,
 				Idx:      1,
 				Lock:     "test_lock",
@@ -67,7 +67,7 @@ func (m *mockHTTPTokensValid) Do(req *http.Request) (*http.Response, error) {
 		} else if strings.Contains(req.URL.String(), "/latest") {
 			// Mock GetOneSatOrdinalLatest
 			mockLatest := OneSatOrdinalLatest{
-				TxID:   "test_txid",
+				TxID:   testTxID1,
 				Vout:   0,
 				Height: 123456,
 				Idx:    1,
@@ -81,13 +81,13 @@ func (m *mockHTTPTokensValid) Do(req *http.Request) (*http.Response, error) {
 			// Mock GetOneSatOrdinalHistory
 			mockHistory := []*OneSatOrdinalHistory{
 				{
-					TxID:   "test_txid1",
+					TxID:   testTxID1,
 					Vout:   0,
 					Height: 123456,
 					Idx:    1,
 				},
 				{
-					TxID:   "test_txid2",
+					TxID:   testTxID2,
 					Vout:   1,
 					Height: 123457,
 					Idx:    2,
@@ -102,14 +102,14 @@ func (m *mockHTTPTokensValid) Do(req *http.Request) (*http.Response, error) {
 			// Mock GetOneSatOrdinalsByTxID
 			mockTokens := []*OneSatOrdinalToken{
 				{
-					Outpoint: "test_outpoint1",
-					Origin:   "827748:753:0",
+					Outpoint: testTxID1 + "_0",
+					Origin:   testTxID1 + "_0",
 					Height:   123456,
 					Idx:      1,
 				},
 				{
-					Outpoint: "test_outpoint2",
-					Origin:   "827748:753:1",
+					Outpoint: testTxID1 + "_1",
+					Origin:   testTxID1 + "_1",
 					Height:   123456,
 					Idx:      2,
 				},
@@ -122,8 +122,8 @@ func (m *mockHTTPTokensValid) Do(req *http.Request) (*http.Response, error) {
 		} else {
 			// Mock GetOneSatOrdinalByOutpoint
 			mockToken := OneSatOrdinalToken{
-				Outpoint: "test_outpoint",
-				Origin:   "827748:753:0",
+				Outpoint: testTxID1 + "_0",
+				Origin:   testTxID1 + "_0",
 				Height:   123456,
 				Idx:      1,
 				Lock:     "test_lock",
@@ -212,7 +212,7 @@ func (m *mockHTTPTokensValid) Do(req *http.Request) (*http.Response, error) {
 		resp.StatusCode = http.StatusOK
 		mockUTXOs := []*STASTokenUTXO{
 			{
-				TxID:       "test_txid",
+				TxID:       testTxID1,
 				Vout:       0,
 				Amount:     1000,
 				Script:     "test_script",
@@ -233,7 +233,7 @@ func (m *mockHTTPTokensValid) Do(req *http.Request) (*http.Response, error) {
 	if strings.Contains(req.URL.String(), "/bsv/") && strings.Contains(req.URL.String(), "/address/") && strings.Contains(req.URL.String(), "/tokens") && !strings.Contains(req.URL.String(), "/unspent") {
 		resp.StatusCode = http.StatusOK
 		mockBalance := STASTokenBalance{
-			Address: "test_address",
+			Address: testAddress1,
 			Tokens: []STASTokenBalanceInfo{
 				{
 					ContractID: "test_contract_id",
@@ -255,11 +255,11 @@ func (m *mockHTTPTokensValid) Do(req *http.Request) (*http.Response, error) {
 		resp.StatusCode = http.StatusOK
 		mockTxList := TxList{
 			{
-				TxID:        "test_txid1",
+				TxID:        testTxID1,
 				BlockHeight: 123456,
 			},
 			{
-				TxID:        "test_txid2",
+				TxID:        testTxID2,
 				BlockHeight: 123457,
 			},
 		}
@@ -295,25 +295,25 @@ func TestClient_GetOneSatOrdinalByOrigin(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, err = btcClient.GetOneSatOrdinalByOrigin(context.Background(), "827748:753:0")
+	_, err = btcClient.GetOneSatOrdinalByOrigin(context.Background(), testTxID1+"_0")
 	if !errors.Is(err, ErrBSVChainRequired) {
 		t.Fatalf("expected BSV chain required error, got: %v", err)
 	}
 
 	// Test valid request
 	client := newMockClientBSV(&mockHTTPTokensValid{})
-	token, err := client.GetOneSatOrdinalByOrigin(context.Background(), "827748:753:0")
+	token, err := client.GetOneSatOrdinalByOrigin(context.Background(), testTxID1+"_0")
 	if err != nil {
 		t.Fatalf("error occurred: %s", err.Error())
 	}
 
-	if token.Origin != "827748:753:0" {
-		t.Fatalf("expected origin: 827748:753:0, got: %s", token.Origin)
+	if token.Origin != testTxID1+"_0" {
+		t.Fatalf("expected origin: %s, got: %s", testTxID1+"_0", token.Origin)
 	}
 
 	// Test 404 not found
 	client = newMockClientBSV(&mockHTTPTokensValid{})
-	_, err = client.GetOneSatOrdinalByOrigin(context.Background(), "invalid")
+	_, err = client.GetOneSatOrdinalByOrigin(context.Background(), testTxIDUnknown+"_0")
 	if !errors.Is(err, ErrTokenNotFound) {
 		t.Fatalf("expected ErrTokenNotFound, got: %v", err)
 	}
@@ -326,20 +326,20 @@ func TestClient_GetOneSatOrdinalByOutpoint(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, err = btcClient.GetOneSatOrdinalByOutpoint(context.Background(), "test_outpoint")
+	_, err = btcClient.GetOneSatOrdinalByOutpoint(context.Background(), testTxID1+"_0")
 	if !errors.Is(err, ErrBSVChainRequired) {
 		t.Fatalf("expected BSV chain required error, got: %v", err)
 	}
 
 	// Test valid request
 	client := newMockClientBSV(&mockHTTPTokensValid{})
-	token, err := client.GetOneSatOrdinalByOutpoint(context.Background(), "test_outpoint")
+	token, err := client.GetOneSatOrdinalByOutpoint(context.Background(), testTxID1+"_0")
 	if err != nil {
 		t.Fatalf("error occurred: %s", err.Error())
 	}
 
-	if token.Outpoint != "test_outpoint" {
-		t.Fatalf("expected outpoint: test_outpoint, got: %s", token.Outpoint)
+	if token.Outpoint != testTxID1+"_0" {
+		t.Fatalf("expected outpoint: %s, got: %s", testTxID1+"_0", token.Outpoint)
 	}
 }
 
@@ -350,14 +350,14 @@ func TestClient_GetOneSatOrdinalContent(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, err = btcClient.GetOneSatOrdinalContent(context.Background(), "test_outpoint")
+	_, err = btcClient.GetOneSatOrdinalContent(context.Background(), testTxID1+"_0")
 	if !errors.Is(err, ErrBSVChainRequired) {
 		t.Fatalf("expected BSV chain required error, got: %v", err)
 	}
 
 	// Test valid request
 	client := newMockClientBSV(&mockHTTPTokensValid{})
-	content, err := client.GetOneSatOrdinalContent(context.Background(), "test_outpoint")
+	content, err := client.GetOneSatOrdinalContent(context.Background(), testTxID1+"_0")
 	if err != nil {
 		t.Fatalf("error occurred: %s", err.Error())
 	}
@@ -374,20 +374,20 @@ func TestClient_GetOneSatOrdinalLatest(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, err = btcClient.GetOneSatOrdinalLatest(context.Background(), "test_outpoint")
+	_, err = btcClient.GetOneSatOrdinalLatest(context.Background(), testTxID1+"_0")
 	if !errors.Is(err, ErrBSVChainRequired) {
 		t.Fatalf("expected BSV chain required error, got: %v", err)
 	}
 
 	// Test valid request
 	client := newMockClientBSV(&mockHTTPTokensValid{})
-	latest, err := client.GetOneSatOrdinalLatest(context.Background(), "test_outpoint")
+	latest, err := client.GetOneSatOrdinalLatest(context.Background(), testTxID1+"_0")
 	if err != nil {
 		t.Fatalf("error occurred: %s", err.Error())
 	}
 
-	if latest.TxID != "test_txid" {
-		t.Fatalf("expected txid: test_txid, got: %s", latest.TxID)
+	if latest.TxID != testTxID1 {
+		t.Fatalf("expected txid: %s, got: %s", testTxID1, latest.TxID)
 	}
 }
 
@@ -398,14 +398,14 @@ func TestClient_GetOneSatOrdinalHistory(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, err = btcClient.GetOneSatOrdinalHistory(context.Background(), "test_outpoint")
+	_, err = btcClient.GetOneSatOrdinalHistory(context.Background(), testTxID1+"_0")
 	if !errors.Is(err, ErrBSVChainRequired) {
 		t.Fatalf("expected BSV chain required error, got: %v", err)
 	}
 
 	// Test valid request
 	client := newMockClientBSV(&mockHTTPTokensValid{})
-	history, err := client.GetOneSatOrdinalHistory(context.Background(), "test_outpoint")
+	history, err := client.GetOneSatOrdinalHistory(context.Background(), testTxID1+"_0")
 	if err != nil {
 		t.Fatalf("error occurred: %s", err.Error())
 	}
@@ -414,8 +414,8 @@ func TestClient_GetOneSatOrdinalHistory(t *testing.T) {
 		t.Fatalf("expected 2 history records, got: %d", len(history))
 	}
 
-	if history[0].TxID != "test_txid1" {
-		t.Fatalf("expected first txid: test_txid1, got: %s", history[0].TxID)
+	if history[0].TxID != testTxID1 {
+		t.Fatalf("expected first txid: %s, got: %s", testTxID1, history[0].TxID)
 	}
 }
 
@@ -426,14 +426,14 @@ func TestClient_GetOneSatOrdinalsByTxID(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, err = btcClient.GetOneSatOrdinalsByTxID(context.Background(), "test_txid")
+	_, err = btcClient.GetOneSatOrdinalsByTxID(context.Background(), testTxID1)
 	if !errors.Is(err, ErrBSVChainRequired) {
 		t.Fatalf("expected BSV chain required error, got: %v", err)
 	}
 
 	// Test valid request
 	client := newMockClientBSV(&mockHTTPTokensValid{})
-	tokens, err := client.GetOneSatOrdinalsByTxID(context.Background(), "test_txid")
+	tokens, err := client.GetOneSatOrdinalsByTxID(context.Background(), testTxID1)
 	if err != nil {
 		t.Fatalf("error occurred: %s", err.Error())
 	}
@@ -442,8 +442,8 @@ func TestClient_GetOneSatOrdinalsByTxID(t *testing.T) {
 		t.Fatalf("expected 2 tokens, got: %d", len(tokens))
 	}
 
-	if tokens[0].Outpoint != "test_outpoint1" {
-		t.Fatalf("expected first outpoint: test_outpoint1, got: %s", tokens[0].Outpoint)
+	if tokens[0].Outpoint != testTxID1+"_0" {
+		t.Fatalf("expected first outpoint: %s, got: %s", testTxID1+"_0", tokens[0].Outpoint)
 	}
 }
 
@@ -534,14 +534,14 @@ func TestClient_GetTokenUTXOsForAddress(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, err = btcClient.GetTokenUTXOsForAddress(context.Background(), "test_address")
+	_, err = btcClient.GetTokenUTXOsForAddress(context.Background(), testAddress1)
 	if !errors.Is(err, ErrBSVChainRequired) {
 		t.Fatalf("expected BSV chain required error, got: %v", err)
 	}
 
 	// Test valid request
 	client := newMockClientBSV(&mockHTTPTokensValid{})
-	utxos, err := client.GetTokenUTXOsForAddress(context.Background(), "test_address")
+	utxos, err := client.GetTokenUTXOsForAddress(context.Background(), testAddress1)
 	if err != nil {
 		t.Fatalf("error occurred: %s", err.Error())
 	}
@@ -562,20 +562,20 @@ func TestClient_GetAddressTokenBalance(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, err = btcClient.GetAddressTokenBalance(context.Background(), "test_address")
+	_, err = btcClient.GetAddressTokenBalance(context.Background(), testAddress1)
 	if !errors.Is(err, ErrBSVChainRequired) {
 		t.Fatalf("expected BSV chain required error, got: %v", err)
 	}
 
 	// Test valid request
 	client := newMockClientBSV(&mockHTTPTokensValid{})
-	balance, err := client.GetAddressTokenBalance(context.Background(), "test_address")
+	balance, err := client.GetAddressTokenBalance(context.Background(), testAddress1)
 	if err != nil {
 		t.Fatalf("error occurred: %s", err.Error())
 	}
 
-	if balance.Address != "test_address" {
-		t.Fatalf("expected address: test_address, got: %s", balance.Address)
+	if balance.Address != testAddress1 {
+		t.Fatalf("expected address: %s, got: %s", testAddress1, balance.Address)
 	}
 
 	if len(balance.Tokens) != 1 {
@@ -606,8 +606,8 @@ func TestClient_GetTokenTransactions(t *testing.T) {
 		t.Fatalf("expected 2 transactions, got: %d", len(transactions))
 	}
 
-	if transactions[0].TxID != "test_txid1" {
-		t.Fatalf("expected first txid: test_txid1, got: %s", transactions[0].TxID)
+	if transactions[0].TxID != testTxID1 {
+		t.Fatalf("expected first txid: %s, got: %s", testTxID1, transactions[0].TxID)
 	}
 }
 
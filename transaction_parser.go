@@ -0,0 +1,390 @@
+package whatsonchain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	// txCoinbaseVout is the previous output index used by coinbase inputs
+	txCoinbaseVout uint32 = math.MaxUint32
+
+	// txSegwitFlag is the flag byte that follows the segwit marker (0x00) after the version
+	txSegwitFlag byte = 0x01
+)
+
+// Transaction is a transaction parsed locally from its raw bytes (see ParseTransaction),
+// without a round-trip to DecodeTransaction
+type Transaction struct {
+	Inputs   []*TransactionInput  `json:"inputs"`
+	LockTime uint32               `json:"locktime"`
+	Outputs  []*TransactionOutput `json:"outputs"`
+	Version  uint32               `json:"version"`
+}
+
+// TransactionInput is an input of a parsed Transaction
+type TransactionInput struct {
+	PrevTxID string   `json:"prev_txid"` // previous transaction ID (hex, in the usual reversed display order)
+	PrevVout uint32   `json:"prev_vout"` // previous output index
+	Script   []byte   `json:"script"`    // unlocking script (scriptSig), or the coinbase data
+	Sequence uint32   `json:"sequence"`
+	Witness  [][]byte `json:"witness,omitempty"` // segwit witness stack (BTC only)
+}
+
+// TransactionOutput is an output of a parsed Transaction
+type TransactionOutput struct {
+	Script []byte   `json:"script"` // locking script (scriptPubKey)
+	Value  Satoshis `json:"value"`
+}
+
+// ParseTransaction parses a raw transaction (legacy or segwit serialization).
+// It returns ErrInvalidTransaction if the bytes are truncated, non-canonical or have trailing data.
+func ParseTransaction(raw []byte) (*Transaction, error) {
+	r := &txReader{data: raw}
+	tx := readTransaction(r)
+	if r.err == nil && r.remaining() > 0 {
+		r.fail(fmt.Sprintf("%d trailing bytes", r.remaining()))
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return tx, nil
+}
+
+// readTransaction reads one transaction, leaving any following data unread (check r.err)
+func readTransaction(r *txReader) *Transaction {
+	tx := &Transaction{Version: r.uint32()}
+
+	// Segwit serialization: a 0x00 marker (zero inputs) followed by the 0x01 flag
+	segwit := r.remaining() >= 2 && r.data[r.pos] == 0x00 && r.data[r.pos+1] == txSegwitFlag
+	if segwit {
+		r.pos += 2
+	}
+
+	inputCount := r.count(txMinInputSize)
+	tx.Inputs = make([]*TransactionInput, 0, inputCount)
+	for i := uint64(0); i < inputCount && r.err == nil; i++ {
+		input := &TransactionInput{}
+		input.PrevTxID = hex.EncodeToString(reverseBytes(r.bytes(32)))
+		input.PrevVout = r.uint32()
+		input.Script = r.varBytes()
+		input.Sequence = r.uint32()
+		tx.Inputs = append(tx.Inputs, input)
+	}
+
+	outputCount := r.count(txMinOutputSize)
+	tx.Outputs = make([]*TransactionOutput, 0, outputCount)
+	for i := uint64(0); i < outputCount && r.err == nil; i++ {
+		output := &TransactionOutput{Value: Satoshis(r.uint64())} //nolint:gosec // the wire format is a signed int64
+		output.Script = r.varBytes()
+		tx.Outputs = append(tx.Outputs, output)
+	}
+
+	if segwit {
+		hasWitness := false
+		for _, input := range tx.Inputs {
+			items := r.count(1)
+			for j := uint64(0); j < items && r.err == nil; j++ {
+				input.Witness = append(input.Witness, r.varBytes())
+			}
+			hasWitness = hasWitness || len(input.Witness) > 0
+		}
+		if !hasWitness && r.err == nil {
+			r.fail("segwit flag without witness data")
+		}
+	}
+
+	tx.LockTime = r.uint32()
+	return tx
+}
+
+// ParseTransactionHex parses a raw transaction from hex (e.g. from GetRawTransactionData)
+func ParseTransactionHex(txHex string) (*Transaction, error) {
+	raw, err := hex.DecodeString(strings.TrimSpace(txHex))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTransaction, err)
+	}
+	return ParseTransaction(raw)
+}
+
+// HasWitness returns true if any input has segwit witness data
+func (tx *Transaction) HasWitness() bool {
+	for _, input := range tx.Inputs {
+		if len(input.Witness) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCoinbase returns true if the transaction is a coinbase (a single input spending no previous output)
+func (tx *Transaction) IsCoinbase() bool {
+	return len(tx.Inputs) == 1 && tx.Inputs[0].PrevVout == txCoinbaseVout &&
+		tx.Inputs[0].PrevTxID == strings.Repeat("0", 64)
+}
+
+// Bytes serializes the transaction (with witness data if it has any)
+func (tx *Transaction) Bytes() []byte {
+	return tx.serialize(tx.HasWitness())
+}
+
+// Hex serializes the transaction to hex (with witness data if it has any)
+func (tx *Transaction) Hex() string {
+	return hex.EncodeToString(tx.Bytes())
+}
+
+// TxID returns the transaction ID: the reversed double SHA-256 of the serialization without witness data
+func (tx *Transaction) TxID() string {
+	return hashToID(tx.serialize(false))
+}
+
+// Hash returns the reversed double SHA-256 of the full serialization (the wtxid for segwit, else the TxID)
+func (tx *Transaction) Hash() string {
+	return hashToID(tx.Bytes())
+}
+
+// TxInfo converts the transaction into the API's TxInfo structure.
+// Only the fields that can be derived from the raw bytes are set (no block data, addresses or asm).
+func (tx *Transaction) TxInfo() *TxInfo {
+	raw := tx.Bytes()
+	info := &TxInfo{
+		Hash:     hashToID(raw),
+		Hex:      hex.EncodeToString(raw),
+		LockTime: int64(tx.LockTime),
+		Size:     int64(len(raw)),
+		TxID:     tx.TxID(),
+		Version:  int64(tx.Version),
+		Vin:      make([]VinInfo, 0, len(tx.Inputs)),
+		Vout:     make([]VoutInfo, 0, len(tx.Outputs)),
+	}
+
+	coinbase := tx.IsCoinbase()
+	for _, input := range tx.Inputs {
+		vin := VinInfo{Sequence: int64(input.Sequence)}
+		if coinbase {
+			vin.Coinbase = hex.EncodeToString(input.Script)
+		} else {
+			vin.TxID = input.PrevTxID
+			vin.Vout = int64(input.PrevVout)
+			vin.ScriptSig = ScriptSigInfo{Hex: hex.EncodeToString(input.Script)}
+		}
+		info.Vin = append(info.Vin, vin)
+	}
+
+	for n, output := range tx.Outputs {
+		info.Vout = append(info.Vout, VoutInfo{
+			N:             int64(n),
+			ScriptPubKey:  ScriptPubKeyInfo{Hex: hex.EncodeToString(output.Script)},
+			Value:         float64(output.Value) / float64(UnitBSV),
+			ValueSatoshis: output.Value,
+		})
+	}
+
+	return info
+}
+
+// serialize encodes the transaction, in segwit format if witness is true
+func (tx *Transaction) serialize(witness bool) []byte {
+	var buf bytes.Buffer
+	buf.Write(binary.LittleEndian.AppendUint32(nil, tx.Version))
+	if witness {
+		buf.Write([]byte{0x00, txSegwitFlag})
+	}
+
+	writeVarInt(&buf, uint64(len(tx.Inputs)))
+	for _, input := range tx.Inputs {
+		buf.Write(internalHash(input.PrevTxID))
+		buf.Write(binary.LittleEndian.AppendUint32(nil, input.PrevVout))
+		writeVarBytes(&buf, input.Script)
+		buf.Write(binary.LittleEndian.AppendUint32(nil, input.Sequence))
+	}
+
+	writeVarInt(&buf, uint64(len(tx.Outputs)))
+	for _, output := range tx.Outputs {
+		buf.Write(binary.LittleEndian.AppendUint64(nil, uint64(output.Value))) //nolint:gosec // the wire format is a signed int64
+		writeVarBytes(&buf, output.Script)
+	}
+
+	if witness {
+		for _, input := range tx.Inputs {
+			writeVarInt(&buf, uint64(len(input.Witness)))
+			for _, item := range input.Witness {
+				writeVarBytes(&buf, item)
+			}
+		}
+	}
+
+	buf.Write(binary.LittleEndian.AppendUint32(nil, tx.LockTime))
+	return buf.Bytes()
+}
+
+const (
+	// txMinInputSize is the smallest serialized input (outpoint, empty script, sequence)
+	txMinInputSize = 32 + 4 + 1 + 4
+
+	// txMinOutputSize is the smallest serialized output (value, empty script)
+	txMinOutputSize = 8 + 1
+)
+
+// txReader reads the wire format, remembering the first error so callers can check once
+type txReader struct {
+	data    []byte
+	err     error
+	invalid error // sentinel wrapped by errors (ErrInvalidTransaction if nil)
+	pos     int
+}
+
+// remaining returns the number of unread bytes
+func (r *txReader) remaining() int {
+	return len(r.data) - r.pos
+}
+
+// fail records the first error
+func (r *txReader) fail(reason string) {
+	if r.err == nil {
+		invalid := r.invalid
+		if invalid == nil {
+			invalid = ErrInvalidTransaction
+		}
+		r.err = fmt.Errorf("%w: %s at byte %d", invalid, reason, r.pos)
+	}
+}
+
+// bytes reads n bytes
+func (r *txReader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || n > r.remaining() {
+		r.fail("unexpected end of data")
+		return nil
+	}
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
+
+// uint32 reads a little-endian uint32
+func (r *txReader) uint32() uint32 {
+	if b := r.bytes(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+// uint64 reads a little-endian uint64
+func (r *txReader) uint64() uint64 {
+	if b := r.bytes(8); b != nil {
+		return binary.LittleEndian.Uint64(b)
+	}
+	return 0
+}
+
+// varInt reads a canonical variable length integer (CompactSize)
+func (r *txReader) varInt() uint64 {
+	prefix := r.bytes(1)
+	if prefix == nil {
+		return 0
+	}
+
+	var value, minimum uint64
+	switch prefix[0] {
+	case 0xfd:
+		if b := r.bytes(2); b != nil {
+			value, minimum = uint64(binary.LittleEndian.Uint16(b)), 0xfd
+		}
+	case 0xfe:
+		if b := r.bytes(4); b != nil {
+			value, minimum = uint64(binary.LittleEndian.Uint32(b)), 0x10000
+		}
+	case 0xff:
+		if b := r.bytes(8); b != nil {
+			value, minimum = binary.LittleEndian.Uint64(b), 0x100000000
+		}
+	default:
+		return uint64(prefix[0])
+	}
+
+	if r.err == nil && value < minimum {
+		r.fail("non-canonical varint")
+	}
+	if r.err != nil {
+		return 0
+	}
+	return value
+}
+
+// count reads an item count, rejecting counts that cannot fit in the remaining data
+func (r *txReader) count(minItemSize int) uint64 {
+	count := r.varInt()
+	if r.err == nil && count > uint64(r.remaining()/minItemSize) { //nolint:gosec // remaining is never negative
+		r.fail(fmt.Sprintf("count %d exceeds the remaining data", count))
+	}
+	if r.err != nil {
+		return 0
+	}
+	return count
+}
+
+// varBytes reads a varint length followed by that many bytes
+func (r *txReader) varBytes() []byte {
+	length := r.varInt()
+	if r.err == nil && length > uint64(r.remaining()) { //nolint:gosec // remaining is never negative
+		r.fail("unexpected end of data")
+		return nil
+	}
+	b := r.bytes(int(length)) //nolint:gosec // bounded by the remaining data
+	if b == nil {
+		return nil
+	}
+	return bytes.Clone(b)
+}
+
+// writeVarInt writes a canonical variable length integer (CompactSize)
+func writeVarInt(buf *bytes.Buffer, value uint64) {
+	switch {
+	case value < 0xfd:
+		buf.WriteByte(byte(value))
+	case value <= math.MaxUint16:
+		buf.WriteByte(0xfd)
+		buf.Write(binary.LittleEndian.AppendUint16(nil, uint16(value)))
+	case value <= math.MaxUint32:
+		buf.WriteByte(0xfe)
+		buf.Write(binary.LittleEndian.AppendUint32(nil, uint32(value)))
+	default:
+		buf.WriteByte(0xff)
+		buf.Write(binary.LittleEndian.AppendUint64(nil, value))
+	}
+}
+
+// writeVarBytes writes a varint length followed by the bytes
+func writeVarBytes(buf *bytes.Buffer, b []byte) {
+	writeVarInt(buf, uint64(len(b)))
+	buf.Write(b)
+}
+
+// doubleSHA256 returns SHA-256(SHA-256(data))
+func doubleSHA256(data []byte) []byte {
+	first := sha256.Sum256(data)
+	second := sha256.Sum256(first[:])
+	return second[:]
+}
+
+// hashToID returns the double SHA-256 of data as a reversed hex ID (txid/block hash display order)
+func hashToID(data []byte) string {
+	return hex.EncodeToString(reverseBytes(doubleSHA256(data)))
+}
+
+// reverseBytes returns a reversed copy of b
+func reverseBytes(b []byte) []byte {
+	reversed := make([]byte, len(b))
+	for i := range b {
+		reversed[len(b)-1-i] = b[i]
+	}
+	return reversed
+}
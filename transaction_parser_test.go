@@ -0,0 +1,150 @@
+package whatsonchain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// testRawTxBlock170 is the first non-coinbase transaction (block 170)
+	testRawTxBlock170 = "0100000001c997a5e56e104102fa209c6a852dd90660a20b2d9c352423edce25857fcd3704000000004847304402204e45e16932b8af514961a1d3a1a25fdf3f4f7732e9d624c6c61548ab5fb8cd410220181522ec8eca07de4860a4acdd12909d831cc56cbbac4622082221a8768d1d0901ffffffff0200ca9a3b00000000434104ae1a62fe09c5f51b13905f07f06b99a2f7159b2225f374cd378d71302fa28414e7aab37397f554a7df5f142c21c1b7303b8a0626f1baded5c72a704f7e6cd84cac00286bee0000000043410411db93e1dcdb8a016b49840f8c53bc1eb68a382e97b1482ecad7b148a6909a5cb2e0eaddfb84ccf9744464f82e160bfa9b8b64f9d4c03f999b8643f656b412a3ac00000000"
+	testTxIDBlock170  = "f4184fc596403b9d638783cf57adfe4c75c605f6356fbc91338530e9831e9e16"
+
+	// testRawTxGenesisCoinbase is the coinbase transaction of the genesis block
+	testRawTxGenesisCoinbase = "01000000010000000000000000000000000000000000000000000000000000000000000000ffffffff4d04ffff001d0104455468652054696d65732030332f4a616e2f32303039204368616e63656c6c6f72206f6e206272696e6b206f66207365636f6e64206261696c6f757420666f722062616e6b73ffffffff0100f2052a01000000434104678afdb0fe5548271967f1a67130b7105cd6a828e03909a67962e0ea1f61deb649f6bc3f4cef38c4f35504e51ec112de5c384df7ba0b8d578a4c702b6bf11d5fac00000000"
+	testTxIDGenesisCoinbase  = "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b"
+)
+
+// TestParseTransactionHex tests parsing known mainnet transactions
+func TestParseTransactionHex(t *testing.T) {
+	t.Parallel()
+
+	t.Run("block 170", func(t *testing.T) {
+		t.Parallel()
+		tx, err := ParseTransactionHex(testRawTxBlock170)
+		require.NoError(t, err)
+
+		assert.Equal(t, testTxIDBlock170, tx.TxID())
+		assert.Equal(t, testTxIDBlock170, tx.Hash())
+		assert.Equal(t, uint32(1), tx.Version)
+		assert.Equal(t, uint32(0), tx.LockTime)
+		assert.False(t, tx.IsCoinbase())
+		assert.False(t, tx.HasWitness())
+
+		require.Len(t, tx.Inputs, 1)
+		assert.Equal(t, "0437cd7f8525ceed2324359c2d0ba26006d92d856a9c20fa0241106ee5a597c9", tx.Inputs[0].PrevTxID)
+		assert.Equal(t, uint32(0), tx.Inputs[0].PrevVout)
+		assert.Len(t, tx.Inputs[0].Script, 72)
+		assert.Equal(t, uint32(0xffffffff), tx.Inputs[0].Sequence)
+
+		require.Len(t, tx.Outputs, 2)
+		assert.Equal(t, Satoshis(1_000_000_000), tx.Outputs[0].Value)
+		assert.Equal(t, Satoshis(4_000_000_000), tx.Outputs[1].Value)
+		assert.Len(t, tx.Outputs[0].Script, 67)
+
+		assert.Equal(t, testRawTxBlock170, tx.Hex())
+	})
+
+	t.Run("genesis coinbase", func(t *testing.T) {
+		t.Parallel()
+		tx, err := ParseTransactionHex(testRawTxGenesisCoinbase)
+		require.NoError(t, err)
+
+		assert.Equal(t, testTxIDGenesisCoinbase, tx.TxID())
+		assert.True(t, tx.IsCoinbase())
+		assert.Contains(t, string(tx.Inputs[0].Script), "The Times 03/Jan/2009")
+		assert.Equal(t, Satoshis(5_000_000_000), tx.Outputs[0].Value)
+		assert.Equal(t, testRawTxGenesisCoinbase, tx.Hex())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		for _, txHex := range []string{
+			"",
+			"zz",
+			"01000000",
+			testRawTxBlock170[:len(testRawTxBlock170)-2],
+			testRawTxBlock170 + "00",
+			"01000000" + "fd0100",                          // non-canonical input count
+			"01000000" + "ff",                              // truncated input count
+			"01000000" + "fdffff",                          // input count larger than the data
+			"01000000" + "0001" + "00" + "00" + "00000000", // segwit flag without witness data
+		} {
+			_, err := ParseTransactionHex(txHex)
+			require.ErrorIs(t, err, ErrInvalidTransaction, txHex)
+		}
+	})
+}
+
+// TestTransaction_Segwit tests the segwit serialization
+func TestTransaction_Segwit(t *testing.T) {
+	t.Parallel()
+
+	legacy, err := ParseTransactionHex(testRawTxBlock170)
+	require.NoError(t, err)
+	txID := legacy.TxID()
+
+	// Add a witness: the txid stays the same, the hash (wtxid) changes
+	legacy.Inputs[0].Witness = [][]byte{{0x30, 0x44}, {}, bytes.Repeat([]byte{0x02}, 33)}
+	raw := legacy.Bytes()
+	assert.Equal(t, []byte{0x00, txSegwitFlag}, raw[4:6])
+	assert.Equal(t, txID, legacy.TxID())
+	assert.NotEqual(t, txID, legacy.Hash())
+
+	parsed, err := ParseTransaction(raw)
+	require.NoError(t, err)
+	assert.Equal(t, legacy, parsed)
+	assert.Equal(t, txID, parsed.TxID())
+	assert.Equal(t, raw, parsed.Bytes())
+}
+
+// TestTransaction_TxInfo tests the conversion into the API's TxInfo structure
+func TestTransaction_TxInfo(t *testing.T) {
+	t.Parallel()
+
+	tx, err := ParseTransactionHex(testRawTxBlock170)
+	require.NoError(t, err)
+	info := tx.TxInfo()
+
+	assert.Equal(t, testTxIDBlock170, info.TxID)
+	assert.Equal(t, testTxIDBlock170, info.Hash)
+	assert.Equal(t, testRawTxBlock170, info.Hex)
+	assert.Equal(t, int64(len(testRawTxBlock170)/2), info.Size)
+	assert.Equal(t, int64(1), info.Version)
+
+	require.Len(t, info.Vin, 1)
+	assert.Equal(t, "0437cd7f8525ceed2324359c2d0ba26006d92d856a9c20fa0241106ee5a597c9", info.Vin[0].TxID)
+	assert.Equal(t, hex.EncodeToString(tx.Inputs[0].Script), info.Vin[0].ScriptSig.Hex)
+	assert.Empty(t, info.Vin[0].Coinbase)
+
+	require.Len(t, info.Vout, 2)
+	assert.Equal(t, int64(1), info.Vout[1].N)
+	assert.Equal(t, Satoshis(4_000_000_000), info.Vout[1].ValueSatoshis)
+	assert.InDelta(t, 40.0, info.Vout[1].Value, 0)
+	assert.True(t, strings.HasSuffix(info.Vout[1].ScriptPubKey.Hex, "ac"))
+
+	coinbase, err := ParseTransactionHex(testRawTxGenesisCoinbase)
+	require.NoError(t, err)
+	coinbaseInfo := coinbase.TxInfo()
+	assert.Equal(t, hex.EncodeToString(coinbase.Inputs[0].Script), coinbaseInfo.Vin[0].Coinbase)
+	assert.Empty(t, coinbaseInfo.Vin[0].TxID)
+}
+
+// TestWriteVarInt tests the CompactSize encoding boundaries
+func TestWriteVarInt(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []uint64{0, 0xfc, 0xfd, 0xffff, 0x10000, 0xffffffff, 0x100000000} {
+		var buf bytes.Buffer
+		writeVarInt(&buf, value)
+		r := &txReader{data: buf.Bytes()}
+		assert.Equal(t, value, r.varInt())
+		require.NoError(t, r.err)
+		assert.Equal(t, 0, r.remaining())
+	}
+}
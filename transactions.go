@@ -7,15 +7,18 @@ import (
 	"net/http"
 	netURL "net/url"
 	"strings"
-	"time"
 )
 
 // GetTxByHash this endpoint retrieves transaction details with given transaction hash
 //
 // For more information: https://docs.whatsonchain.com/#get-by-tx-hash
 func (c *Client) GetTxByHash(ctx context.Context, hash string) (*TxInfo, error) {
+	if err := c.validateHash("txid", hash); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/tx/hash/%s", hash)
-	return requestAndUnmarshal[TxInfo](ctx, c, url, http.MethodGet, nil, ErrTransactionNotFound)
+	return cachedRequestAndUnmarshal[TxInfo](ctx, c, CacheEndpointTransaction, url, ErrTransactionNotFound)
 }
 
 // BulkTransactionDetails this fetches details for multiple transactions in single request
@@ -29,6 +32,9 @@ func (c *Client) BulkTransactionDetails(ctx context.Context, hashes *TxHashes) (
 	if len(hashes.TxIDs) > MaxTransactionsUTXO {
 		return nil, fmt.Errorf("%w: %d transactions requested, max is %d", ErrMaxTransactionsExceeded, len(hashes.TxIDs), MaxTransactionsUTXO)
 	}
+	if err := c.validateHashes("txids", hashes.TxIDs); err != nil {
+		return nil, err
+	}
 
 	postData, err := json.Marshal(hashes)
 	if err != nil {
@@ -36,58 +42,14 @@ func (c *Client) BulkTransactionDetails(ctx context.Context, hashes *TxHashes) (
 	}
 
 	url := c.buildURL("/txs")
-	return requestAndUnmarshalSlice[*TxInfo](ctx, c, url, http.MethodPost, postData, nil)
+	return requestAndUnmarshalSlice[*TxInfo](ctx, c, url, http.MethodPost, postData, ErrTransactionNotFound)
 }
 
 // BulkTransactionDetailsProcessor will get the details for ALL transactions in batches
 // Processes 20 transactions per request
 // See: BulkTransactionDetails()
-func (c *Client) BulkTransactionDetailsProcessor(ctx context.Context, hashes *TxHashes) (txList TxList, err error) {
-	if hashes == nil {
-		return nil, ErrMissingRequest
-	}
-	// Break up the transactions into batches
-	batches := chunkSlice(hashes.TxIDs, MaxTransactionsUTXO)
-
-	// Set up rate limiting with a ticker
-	ticker := time.NewTicker(time.Second / time.Duration(c.RateLimit()))
-	defer ticker.Stop()
-
-	txHashes := &TxHashes{}
-
-	// Loop Batches - and get each batch (multiple batches of MaxTransactionsUTXO)
-	for _, batch := range batches {
-		// Check for context cancellation before processing
-		select {
-		case <-ctx.Done():
-			return txList, ctx.Err()
-		default:
-		}
-
-		// Wait for rate limit tick
-		select {
-		case <-ctx.Done():
-			return txList, ctx.Err()
-		case <-ticker.C:
-		}
-
-		// Reuse the TxHashes struct
-		txHashes.TxIDs = txHashes.TxIDs[:0]
-		txHashes.TxIDs = append(txHashes.TxIDs, batch...)
-
-		// Get the tx details (max of MaxTransactionsUTXO)
-		var returnedList TxList
-		if returnedList, err = c.BulkTransactionDetails(
-			ctx, txHashes,
-		); err != nil {
-			return txList, err
-		}
-
-		// Add to the list
-		txList = append(txList, returnedList...)
-	}
-
-	return txList, err
+func (c *Client) BulkTransactionDetailsProcessor(ctx context.Context, hashes *TxHashes) (TxList, error) {
+	return bulkTxIDs(ctx, c, "BulkTransactionDetails", hashes, MaxTransactionsUTXO, c.BulkTransactionDetails)
 }
 
 // GetMerkleProof retrieves the merkle proof for a transaction.
@@ -97,6 +59,10 @@ func (c *Client) BulkTransactionDetailsProcessor(ctx context.Context, hashes *Tx
 //
 // For more information: https://docs.whatsonchain.com/#get-merkle-proof
 func (c *Client) GetMerkleProof(ctx context.Context, hash string) (MerkleResults, error) {
+	if err := c.validateHash("txid", hash); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/tx/%s/proof", hash)
 	return requestAndUnmarshalSlice[*MerkleInfo](ctx, c, url, http.MethodGet, nil, ErrTransactionNotFound)
 }
@@ -105,16 +71,24 @@ func (c *Client) GetMerkleProof(ctx context.Context, hash string) (MerkleResults
 //
 // For more information: https://docs.whatsonchain.com/#get-merkle-proof-tsc
 func (c *Client) GetMerkleProofTSC(ctx context.Context, hash string) (MerkleTSCResults, error) {
+	if err := c.validateHash("txid", hash); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/tx/%s/proof/tsc", hash)
-	return requestAndUnmarshalSlice[*MerkleTSCInfo](ctx, c, url, http.MethodGet, nil, ErrTransactionNotFound)
+	return cachedRequestAndUnmarshalSlice[*MerkleTSCInfo](ctx, c, CacheEndpointMerkleProofTSC, url, ErrTransactionNotFound)
 }
 
 // GetRawTransactionData this endpoint returns raw hex for the transaction with given hash
 //
 // For more information: https://docs.whatsonchain.com/#get-raw-transaction-data
 func (c *Client) GetRawTransactionData(ctx context.Context, hash string) (string, error) {
+	if err := c.validateHash("txid", hash); err != nil {
+		return "", err
+	}
+
 	url := c.buildURL("/tx/%s/hex", hash)
-	return requestString(ctx, c, url)
+	return cachedRequestString(ctx, c, CacheEndpointRawTransaction, url, ErrTransactionNotFound)
 }
 
 // BulkRawTransactionData this fetches raw hex data for multiple
@@ -129,6 +103,9 @@ func (c *Client) BulkRawTransactionData(ctx context.Context, hashes *TxHashes) (
 	if len(hashes.TxIDs) > MaxTransactionsRaw {
 		return nil, fmt.Errorf("%w: %d transactions requested, max is %d", ErrMaxRawTransactionsExceeded, len(hashes.TxIDs), MaxTransactionsRaw)
 	}
+	if err := c.validateHashes("txids", hashes.TxIDs); err != nil {
+		return nil, err
+	}
 
 	postData, err := json.Marshal(hashes)
 	if err != nil {
@@ -136,7 +113,7 @@ func (c *Client) BulkRawTransactionData(ctx context.Context, hashes *TxHashes) (
 	}
 
 	url := c.buildURL("/txs/hex")
-	return requestAndUnmarshalSlice[*TxInfo](ctx, c, url, http.MethodPost, postData, nil)
+	return requestAndUnmarshalSlice[*TxInfo](ctx, c, url, http.MethodPost, postData, ErrTransactionNotFound)
 }
 
 // BulkRawTransactionDataProcessor this fetches raw hex data for
@@ -144,60 +121,23 @@ func (c *Client) BulkRawTransactionData(ctx context.Context, hashes *TxHashes) (
 // Max 20 transactions per request
 //
 // For more information: https://docs.whatsonchain.com/#bulk-raw-transaction-data
-func (c *Client) BulkRawTransactionDataProcessor(ctx context.Context, hashes *TxHashes) (txList TxList, err error) {
-	if hashes == nil {
-		return nil, ErrMissingRequest
-	}
-	// Break up the transactions into batches
-	batches := chunkSlice(hashes.TxIDs, MaxTransactionsRaw)
-
-	// Set up rate limiting with a ticker
-	ticker := time.NewTicker(time.Second / time.Duration(c.RateLimit()))
-	defer ticker.Stop()
-
-	txHashes := &TxHashes{}
-
-	// Loop Batches - and get each batch (multiple batches of MaxTransactionsRaw)
-	for _, batch := range batches {
-		// Check for context cancellation before processing
-		select {
-		case <-ctx.Done():
-			return txList, ctx.Err()
-		default:
-		}
-
-		// Wait for rate limit tick
-		select {
-		case <-ctx.Done():
-			return txList, ctx.Err()
-		case <-ticker.C:
-		}
-
-		// Reuse the TxHashes struct
-		txHashes.TxIDs = txHashes.TxIDs[:0]
-		txHashes.TxIDs = append(txHashes.TxIDs, batch...)
-
-		// Get the tx details (max of MaxTransactionsRaw)
-		var returnedList TxList
-		if returnedList, err = c.BulkRawTransactionData(
-			ctx, txHashes,
-		); err != nil {
-			return txList, err
-		}
-
-		// Add to the list
-		txList = append(txList, returnedList...)
-	}
-
-	return txList, err
+func (c *Client) BulkRawTransactionDataProcessor(ctx context.Context, hashes *TxHashes) (TxList, error) {
+	return bulkTxIDs(ctx, c, "BulkRawTransactionData", hashes, MaxTransactionsRaw, c.BulkRawTransactionData)
 }
 
 // GetRawTransactionOutputData this endpoint returns raw hex for the transaction output with given hash and index
 //
 // For more information: https://docs.whatsonchain.com/#get-raw-transaction-output-data
 func (c *Client) GetRawTransactionOutputData(ctx context.Context, hash string, vOutIndex int) (string, error) {
+	if err := c.validateHash("txid", hash); err != nil {
+		return "", err
+	}
+	if err := c.validateIndex("vOutIndex", vOutIndex); err != nil {
+		return "", err
+	}
+
 	url := c.buildURL("/tx/%s/out/%d/hex", hash, vOutIndex)
-	return requestString(ctx, c, url)
+	return requestString(ctx, c, url, ErrTransactionNotFound)
 }
 
 // BroadcastTx will broadcast transaction using this endpoint.
@@ -212,9 +152,9 @@ func (c *Client) BroadcastTx(ctx context.Context, txHex string) (txID string, er
 	}
 
 	// https://api.whatsonchain.com/v1/bsv/<network>/tx/raw
+	// Non-OK status codes are returned as an *APIError by request()
 	var resp []byte
-	var statusCode int
-	if resp, statusCode, err = c.request(
+	if resp, _, err = c.request(
 		ctx,
 		c.buildURL("/tx/raw"),
 		http.MethodPost, postData,
@@ -222,11 +162,6 @@ func (c *Client) BroadcastTx(ctx context.Context, txHex string) (txID string, er
 		return "", fmt.Errorf("%w: %w", ErrBroadcastFailed, err)
 	}
 
-	// Check for non-OK status codes using the standard helper
-	if err = checkStatusCode(statusCode, resp); err != nil {
-		return "", fmt.Errorf("%w: %w", ErrBroadcastFailed, err)
-	}
-
 	// Remove quotes or spaces from successful response
 	txID = strings.TrimSpace(strings.ReplaceAll(string(resp), `"`, ""))
 	return txID, nil
@@ -289,10 +224,10 @@ func (c *Client) BulkBroadcastTx(ctx context.Context, rawTxs []string,
 	}
 
 	var resp []byte
-	var statusCode int
 
 	// https://api.whatsonchain.com/v1/bsv/<network>/tx/broadcast?feedback=<feedback>
-	if resp, statusCode, err = c.request(
+	// Non-OK status codes are returned as an *APIError by request()
+	if resp, _, err = c.request(
 		ctx,
 		c.buildURL("/tx/broadcast?feedback=%t", feedback),
 		http.MethodPost, postData,
@@ -300,11 +235,6 @@ func (c *Client) BulkBroadcastTx(ctx context.Context, rawTxs []string,
 		return nil, fmt.Errorf("%w: %w", ErrBroadcastFailed, err)
 	}
 
-	// Check for non-OK status codes using the standard helper
-	if err = checkStatusCode(statusCode, resp); err != nil {
-		return nil, fmt.Errorf("%w: %w", ErrBroadcastFailed, err)
-	}
-
 	response = &BulkBroadcastResponse{Feedback: feedback}
 	if feedback {
 		if err = json.Unmarshal(resp, response); err != nil {
@@ -332,9 +262,13 @@ func (c *Client) DecodeTransaction(ctx context.Context, txHex string) (*TxInfo,
 //
 // For more information: https://docs.whatsonchain.com/#download-receipt
 func (c *Client) DownloadReceipt(ctx context.Context, hash string) (string, error) {
+	if err := c.validateHash("txid", hash); err != nil {
+		return "", err
+	}
+
 	// This endpoint does not follow the convention of the WOC API v1
 	url := fmt.Sprintf("https://%s.whatsonchain.com/receipt/%s", c.Network(), netURL.PathEscape(hash))
-	return requestString(ctx, c, url)
+	return requestString(ctx, c, url, ErrTransactionNotFound)
 }
 
 // GetTransactionPropagationStatus this endpoint retrieves transaction propagation status (BSV only)
@@ -345,6 +279,10 @@ func (c *Client) GetTransactionPropagationStatus(ctx context.Context, hash strin
 		return nil, ErrBSVChainRequired
 	}
 
+	if err := c.validateHash("txid", hash); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/tx/hash/%s/propagation", hash)
 	return requestAndUnmarshal[PropagationStatus](ctx, c, url, http.MethodGet, nil, ErrTransactionNotFound)
 }
@@ -360,6 +298,9 @@ func (c *Client) BulkTransactionStatus(ctx context.Context, hashes *TxHashes) (T
 	if len(hashes.TxIDs) > MaxTransactionsUTXO {
 		return nil, fmt.Errorf("%w: %d transactions requested, max is %d", ErrMaxTransactionsExceeded, len(hashes.TxIDs), MaxTransactionsUTXO)
 	}
+	if err := c.validateHashes("txids", hashes.TxIDs); err != nil {
+		return nil, err
+	}
 
 	postData, err := json.Marshal(hashes)
 	if err != nil {
@@ -367,21 +308,28 @@ func (c *Client) BulkTransactionStatus(ctx context.Context, hashes *TxHashes) (T
 	}
 
 	url := c.buildURL("/txs/status")
-	return requestAndUnmarshalSlice[*TxStatus](ctx, c, url, http.MethodPost, postData, nil)
+	return requestAndUnmarshalSlice[*TxStatus](ctx, c, url, http.MethodPost, postData, ErrTransactionNotFound)
+}
+
+// BulkTransactionStatusProcessor retrieves statuses for any number of transactions in batches of 20
+// See: BulkTransactionStatus()
+func (c *Client) BulkTransactionStatusProcessor(ctx context.Context, hashes *TxHashes) (TxStatusList, error) {
+	return bulkTxIDs(ctx, c, "BulkTransactionStatus", hashes, MaxTransactionsUTXO, c.BulkTransactionStatus)
 }
 
 // GetTransactionAsBinary this endpoint retrieves transaction data as binary
 //
 // For more information: https://docs.whatsonchain.com/#get-tx-binary
 func (c *Client) GetTransactionAsBinary(ctx context.Context, hash string) ([]byte, error) {
+	if err := c.validateHash("txid", hash); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/tx/%s/bin", hash)
-	resp, err := requestString(ctx, c, url)
+	resp, err := cachedRequestString(ctx, c, CacheEndpointTransactionBinary, url, ErrTransactionNotFound)
 	if err != nil {
 		return nil, err
 	}
-	if len(resp) == 0 {
-		return nil, ErrTransactionNotFound
-	}
 	return []byte(resp), nil
 }
 
@@ -396,6 +344,9 @@ func (c *Client) BulkRawTransactionOutputData(ctx context.Context, request *Bulk
 	if len(request.TxIDs) > MaxTransactionsUTXO {
 		return nil, fmt.Errorf("%w: %d transactions requested, max is %d", ErrMaxUTXOsExceeded, len(request.TxIDs), MaxTransactionsUTXO)
 	}
+	if err := c.validateOutputRequests(request.TxIDs); err != nil {
+		return nil, err
+	}
 
 	postData, err := json.Marshal(request)
 	if err != nil {
@@ -403,13 +354,35 @@ func (c *Client) BulkRawTransactionOutputData(ctx context.Context, request *Bulk
 	}
 
 	url := c.buildURL("/txs/vouts/hex")
-	return requestAndUnmarshalSlice[*BulkRawOutputResponse](ctx, c, url, http.MethodPost, postData, nil)
+	return requestAndUnmarshalSlice[*BulkRawOutputResponse](ctx, c, url, http.MethodPost, postData, ErrTransactionNotFound)
+}
+
+// BulkRawTransactionOutputDataProcessor retrieves raw output data for any number of transactions in batches of 20
+// See: BulkRawTransactionOutputData()
+func (c *Client) BulkRawTransactionOutputDataProcessor(ctx context.Context, request *BulkRawOutputRequest) ([]*BulkRawOutputResponse, error) {
+	if request == nil {
+		return nil, ErrMissingRequest
+	}
+	if err := c.validateOutputRequests(request.TxIDs); err != nil {
+		return nil, err
+	}
+	return bulkFanOut(ctx, c.bulkRun("BulkRawTransactionOutputData"), request.TxIDs, MaxTransactionsUTXO,
+		func(ctx context.Context, batch []BulkRawOutputTxID) ([]*BulkRawOutputResponse, error) {
+			return c.BulkRawTransactionOutputData(ctx, &BulkRawOutputRequest{TxIDs: batch})
+		})
 }
 
 // GetUnconfirmedSpentOutput this endpoint retrieves unconfirmed spent transaction output details
 //
 // For more information: https://docs.whatsonchain.com/#get-unconfirmed-spent
 func (c *Client) GetUnconfirmedSpentOutput(ctx context.Context, txHash string, index int) (*SpentOutput, error) {
+	if err := c.validateHash("txid", txHash); err != nil {
+		return nil, err
+	}
+	if err := c.validateIndex("index", index); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/tx/%s/%d/unconfirmed/spent", txHash, index)
 	return requestAndUnmarshal[SpentOutput](ctx, c, url, http.MethodGet, nil, ErrTransactionNotFound)
 }
@@ -418,6 +391,13 @@ func (c *Client) GetUnconfirmedSpentOutput(ctx context.Context, txHash string, i
 //
 // For more information: https://docs.whatsonchain.com/#get-confirmed-spent
 func (c *Client) GetConfirmedSpentOutput(ctx context.Context, txHash string, index int) (*SpentOutput, error) {
+	if err := c.validateHash("txid", txHash); err != nil {
+		return nil, err
+	}
+	if err := c.validateIndex("index", index); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/tx/%s/%d/confirmed/spent", txHash, index)
 	return requestAndUnmarshal[SpentOutput](ctx, c, url, http.MethodGet, nil, ErrTransactionNotFound)
 }
@@ -426,6 +406,13 @@ func (c *Client) GetConfirmedSpentOutput(ctx context.Context, txHash string, ind
 //
 // For more information: https://docs.whatsonchain.com/#get-spent-output
 func (c *Client) GetSpentOutput(ctx context.Context, txHash string, index int) (*SpentOutput, error) {
+	if err := c.validateHash("txid", txHash); err != nil {
+		return nil, err
+	}
+	if err := c.validateIndex("index", index); err != nil {
+		return nil, err
+	}
+
 	url := c.buildURL("/tx/%s/%d/spent", txHash, index)
 	return requestAndUnmarshal[SpentOutput](ctx, c, url, http.MethodGet, nil, ErrTransactionNotFound)
 }
@@ -441,6 +428,9 @@ func (c *Client) BulkSpentOutputs(ctx context.Context, request *BulkSpentOutputR
 	if len(request.UTXOs) > MaxTransactionsUTXO {
 		return nil, fmt.Errorf("%w: %d UTXOs requested, max is %d", ErrMaxUTXOsExceeded, len(request.UTXOs), MaxTransactionsUTXO)
 	}
+	if err := c.validateUTXOs(request.UTXOs); err != nil {
+		return nil, err
+	}
 
 	postData, err := json.Marshal(request)
 	if err != nil {
@@ -448,5 +438,20 @@ func (c *Client) BulkSpentOutputs(ctx context.Context, request *BulkSpentOutputR
 	}
 
 	url := c.buildURL("/utxos/spent")
-	return requestAndUnmarshalSlice[BulkSpentOutputResult](ctx, c, url, http.MethodPost, postData, nil)
+	return requestAndUnmarshalSlice[BulkSpentOutputResult](ctx, c, url, http.MethodPost, postData, ErrTransactionNotFound)
+}
+
+// BulkSpentOutputsProcessor retrieves spent output details for any number of UTXOs in batches of 20
+// See: BulkSpentOutputs()
+func (c *Client) BulkSpentOutputsProcessor(ctx context.Context, request *BulkSpentOutputRequest) (BulkSpentOutputResponse, error) {
+	if request == nil {
+		return nil, ErrMissingRequest
+	}
+	if err := c.validateUTXOs(request.UTXOs); err != nil {
+		return nil, err
+	}
+	return bulkFanOut(ctx, c.bulkRun("BulkSpentOutputs"), request.UTXOs, MaxTransactionsUTXO,
+		func(ctx context.Context, batch []BulkSpentUTXO) (BulkSpentOutputResponse, error) {
+			return c.BulkSpentOutputs(ctx, &BulkSpentOutputRequest{UTXOs: batch})
+		})
 }
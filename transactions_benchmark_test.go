@@ -15,7 +15,7 @@ func (m *mockHTTPTransactionsBenchmark) Do(req *http.Request) (*http.Response, e
 	resp := new(http.Response)
 	resp.StatusCode = http.StatusOK
 
-	txResponse := `{"txid":"293cd46be8e436099e183a5bc145e19e7bb1ccc7a7f9c0bc842b3c6a992c7946","hash":"293cd46be8e436099e183a5bc145e19e7bb1ccc7a7f9c0bc842b3c6a992c7946","version":1,"size":225,"locktime":0,"vin":[{"coinbase":"03c2c70e","txid":"0000000000000000000000000000000000000000000000000000000000000000","vout":4294967295,"scriptSig":{"asm":"03c2c70e","hex":"03c2c70e"},"sequence":4294967295}],"vout":[{"value":50.00000000,"n":0,"scriptPubKey":{"asm":"OP_DUP OP_HASH160 a94a8f3b09b432f1e5c2c1c8b0b4d7e0b6e3e2f7 OP_EQUALVERIFY OP_CHECKSIG","hex":"76a914a94a8f3b09b432f1e5c2c1c8b0b4d7e0b6e3e2f788ac","reqSigs":1,"type":"pubkeyhash","addresses":["1GSEjCJaEzPKrJWWqhPVPuUGpfzU2c2tz1"]}}],"blockhash":"00000000000000000373d17e4f4f8e0f0f3f3e3d3c3b3a39383736353433323","confirmations":100,"time":1609459200,"blocktime":1609459200}`
+	txResponse := `{"txid":"293cd46be8e436099e183a5bc145e19e7bb1ccc7a7f9c0bc842b3c6a992c7946","hash":"293cd46be8e436099e183a5bc145e19e7bb1ccc7a7f9c0bc842b3c6a992c7946","version":1,"size":225,"locktime":0,"vin":[{"coinbase":"03c2c70e","txid":"0000000000000000000000000000000000000000000000000000000000000000","vout":4294967295,"scriptSig":{"asm":"03c2c70e","hex":"03c2c70e"},"sequence":4294967295}],"vout":[{"value":50.00000000,"n":0,"scriptPubKey":{"asm":"OP_DUP OP_HASH160 a94a8f3b09b432f1e5c2c1c8b0b4d7e0b6e3e2f7 OP_EQUALVERIFY OP_CHECKSIG","hex":"76a914a94a8f3b09b432f1e5c2c1c8b0b4d7e0b6e3e2f788ac","reqSigs":1,"type":"pubkeyhash","addresses":["1GSEjCJaEzPKrJWWqhPVPuUGpfzU2c2tz1"]}}],"blockhash":"000000000000000000373d17e4f4f8e0f0f3f3e3d3c3b3a39383736353433323","confirmations":100,"time":1609459200,"blocktime":1609459200}`
 
 	// Single transaction
 	if strings.Contains(req.URL.String(), "/tx/hash/") {
@@ -56,7 +56,7 @@ func (m *mockHTTPTransactionsBenchmark) Do(req *http.Request) (*http.Response, e
 
 	// Merkle proof
 	if strings.Contains(req.URL.String(), "/proof") {
-		resp.Body = io.NopCloser(strings.NewReader(`[{"blockHash":"00000000000000000373d17e4f4f8e0f0f3f3e3d3c3b3a39383736353433323","branches":[{"hash":"abc123","pos":"R"}],"hash":"293cd46be8e436099e183a5bc145e19e7bb1ccc7a7f9c0bc842b3c6a992c7946","merkleRoot":"def456"}]`))
+		resp.Body = io.NopCloser(strings.NewReader(`[{"blockHash":"000000000000000000373d17e4f4f8e0f0f3f3e3d3c3b3a39383736353433323","branches":[{"hash":"abc123","pos":"R"}],"hash":"293cd46be8e436099e183a5bc145e19e7bb1ccc7a7f9c0bc842b3c6a992c7946","merkleRoot":"def456"}]`))
 		return resp, nil
 	}
 
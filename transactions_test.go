@@ -57,7 +57,7 @@ func (m *mockHTTPTransactions) Do(req *http.Request) (*http.Response, error) {
 		}
 
 		// Invalid
-		if len(bulkRawData.TxIDs) > 0 && strings.Contains(bulkRawData.TxIDs[0], testMockError) {
+		if len(bulkRawData.TxIDs) > 0 && strings.Contains(bulkRawData.TxIDs[0], testHashError) {
 			resp.StatusCode = http.StatusBadRequest
 			resp.Body = io.NopCloser(bytes.NewBufferString(""))
 			return resp, errTxUnknownError
@@ -80,14 +80,14 @@ func (m *mockHTTPTransactions) Do(req *http.Request) (*http.Response, error) {
 	}
 
 	// Invalid - return an error
-	if strings.Contains(req.URL.String(), "/tx/hash/error") {
+	if strings.Contains(req.URL.String(), "/tx/hash/"+testHashError) {
 		resp.StatusCode = http.StatusInternalServerError
 		resp.Body = io.NopCloser(bytes.NewBufferString(""))
 		return resp, errTxMissingRequest
 	}
 
 	// Not found
-	if strings.Contains(req.URL.String(), "/tx/hash/notFound") {
+	if strings.Contains(req.URL.String(), "/tx/hash/"+testHashNotFound) {
 		resp.StatusCode = http.StatusNotFound
 		resp.Body = io.NopCloser(bytes.NewBufferString(""))
 		return resp, nil
@@ -104,20 +104,20 @@ func (m *mockHTTPTransactions) Do(req *http.Request) (*http.Response, error) {
 	}
 
 	// Invalid - invalid length
-	if strings.Contains(req.URL.String(), "/tx/error/proof") {
+	if strings.Contains(req.URL.String(), "/tx/"+testHashError+"/proof") {
 		resp.Body = io.NopCloser(bytes.NewBufferString(`txid must be 64 hex characters in length`))
 		return resp, errTxInvalidLength
 	}
 
 	// Not found
-	if strings.Contains(req.URL.String(), "/tx/notFound/proof") {
+	if strings.Contains(req.URL.String(), "/tx/"+testHashNotFound+"/proof") {
 		resp.StatusCode = http.StatusNotFound
 		resp.Body = io.NopCloser(bytes.NewBufferString(""))
 		return resp, nil
 	}
 
 	// Invalid - tx is not valid
-	if strings.Contains(req.URL.String(), "/tx/c1d32f28baa27a376ba977f6a8de6ce0a87041157cef0274b20bfda2b0d8dfzz/proof") {
+	if strings.Contains(req.URL.String(), "/tx/"+testHashInvalid+"/proof") {
 		resp.StatusCode = http.StatusOK
 		resp.Body = io.NopCloser(bytes.NewBufferString(`null`))
 	}
@@ -133,20 +133,20 @@ func (m *mockHTTPTransactions) Do(req *http.Request) (*http.Response, error) {
 	}
 
 	// Invalid - invalid length
-	if strings.Contains(req.URL.String(), "/tx/error/proof/tsc") {
+	if strings.Contains(req.URL.String(), "/tx/"+testHashError+"/proof/tsc") {
 		resp.Body = io.NopCloser(bytes.NewBufferString(`txid must be 64 hex characters in length`))
 		return resp, errTxInvalidLength
 	}
 
 	// Not found
-	if strings.Contains(req.URL.String(), "/tx/notFound/proof/tsc") {
+	if strings.Contains(req.URL.String(), "/tx/"+testHashNotFound+"/proof/tsc") {
 		resp.StatusCode = http.StatusNotFound
 		resp.Body = io.NopCloser(bytes.NewBufferString(""))
 		return resp, nil
 	}
 
 	// Invalid - tx is not valid
-	if strings.Contains(req.URL.String(), "/tx/c1d32f28baa27a376ba977f6a8de6ce0a87041157cef0274b20bfda2b0d8dfzz/proof") {
+	if strings.Contains(req.URL.String(), "/tx/"+testHashInvalid+"/proof") {
 		resp.StatusCode = http.StatusOK
 		resp.Body = io.NopCloser(bytes.NewBufferString(`null`))
 	}
@@ -162,19 +162,19 @@ func (m *mockHTTPTransactions) Do(req *http.Request) (*http.Response, error) {
 	}
 
 	// Invalid - invalid length
-	if strings.Contains(req.URL.String(), "/tx/error/hex") {
+	if strings.Contains(req.URL.String(), "/tx/"+testHashError+"/hex") {
 		resp.Body = io.NopCloser(bytes.NewBufferString(`txid must be 64 hex characters in length`))
 		return resp, errTxInvalidLength
 	}
 
 	// Invalid - tx is not valid
-	if strings.Contains(req.URL.String(), "/tx/c1d32f28baa27a376ba977f6a8de6ce0a87041157cef0274b20bfda2b0d8dfzz/hex") {
+	if strings.Contains(req.URL.String(), "/tx/"+testHashInvalid+"/hex") {
 		resp.StatusCode = http.StatusNotFound
 		resp.Body = io.NopCloser(bytes.NewBufferString(""))
 	}
 
 	// Not found
-	if strings.Contains(req.URL.String(), "/tx/notFound/hex") {
+	if strings.Contains(req.URL.String(), "/tx/"+testHashNotFound+"/hex") {
 		resp.StatusCode = http.StatusNotFound
 		resp.Body = io.NopCloser(bytes.NewBufferString(""))
 		return resp, nil
@@ -191,13 +191,13 @@ func (m *mockHTTPTransactions) Do(req *http.Request) (*http.Response, error) {
 	}
 
 	// Invalid - invalid length
-	if strings.Contains(req.URL.String(), "/tx/error/out/0/hex") {
+	if strings.Contains(req.URL.String(), "/tx/"+testHashError+"/out/0/hex") {
 		resp.Body = io.NopCloser(bytes.NewBufferString(`txid must be 64 hex characters in length`))
 		return resp, errTxInvalidLength
 	}
 
 	// Invalid - tx is not valid
-	if strings.Contains(req.URL.String(), "/tx/c1d32f28baa27a376ba977f6a8de6ce0a87041157cef0274b20bfda2b0d8dfzz/out/0/hex") {
+	if strings.Contains(req.URL.String(), "/tx/"+testHashInvalid+"/out/0/hex") {
 		resp.StatusCode = http.StatusBadGateway
 		resp.Body = io.NopCloser(bytes.NewBufferString(""))
 	}
@@ -207,7 +207,7 @@ func (m *mockHTTPTransactions) Do(req *http.Request) (*http.Response, error) {
 	//
 
 	var data TxHashes
-	if strings.Contains(req.URL.String(), "/test/txs") {
+	if strings.Contains(req.URL.String(), "/main/txs") {
 
 		decoder := json.NewDecoder(req.Body)
 		err := decoder.Decode(&data)
@@ -222,13 +222,13 @@ func (m *mockHTTPTransactions) Do(req *http.Request) (*http.Response, error) {
 		}
 
 		// Valid (1 bad tx, 1 good)
-		if strings.Contains(data.TxIDs[0], "294cd1ebd5689fdee03509f92c32184c0f52f037d4046af250229b97e0c8f1ZZ") {
+		if strings.Contains(data.TxIDs[0], "294cd1ebd5689fdee03509f92c32184c0f52f037d4046af250229b97e0c8f1ee") {
 			resp.StatusCode = http.StatusOK
 			resp.Body = io.NopCloser(bytes.NewBufferString(`[{"hex":"","txid":"91f68c2c598bc73812dd32d60ab67005eac498bef5f0c45b822b3c9468ba3258","hash":"91f68c2c598bc73812dd32d60ab67005eac498bef5f0c45b822b3c9468ba3258","version":1,"size":118,"locktime":0,"vin":[{"coinbase":"033f6b092f636f696e6765656b2e636f6d2f7759319af9d4f815e3a2fae5e60000","txid":"","vout":0,"scriptSig":{"asm":"","hex":""},"sequence":4294967295}],"vout":[{"value":12.5133703,"n":0,"scriptPubKey":{"asm":"OP_DUP OP_HASH160 8460e9a972a8600766a1b38fac4a2cfb8692d3ad OP_EQUALVERIFY OP_CHECKSIG","hex":"76a9148460e9a972a8600766a1b38fac4a2cfb8692d3ad88ac","reqSigs":1,"type":"pubkeyhash","addresses":["1D4xHwLxA8E9vU87N1ELHtPEZdKeLhywY1"],"opReturn":null,"isTruncated":false}}],"blockhash":"000000000000000002e8d4b4c0385abd195709c82f16d9917f081b70000e8804","confirmations":23367,"time":1578837295,"blocktime":1578837295}]`))
 		}
 
 		// Invalid (two bad txs)
-		if strings.Contains(data.TxIDs[0], testTxIDInvalid) {
+		if strings.Contains(data.TxIDs[0], testTxIDUnknown) {
 			resp.StatusCode = http.StatusOK
 			resp.Body = io.NopCloser(bytes.NewBufferString(`[]`))
 		}
@@ -240,14 +240,14 @@ func (m *mockHTTPTransactions) Do(req *http.Request) (*http.Response, error) {
 		}
 
 		// Invalid - force an error
-		if strings.Contains(data.TxIDs[0], testMockError) {
+		if strings.Contains(data.TxIDs[0], testHashError) {
 			resp.StatusCode = http.StatusBadRequest
 			resp.Body = io.NopCloser(bytes.NewBufferString(""))
 			return resp, errTxUnknownError
 		}
 
 		// Not found
-		if strings.Contains(data.TxIDs[0], testMockNotFound) {
+		if strings.Contains(data.TxIDs[0], testHashNotFound) {
 			resp.StatusCode = http.StatusNotFound
 			resp.Body = io.NopCloser(bytes.NewBufferString(""))
 			return resp, nil
@@ -273,7 +273,7 @@ endobj
 	}
 
 	// Invalid (download receipt) (invalid address)
-	if strings.Contains(req.URL.String(), "/receipt/invalid") {
+	if strings.Contains(req.URL.String(), "/receipt/"+testHashInvalid) {
 		resp.StatusCode = http.StatusGatewayTimeout
 		resp.Body = io.NopCloser(bytes.NewBufferString(""))
 		return resp, errTxGatewayTimeout
@@ -302,9 +302,9 @@ endobj
 	}
 
 	// Not found spent output
-	if strings.Contains(req.URL.String(), "/tx/notFound/0/spent") ||
-		strings.Contains(req.URL.String(), "/tx/notFound/0/unconfirmed/spent") ||
-		strings.Contains(req.URL.String(), "/tx/notFound/0/confirmed/spent") {
+	if strings.Contains(req.URL.String(), "/tx/"+testHashNotFound+"/0/spent") ||
+		strings.Contains(req.URL.String(), "/tx/"+testHashNotFound+"/0/unconfirmed/spent") ||
+		strings.Contains(req.URL.String(), "/tx/"+testHashNotFound+"/0/confirmed/spent") {
 		resp.StatusCode = http.StatusNotFound
 		resp.Body = io.NopCloser(bytes.NewBufferString(""))
 		return resp, nil
@@ -421,7 +421,7 @@ func (m *mockHTTPBroadcast) Do(req *http.Request) (*http.Response, error) {
 	}
 
 	// Not found
-	if strings.Contains(data.TxHex, testMockNotFound) {
+	if strings.Contains(data.TxHex, testHashNotFound) {
 		resp.StatusCode = http.StatusNotFound
 		resp.Body = io.NopCloser(bytes.NewBufferString(""))
 		return resp, nil
@@ -468,7 +468,7 @@ func (m *mockHTTPBroadcastBulk) Do(req *http.Request) (*http.Response, error) {
 	}
 
 	// Invalid - error
-	if strings.Contains(data[0], testMockError) {
+	if strings.Contains(data[0], testHashError) {
 		resp.Body = io.NopCloser(bytes.NewBufferString(`unknown error`))
 		return resp, errTxUnknownError
 	}
@@ -493,8 +493,8 @@ func TestClient_GetTxByHash(t *testing.T) {
 		statusCode    int
 	}{
 		{testTxID1, testTxID1, false, http.StatusOK},
-		{testMockError, "", true, http.StatusInternalServerError},
-		{testMockNotFound, "", true, http.StatusNotFound},
+		{testHashError, "", true, http.StatusInternalServerError},
+		{testHashNotFound, "", true, http.StatusNotFound},
 	}
 
 	// Test all
@@ -529,9 +529,9 @@ func TestClient_GetMerkleProof(t *testing.T) {
 		statusCode    int
 	}{
 		{testTxID1, "0000000000000000091216c46973d82db057a6f9911352892b7769ed517681c3", "95a920b1002bed05379a0d2650bb13eb216138f28ee80172f4cf21048528dc60", false, http.StatusOK},
-		{testMockError, "", "", true, http.StatusBadRequest},
-		{testMockNotFound, "", "", true, http.StatusNotFound},
-		{"c1d32f28baa27a376ba977f6a8de6ce0a87041157cef0274b20bfda2b0d8dfzz", "", "", false, http.StatusOK},
+		{testHashError, "", "", true, http.StatusBadRequest},
+		{testHashNotFound, "", "", true, http.StatusNotFound},
+		{testHashInvalid, "", "", false, http.StatusOK},
 	}
 
 	// Test all
@@ -568,9 +568,9 @@ func TestClient_GetMerkleProofTSC(t *testing.T) {
 		statusCode    int
 	}{
 		{0, testTxID1, "0000000000000000091216c46973d82db057a6f9911352892b7769ed517681c3", []string{"7e0ba1980522125f1f40d19a249ab3ae036001b991776813d25aebe08e8b8a50", "1e3a5a8946e0caf07006f6c4f76773d7e474d4f240a276844f866bd09820adb3"}, false, http.StatusOK},
-		{0, testMockError, "", []string{}, true, http.StatusBadRequest},
-		{0, testMockNotFound, "", []string{}, true, http.StatusNotFound},
-		{0, "c1d32f28baa27a376ba977f6a8de6ce0a87041157cef0274b20bfda2b0d8dfzz", "", []string{}, false, http.StatusOK},
+		{0, testHashError, "", []string{}, true, http.StatusBadRequest},
+		{0, testHashNotFound, "", []string{}, true, http.StatusNotFound},
+		{0, testHashInvalid, "", []string{}, false, http.StatusOK},
 	}
 
 	// Test all
@@ -622,9 +622,9 @@ func TestClient_GetRawTransactionData(t *testing.T) {
 		statusCode    int
 	}{
 		{testTxID1, "01000000010000000000000000000000000000000000000000000000000000000000000000ffffffff1c03d7c6082f7376706f6f6c2e636f6d2f3edff034600055b8467f0040ffffffff01247e814a000000001976a914492558fb8ca71a3591316d095afc0f20ef7d42f788ac00000000", false, http.StatusOK},
-		{"c1d32f28baa27a376ba977f6a8de6ce0a87041157cef0274b20bfda2b0d8dfzz", "", false, http.StatusNotFound},
-		{testMockError, "", true, http.StatusBadRequest},
-		{testMockNotFound, "", false, http.StatusNotFound},
+		{testHashInvalid, "", true, http.StatusNotFound},
+		{testHashError, "", true, http.StatusBadRequest},
+		{testHashNotFound, "", true, http.StatusNotFound},
 	}
 
 	// Test all
@@ -657,8 +657,8 @@ func TestClient_GetRawTransactionOutputData(t *testing.T) {
 		statusCode    int
 	}{
 		{testTxID1, "76a914492558fb8ca71a3591316d095afc0f20ef7d42f788ac", false, http.StatusOK},
-		{"c1d32f28baa27a376ba977f6a8de6ce0a87041157cef0274b20bfda2b0d8dfzz", "", true, http.StatusBadGateway},
-		{testMockError, "", true, http.StatusBadRequest},
+		{testHashInvalid, "", true, http.StatusBadGateway},
+		{testHashError, "", true, http.StatusBadRequest},
 	}
 
 	// Test all
@@ -692,11 +692,11 @@ func TestClient_BulkTransactionDetails(t *testing.T) {
 		statusCode    int
 	}{
 		{&TxHashes{TxIDs: []string{"294cd1ebd5689fdee03509f92c32184c0f52f037d4046af250229b97e0c8f1aa", testTxID2}}, "294cd1ebd5689fdee03509f92c32184c0f52f037d4046af250229b97e0c8f1aa", testTxID2, false, http.StatusOK},
-		{&TxHashes{TxIDs: []string{"294cd1ebd5689fdee03509f92c32184c0f52f037d4046af250229b97e0c8f1ZZ", testTxID2}}, testTxID2, "", false, http.StatusOK},
-		{&TxHashes{TxIDs: []string{testTxIDInvalid, testTxID2Invalid}}, "", "", false, http.StatusOK},
-		{&TxHashes{TxIDs: []string{testTxIDInvalid, testTxID2Invalid, testTxIDInvalid, testTxID2Invalid, testTxIDInvalid, testTxID2Invalid, testTxIDInvalid, testTxID2Invalid, testTxIDInvalid, testTxID2Invalid, testTxIDInvalid, testTxID2Invalid, testTxIDInvalid, testTxID2Invalid, testTxIDInvalid, testTxID2Invalid, testTxIDInvalid, testTxID2Invalid, testTxIDInvalid, testTxID2Invalid, testTxIDInvalid, testTxID2Invalid}}, "", "", true, http.StatusOK},
-		{&TxHashes{TxIDs: []string{testMockError}}, "", "", true, http.StatusBadRequest},
-		{&TxHashes{TxIDs: []string{testMockNotFound}}, "", "", false, http.StatusNotFound},
+		{&TxHashes{TxIDs: []string{"294cd1ebd5689fdee03509f92c32184c0f52f037d4046af250229b97e0c8f1ee", testTxID2}}, testTxID2, "", false, http.StatusOK},
+		{&TxHashes{TxIDs: []string{testTxIDUnknown, testTxID2Unknown}}, "", "", false, http.StatusOK},
+		{&TxHashes{TxIDs: []string{testTxIDUnknown, testTxID2Unknown, testTxIDUnknown, testTxID2Unknown, testTxIDUnknown, testTxID2Unknown, testTxIDUnknown, testTxID2Unknown, testTxIDUnknown, testTxID2Unknown, testTxIDUnknown, testTxID2Unknown, testTxIDUnknown, testTxID2Unknown, testTxIDUnknown, testTxID2Unknown, testTxIDUnknown, testTxID2Unknown, testTxIDUnknown, testTxID2Unknown, testTxIDUnknown, testTxID2Unknown}}, "", "", true, http.StatusOK},
+		{&TxHashes{TxIDs: []string{testHashError}}, "", "", true, http.StatusBadRequest},
+		{&TxHashes{TxIDs: []string{testHashNotFound}}, "", "", true, http.StatusNotFound},
 	}
 
 	// Test all
@@ -769,7 +769,7 @@ func TestClient_BulkBroadcastTx(t *testing.T) {
 	}{
 		{[]string{"020000000232900e9b0e359cb95ad3853e1450591fdf01e3efaa1b3b2b5ab5c5ef784946b8010000006a473044022055ec4f9b9cbdd97cf5f4893f921da75287483edb4ebba4f5b23231577212fd5f022007ed037ab7da039e0d4cf0fa35f620f2d7f71285959dcb6c885652d843d0038741210232b357c5309644cf4aa72b9b2d8bfe58bdf2515d40119318d5cb51ef378cae7efffffffff91d2feda79506806c5b0dc74b6fa6ae42fb7963da460ac256383fce498b9952020000006b483045022100ac75defcda55d644b6c095c2e7cbded92e38ea52e4d7f561f37962aa036a92fe0220059c0071d7c53cc964f641fa60ba2a076e3030ae6edc0f650792041c7691c66641210282d7e568e56f59e01a4edae297ac26caabc4684971ac6c7558c91c0fa84002f7ffffffff03322a093f000000001976a914c4263eb96d88849f498d139424b59a0cba1005e888ac2f92bb09000000001976a9146cbff9881ac47da8cb699e4543c28f9b3d6941da88ac404b4c00000000001976a914f7899faf1696892e6cb029b00c713f044761f03588ac00000000", "0100000006279fdaff3d61920bc0b017c7b7ed8a8944b1ed1cc1998efc18b71f4828dd3a02010000006a47304402205ba16dccd88461f11e0a705b1015ae84d543acc92cb25012c14578d14cedf677022036bfdd96c6bbe57488b288a1dd3c1c4208154066fb7f8f9dfe59fb6ef53ca01b41210215b9d8176b6697758859e95ff43e61dfea94d44340b16d6f6ac4ae6d61a37ab3ffffffff3efb67c48b0a387f769219ee5c7431eaa49e11a3bfb4e7969d91b0973bcc3a30010000006b483045022100d1a25279f2bb720848717b85c518018c3a26ac584dce2956aeaa4ead86e43b1002200c2e5193fcc2250cc08407b22e7ee73ba48c8755d2aa193b543d01ad97dff85941210306e0678608241a4dc0bde0a39ab3d29dfdef6624ab5a20aa0b821dea85c9d9d4ffffffff9da533cd621baa3d07aca98f07c03115db993bf685afad6a3f321dac61db1731010000006a473044022009769824e84a1b9756aa9450249c19604ffe1474875e9389e851c562dcf272e502206dec4452cdec705bb4066883a2b9cd571768df2017638143bdec4a053b37abcb41210243b052e875c67900cebb6bcf816c7ee510f1a7c1068b0625a1828f1ab82c3806ffffffff4e6647164ee0edc0c7617e82575a88eca16056efb3af297e32fd03767c4e353d010000006a473044022012949fd73deca106804968fd50d86cb89872758f5c492b0938fdb8cee28b30df0220168f88d65400c68c5df348d60c800b1f9fb7e3135af05addac049c940da36bf9412102645b8993f1a183e9d37ec2fd9c5f950110b404aea7e9f01687ab42eb6f8b3563ffffffff49236581eb2ada13ac5972e7211771539ebe06061ac6a27a58acde5521b80949010000006a4730440220621f6dff81bfc0ab6c2b9e321c920f477f4ee5e5a01e3d24aecbb265ad264a1202205e99ac2fe08f6cecfcc8ddc98de09d4693c7f81f03bb478d1ee5936919e00b0d4121039925d3b23e560bd021d665e56f912735fcbb96303051b037a6816a55697a226effffffff1b6196327f18bbe6af3fabd46a5d3af568cc7c4447a340f4949824b6762a98f2010000006b483045022100f679c4408b7d893a6bbfb042685053369de044d4d12c6ddcc678d223684f7a320220669bd27d9e9e2be82c0f653d95cd74a4e188a66b976c5be7ba7d3b113692547041210235157690d4fc237b4aceb9e6f91ae0f0058628df36e4450f173ffae2d7d0d49affffffff0253290000000000001976a914dc57ab8a8365a7263fad7491e9a36601a786772388ac37d60000000000001976a914594d5717bd8f9ae5ae8c56646042082d3d6995f988ac00000000"}, "", false, false, http.StatusOK},
 		{[]string{"0100000001d1bda0bde67183817b21af863adaa31fda8cafcf2083ca1eaba3054496cbde10010000006a47304402205fddd6abab6b8e94f36bfec51ba2e1f3a91b5327efa88264b5530d0c86538723022010e51693e3d52347d4d2ff142b85b460d3953e625d1e062a5fa2569623fb0ea94121029df3723daceb1fef64fa0558371bc48cc3a7a8e35d8e05b87137dc129a9d4598ffffffff0115d40000000000001976a91459cc95a8cde59ceda718dbf70e612dba4034552688ac00000000", "0100000006279fdaff3d61920bc0b017c7b7ed8a8944b1ed1cc1998efc18b71f4828dd3a02010000006a47304402205ba16dccd88461f11e0a705b1015ae84d543acc92cb25012c14578d14cedf677022036bfdd96c6bbe57488b288a1dd3c1c4208154066fb7f8f9dfe59fb6ef53ca01b41210215b9d8176b6697758859e95ff43e61dfea94d44340b16d6f6ac4ae6d61a37ab3ffffffff3efb67c48b0a387f769219ee5c7431eaa49e11a3bfb4e7969d91b0973bcc3a30010000006b483045022100d1a25279f2bb720848717b85c518018c3a26ac584dce2956aeaa4ead86e43b1002200c2e5193fcc2250cc08407b22e7ee73ba48c8755d2aa193b543d01ad97dff85941210306e0678608241a4dc0bde0a39ab3d29dfdef6624ab5a20aa0b821dea85c9d9d4ffffffff9da533cd621baa3d07aca98f07c03115db993bf685afad6a3f321dac61db1731010000006a473044022009769824e84a1b9756aa9450249c19604ffe1474875e9389e851c562dcf272e502206dec4452cdec705bb4066883a2b9cd571768df2017638143bdec4a053b37abcb41210243b052e875c67900cebb6bcf816c7ee510f1a7c1068b0625a1828f1ab82c3806ffffffff4e6647164ee0edc0c7617e82575a88eca16056efb3af297e32fd03767c4e353d010000006a473044022012949fd73deca106804968fd50d86cb89872758f5c492b0938fdb8cee28b30df0220168f88d65400c68c5df348d60c800b1f9fb7e3135af05addac049c940da36bf9412102645b8993f1a183e9d37ec2fd9c5f950110b404aea7e9f01687ab42eb6f8b3563ffffffff49236581eb2ada13ac5972e7211771539ebe06061ac6a27a58acde5521b80949010000006a4730440220621f6dff81bfc0ab6c2b9e321c920f477f4ee5e5a01e3d24aecbb265ad264a1202205e99ac2fe08f6cecfcc8ddc98de09d4693c7f81f03bb478d1ee5936919e00b0d4121039925d3b23e560bd021d665e56f912735fcbb96303051b037a6816a55697a226effffffff1b6196327f18bbe6af3fabd46a5d3af568cc7c4447a340f4949824b6762a98f2010000006b483045022100f679c4408b7d893a6bbfb042685053369de044d4d12c6ddcc678d223684f7a320220669bd27d9e9e2be82c0f653d95cd74a4e188a66b976c5be7ba7d3b113692547041210235157690d4fc237b4aceb9e6f91ae0f0058628df36e4450f173ffae2d7d0d49affffffff0253290000000000001976a914dc57ab8a8365a7263fad7491e9a36601a786772388ac37d60000000000001976a914594d5717bd8f9ae5ae8c56646042082d3d6995f988ac00000000"}, "https://api.whatsonchain.com/v1/bsv/tx/broadcast/cxF3xOdSvR_JgoXWDYZ0RQ", true, false, http.StatusOK},
-		{[]string{testMockError, "error2"}, "", true, true, http.StatusBadRequest},
+		{[]string{testHashError, "error2"}, "", true, true, http.StatusBadRequest},
 	}
 
 	// Test all
@@ -850,7 +850,7 @@ func TestClient_DecodeTransaction(t *testing.T) {
 	}{
 		{"010000000110784fd521b55a303da0f8b4ea113a2a3b5fa71565bab86c4257cff83ab4a1b9010000006a4730440220113a56d87122f28d6b60931498951f9709527a5c095ae852dc7b17d3d7915ef802206fd0b026d2e8dd30a39daaef17f503d985e2306c8244bb0e09a957bf1c9b530441210269a7785783c12405a1eaecb3088a3d830ed7e2de6ac527f42374a55a8cc5aeadffffffff0266ba0200000000001976a914021e4ac858f0ee6e0dfdf4438857f602a900698988acde905606000000001976a914022a8c1a18378885db9054676f17a27f4219045e88ac00000000", "cfcd9c342411592319442f705f9083938847e88f709096aa2cec15e6350b947e", false, http.StatusOK},
 		{"zzzz0784fd521b55a303da0f8b4ea113a2a3b5fa71565bab86c4257cff83ab4a1b9010000006a4730440220113a56d87122f28d6b60931498951f9709527a5c095ae852dc7b17d3d7915ef802206fd0b026d2e8dd30a39daaef17f503d985e2306c8244bb0e09a957bf1c9b530441210269a7785783c12405a1eaecb3088a3d830ed7e2de6ac527f42374a55a8cc5aeadffffffff0266ba0200000000001976a914021e4ac858f0ee6e0dfdf4438857f602a900698988acde905606000000001976a914022a8c1a18378885db9054676f17a27f4219045e88ac00000000", "", true, http.StatusBadRequest},
-		{testMockNotFound, "", true, http.StatusNotFound},
+		{testHashNotFound, "", true, http.StatusNotFound},
 	}
 
 	// Test all
@@ -883,7 +883,7 @@ func TestClient_DownloadReceipt(t *testing.T) {
 		statusCode    int
 	}{
 		{testTxID1, "PDF", false, http.StatusOK},
-		{"invalid", "invalid", true, http.StatusGatewayTimeout},
+		{testHashInvalid, "invalid", true, http.StatusGatewayTimeout},
 	}
 
 	// Test all
@@ -930,7 +930,7 @@ func TestClient_BulkTransactionDetailsProcessor(t *testing.T) {
 		{
 			"one real tx, one wrong",
 			&TxHashes{TxIDs: []string{
-				"294cd1ebd5689fdee03509f92c32184c0f52f037d4046af250229b97e0c8f1ZZ",
+				"294cd1ebd5689fdee03509f92c32184c0f52f037d4046af250229b97e0c8f1ee",
 				testTxID2,
 			}},
 			testTxID2,
@@ -941,8 +941,8 @@ func TestClient_BulkTransactionDetailsProcessor(t *testing.T) {
 		{
 			"both txs are not found",
 			&TxHashes{TxIDs: []string{
-				testTxIDInvalid,
-				testTxID2Invalid,
+				testTxIDUnknown,
+				testTxID2Unknown,
 			}},
 			"",
 			"",
@@ -952,28 +952,28 @@ func TestClient_BulkTransactionDetailsProcessor(t *testing.T) {
 		{
 			"using 20 transactions",
 			&TxHashes{TxIDs: []string{
-				testTxIDInvalid,
-				testTxID2Invalid,
-				testTxIDInvalid,
-				testTxID2Invalid,
-				testTxIDInvalid,
-				testTxID2Invalid,
-				testTxIDInvalid,
-				testTxID2Invalid,
-				testTxIDInvalid,
-				testTxID2Invalid,
-				testTxIDInvalid,
-				testTxID2Invalid,
-				testTxIDInvalid,
-				testTxID2Invalid,
-				testTxIDInvalid,
-				testTxID2Invalid,
-				testTxIDInvalid,
-				testTxID2Invalid,
-				testTxIDInvalid,
-				testTxID2Invalid,
-				testTxIDInvalid,
-				testTxID2Invalid,
+				testTxIDUnknown,
+				testTxID2Unknown,
+				testTxIDUnknown,
+				testTxID2Unknown,
+				testTxIDUnknown,
+				testTxID2Unknown,
+				testTxIDUnknown,
+				testTxID2Unknown,
+				testTxIDUnknown,
+				testTxID2Unknown,
+				testTxIDUnknown,
+				testTxID2Unknown,
+				testTxIDUnknown,
+				testTxID2Unknown,
+				testTxIDUnknown,
+				testTxID2Unknown,
+				testTxIDUnknown,
+				testTxID2Unknown,
+				testTxIDUnknown,
+				testTxID2Unknown,
+				testTxIDUnknown,
+				testTxID2Unknown,
 			}},
 			"",
 			"",
@@ -983,7 +983,7 @@ func TestClient_BulkTransactionDetailsProcessor(t *testing.T) {
 		{
 			"invalid tx",
 			&TxHashes{TxIDs: []string{
-				testMockError,
+				testHashError,
 			}},
 			"",
 			"",
@@ -1039,7 +1039,7 @@ func TestClient_BulkRawTransactionDataProcessor(t *testing.T) {
 		{
 			"one real tx, one wrong",
 			&TxHashes{TxIDs: []string{
-				"294cd1ebd5689fdee03509f92c32184c0f52f037d4046af250229b97e0c8f1ZZ",
+				"294cd1ebd5689fdee03509f92c32184c0f52f037d4046af250229b97e0c8f1ee",
 				testTxID2,
 			}},
 			testTxID2,
@@ -1050,8 +1050,8 @@ func TestClient_BulkRawTransactionDataProcessor(t *testing.T) {
 		{
 			"both txs are not found",
 			&TxHashes{TxIDs: []string{
-				testTxIDInvalid,
-				testTxID2Invalid,
+				testTxIDUnknown,
+				testTxID2Unknown,
 			}},
 			"",
 			"",
@@ -1061,28 +1061,28 @@ func TestClient_BulkRawTransactionDataProcessor(t *testing.T) {
 		{
 			"using 20 transactions",
 			&TxHashes{TxIDs: []string{
-				testTxIDInvalid,
-				testTxID2Invalid,
-				testTxIDInvalid,
-				testTxID2Invalid,
-				testTxIDInvalid,
-				testTxID2Invalid,
-				testTxIDInvalid,
-				testTxID2Invalid,
-				testTxIDInvalid,
-				testTxID2Invalid,
-				testTxIDInvalid,
-				testTxID2Invalid,
-				testTxIDInvalid,
-				testTxID2Invalid,
-				testTxIDInvalid,
-				testTxID2Invalid,
-				testTxIDInvalid,
-				testTxID2Invalid,
-				testTxIDInvalid,
-				testTxID2Invalid,
-				testTxIDInvalid,
-				testTxID2Invalid,
+				testTxIDUnknown,
+				testTxID2Unknown,
+				testTxIDUnknown,
+				testTxID2Unknown,
+				testTxIDUnknown,
+				testTxID2Unknown,
+				testTxIDUnknown,
+				testTxID2Unknown,
+				testTxIDUnknown,
+				testTxID2Unknown,
+				testTxIDUnknown,
+				testTxID2Unknown,
+				testTxIDUnknown,
+				testTxID2Unknown,
+				testTxIDUnknown,
+				testTxID2Unknown,
+				testTxIDUnknown,
+				testTxID2Unknown,
+				testTxIDUnknown,
+				testTxID2Unknown,
+				testTxIDUnknown,
+				testTxID2Unknown,
 			}},
 			"",
 			"",
@@ -1092,7 +1092,7 @@ func TestClient_BulkRawTransactionDataProcessor(t *testing.T) {
 		{
 			"invalid tx",
 			&TxHashes{TxIDs: []string{
-				testMockError,
+				testHashError,
 			}},
 			"",
 			"",
@@ -1132,7 +1132,7 @@ func (m *mockHTTPPropagationStatus) Do(req *http.Request) (*http.Response, error
 	}
 
 	// Valid
-	if strings.Contains(req.URL.String(), "propagation") && !strings.Contains(req.URL.String(), "invalid") {
+	if strings.Contains(req.URL.String(), "propagation") && !strings.Contains(req.URL.String(), testHashInvalid) {
 		resp.StatusCode = http.StatusOK
 		resp.Body = io.NopCloser(strings.NewReader(`{"txid":"c1d32f28baa27a376ba977f6a8de6ce0a87041157cef0274b20bfda2b0d8df96","propagation":[{"peer":"127.0.0.1","status":"accepted","timestamp":1640995200}]}`))
 	} else {
@@ -1159,7 +1159,7 @@ func TestClient_GetTransactionPropagationStatus(t *testing.T) {
 		statusCode    int
 	}{
 		{testTxID1, testTxID1, false, http.StatusOK},
-		{"invalid", "invalid", true, http.StatusBadRequest},
+		{testHashInvalid, "invalid", true, http.StatusBadRequest},
 	}
 
 	// Test all
@@ -1266,7 +1266,7 @@ func (m *mockHTTPTransactionBinary) Do(req *http.Request) (*http.Response, error
 	}
 
 	// Valid
-	if strings.Contains(req.URL.String(), "/bin") && !strings.Contains(req.URL.String(), "invalid") {
+	if strings.Contains(req.URL.String(), "/bin") && !strings.Contains(req.URL.String(), testHashInvalid) {
 		resp.StatusCode = http.StatusOK
 		resp.Body = io.NopCloser(strings.NewReader("binary_data_here"))
 	} else {
@@ -1293,7 +1293,7 @@ func TestClient_GetTransactionAsBinary(t *testing.T) {
 		statusCode    int
 	}{
 		{testTxID1, "binary_data_here", false, http.StatusOK},
-		{"invalid", "invalid", true, http.StatusBadRequest},
+		{testHashInvalid, "invalid", true, http.StatusBadRequest},
 	}
 
 	// Test all
@@ -1417,7 +1417,7 @@ func TestClient_BulkRawTransactionData(t *testing.T) {
 		ctx := context.Background()
 		var txIDs []string
 		for i := 0; i < 21; i++ {
-			txIDs = append(txIDs, "hash"+fmt.Sprintf("%d", i))
+			txIDs = append(txIDs, fmt.Sprintf("%064x", i))
 		}
 		txList, err := client.BulkRawTransactionData(ctx, &TxHashes{TxIDs: txIDs})
 		require.Error(t, err)
@@ -1428,7 +1428,7 @@ func TestClient_BulkRawTransactionData(t *testing.T) {
 		client := newMockClient(&mockHTTPTransactions{})
 		ctx := context.Background()
 		txList, err := client.BulkRawTransactionData(ctx, &TxHashes{TxIDs: []string{
-			testMockError,
+			testHashError,
 		}})
 		require.Error(t, err)
 		assert.Nil(t, txList)
@@ -1462,7 +1462,7 @@ func TestClient_GetUnconfirmedSpentOutput(t *testing.T) {
 		},
 		{
 			name:          "not found",
-			txHash:        testMockNotFound,
+			txHash:        testHashNotFound,
 			index:         0,
 			expectedTxID:  "",
 			expectedVin:   0,
@@ -1517,7 +1517,7 @@ func TestClient_GetConfirmedSpentOutput(t *testing.T) {
 		},
 		{
 			name:          "not found",
-			txHash:        testMockNotFound,
+			txHash:        testHashNotFound,
 			index:         0,
 			expectedTxID:  "",
 			expectedVin:   0,
@@ -1572,7 +1572,7 @@ func TestClient_GetSpentOutput(t *testing.T) {
 		},
 		{
 			name:          "not found",
-			txHash:        testMockNotFound,
+			txHash:        testHashNotFound,
 			index:         0,
 			expectedTxID:  "",
 			expectedVin:   0,
@@ -1631,7 +1631,7 @@ func TestClient_BulkSpentOutputs(t *testing.T) {
 		var utxos []BulkSpentUTXO
 		for i := 0; i < 21; i++ {
 			utxos = append(utxos, BulkSpentUTXO{
-				TxID: fmt.Sprintf("hash%d", i),
+				TxID: fmt.Sprintf("%064x", i),
 				Vout: i,
 			})
 		}
@@ -1647,7 +1647,7 @@ func TestClient_GetUnconfirmedSpentOutput_EmptyResponse(t *testing.T) {
 
 	client := newMockClient(&mockHTTPTransactionsNotFound{})
 	ctx := context.Background()
-	output, err := client.GetUnconfirmedSpentOutput(ctx, testMockNotFound, 0)
+	output, err := client.GetUnconfirmedSpentOutput(ctx, testHashNotFound, 0)
 	require.Error(t, err)
 	require.ErrorIs(t, err, ErrTransactionNotFound)
 	assert.Nil(t, output)
@@ -1659,7 +1659,7 @@ func TestClient_GetConfirmedSpentOutput_EmptyResponse(t *testing.T) {
 
 	client := newMockClient(&mockHTTPTransactionsNotFound{})
 	ctx := context.Background()
-	output, err := client.GetConfirmedSpentOutput(ctx, testMockNotFound, 0)
+	output, err := client.GetConfirmedSpentOutput(ctx, testHashNotFound, 0)
 	require.Error(t, err)
 	require.ErrorIs(t, err, ErrTransactionNotFound)
 	assert.Nil(t, output)
@@ -1671,7 +1671,7 @@ func TestClient_GetSpentOutput_EmptyResponse(t *testing.T) {
 
 	client := newMockClient(&mockHTTPTransactionsNotFound{})
 	ctx := context.Background()
-	output, err := client.GetSpentOutput(ctx, testMockNotFound, 0)
+	output, err := client.GetSpentOutput(ctx, testHashNotFound, 0)
 	require.Error(t, err)
 	require.ErrorIs(t, err, ErrTransactionNotFound)
 	assert.Nil(t, output)
@@ -1683,7 +1683,7 @@ func TestClient_GetTransactionPropagationStatus_EmptyResponse(t *testing.T) {
 
 	client := newMockClient(&mockHTTPTransactionsNotFound{})
 	ctx := context.Background()
-	status, err := client.GetTransactionPropagationStatus(ctx, testMockNotFound)
+	status, err := client.GetTransactionPropagationStatus(ctx, testHashNotFound)
 	require.Error(t, err)
 	require.ErrorIs(t, err, ErrTransactionNotFound)
 	assert.Nil(t, status)
@@ -1695,7 +1695,7 @@ func TestClient_GetTransactionAsBinary_EmptyResponse(t *testing.T) {
 
 	client := newMockClient(&mockHTTPTransactionsNotFound{})
 	ctx := context.Background()
-	binary, err := client.GetTransactionAsBinary(ctx, testMockNotFound)
+	binary, err := client.GetTransactionAsBinary(ctx, testHashNotFound)
 	require.Error(t, err)
 	require.ErrorIs(t, err, ErrTransactionNotFound)
 	assert.Nil(t, binary)
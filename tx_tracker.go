@@ -0,0 +1,367 @@
+package whatsonchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// TxTrackState is where a tracked transaction is in its life
+type TxTrackState string
+
+const (
+	// TxStatePending is a transaction not yet seen in the mempool or a block
+	TxStatePending TxTrackState = "pending"
+
+	// TxStateMempool is a transaction in the mempool
+	TxStateMempool TxTrackState = "mempool"
+
+	// TxStateConfirmed is a transaction in a block of the active chain, short of the target depth
+	TxStateConfirmed TxTrackState = "confirmed"
+
+	// TxStateFinal is a transaction at the target depth; it is no longer polled
+	TxStateFinal TxTrackState = "final"
+
+	// TxStateDropped is a transaction not found for DropAfter polls in a row; it is no longer polled
+	TxStateDropped TxTrackState = "dropped"
+)
+
+const (
+	// defaultTxTrackerDropAfter is the default TxTrackerOptions.DropAfter
+	defaultTxTrackerDropAfter = 3
+
+	// defaultTxTrackerInterval is the default TxTrackerOptions.Interval
+	defaultTxTrackerInterval = 30 * time.Second
+
+	// defaultTxTrackerTargetDepth is the default TxTrackerOptions.TargetDepth
+	defaultTxTrackerTargetDepth int64 = 6
+)
+
+// TrackedTx is a transaction followed by a TxTracker
+type TrackedTx struct {
+	AddedAt       time.Time    `json:"added_at"`
+	BlockHash     string       `json:"block_hash,omitempty"`   // the block it is in (confirmed and final)
+	BlockHeight   int64        `json:"block_height,omitempty"` // the height of the block (confirmed and final)
+	Confirmations int64        `json:"confirmations"`          // blocks from the active tip down to its block
+	Misses        int          `json:"misses"`                 // polls in a row the transaction was not found
+	State         TxTrackState `json:"state"`
+	TxID          string       `json:"txid"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+}
+
+// TxTrackerFunc is a TxTracker callback. It gets a copy of the transaction as stored after the change.
+type TxTrackerFunc func(tx *TrackedTx)
+
+// TxTrackerOptions are the options for NewTxTracker
+type TxTrackerOptions struct {
+	// DropAfter is how many polls in a row a transaction can be missing from the mempool and the
+	// chain before it is dropped (default 3)
+	DropAfter int
+
+	// Interval is the time between polls in Run (default 30s)
+	Interval time.Duration
+
+	// OnConfirmed is called when a transaction is first seen in a block, and again when it is
+	// mined into a new block after a reorg
+	OnConfirmed TxTrackerFunc
+
+	// OnDropped is called when a transaction is dropped (see DropAfter)
+	OnDropped TxTrackerFunc
+
+	// OnError is called by Run when a poll fails
+	OnError func(err error)
+
+	// OnFinal is called when a transaction reaches TargetDepth confirmations
+	OnFinal TxTrackerFunc
+
+	// OnMempool is called when a transaction is seen in the mempool (also after a reorg)
+	OnMempool TxTrackerFunc
+
+	// OnReorged is called when the block of a confirmed transaction is no longer in the active chain.
+	// The state is updated (and the other callbacks called) after it, in the same poll.
+	OnReorged TxTrackerFunc
+
+	// Store keeps the tracked transactions (default a new MemoryTxTrackerStore)
+	Store TxTrackerStore
+
+	// TargetDepth is how many confirmations make a transaction final (default 6)
+	TargetDepth int64
+}
+
+// TxTracker follows transactions from broadcast to a target number of confirmations.
+//
+// Each poll fetches the active tip with GetChainTips and the status of every transaction still
+// being followed with BulkTransactionStatusProcessor (batches of 20). The height of each confirmed
+// transaction is cross-checked against the hash of the active chain's block at that height
+// (GetBlockByHeight), so a transaction whose block is reorged out is reported even if it was
+// mined again at the same height. Final and dropped transactions stay in the store, but are no
+// longer polled; Untrack removes them.
+type TxTracker struct {
+	client      ClientInterface
+	dropAfter   int
+	interval    time.Duration
+	mu          sync.Mutex // serializes store changes between Track, Untrack and Poll
+	onConfirmed TxTrackerFunc
+	onDropped   TxTrackerFunc
+	onError     func(err error)
+	onFinal     TxTrackerFunc
+	onMempool   TxTrackerFunc
+	onReorged   TxTrackerFunc
+	pollMu      sync.Mutex // serializes Poll
+	store       TxTrackerStore
+	targetDepth int64
+}
+
+// NewTxTracker creates a transaction tracker polling through the client
+func NewTxTracker(client ClientInterface, opts *TxTrackerOptions) *TxTracker {
+	if opts == nil {
+		opts = &TxTrackerOptions{}
+	}
+	t := &TxTracker{
+		client:      client,
+		dropAfter:   opts.DropAfter,
+		interval:    opts.Interval,
+		onConfirmed: opts.OnConfirmed,
+		onDropped:   opts.OnDropped,
+		onError:     opts.OnError,
+		onFinal:     opts.OnFinal,
+		onMempool:   opts.OnMempool,
+		onReorged:   opts.OnReorged,
+		store:       opts.Store,
+		targetDepth: opts.TargetDepth,
+	}
+	if t.dropAfter <= 0 {
+		t.dropAfter = defaultTxTrackerDropAfter
+	}
+	if t.interval <= 0 {
+		t.interval = defaultTxTrackerInterval
+	}
+	if t.store == nil {
+		t.store = NewMemoryTxTrackerStore()
+	}
+	if t.targetDepth <= 0 {
+		t.targetDepth = defaultTxTrackerTargetDepth
+	}
+	return t
+}
+
+// Track starts following transactions (already tracked ones are left as they are).
+// It returns an *InvalidInputError, and tracks none, if a txid is not 64 hex characters.
+func (t *TxTracker) Track(txIDs ...string) error {
+	for i, txID := range txIDs {
+		if err := checkHash(fmt.Sprintf("txids[%d]", i), txID); err != nil {
+			return err
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now().UTC()
+	for _, txID := range txIDs {
+		if _, err := t.store.Get(txID); err == nil {
+			continue
+		} else if !errors.Is(err, ErrTransactionNotFound) {
+			return err
+		}
+		if err := t.store.Put(&TrackedTx{AddedAt: now, State: TxStatePending, TxID: txID, UpdatedAt: now}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Untrack stops following transactions and removes them from the store
+func (t *TxTracker) Untrack(txIDs ...string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, txID := range txIDs {
+		if err := t.store.Delete(txID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Tracked returns the tracked transactions, including final and dropped ones
+func (t *TxTracker) Tracked() ([]*TrackedTx, error) {
+	return t.store.List()
+}
+
+// Run polls every interval until the context is canceled, passing failed polls to OnError.
+// It returns the context's error.
+func (t *TxTracker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+	for {
+		if err := t.Poll(ctx); err != nil && ctx.Err() == nil && t.onError != nil {
+			t.onError(err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Poll checks every pending, mempool and confirmed transaction once, stores the changes and
+// calls the callbacks. Transactions whose status or block could not be fetched are left as they
+// are; the error reports those failures (a *BulkError for failed status batches).
+func (t *TxTracker) Poll(ctx context.Context) error {
+	t.pollMu.Lock()
+	defer t.pollMu.Unlock()
+
+	txs, err := t.store.List()
+	if err != nil {
+		return err
+	}
+	var txIDs []string
+	for _, tx := range txs {
+		if tx.State != TxStateFinal && tx.State != TxStateDropped {
+			txIDs = append(txIDs, tx.TxID)
+		}
+	}
+	if len(txIDs) == 0 {
+		return nil
+	}
+
+	tip, err := activeChainTip(ctx, t.client)
+	if err != nil {
+		return err
+	}
+
+	statuses, statusErr := t.client.BulkTransactionStatusProcessor(ctx, &TxHashes{TxIDs: txIDs})
+	var bulkErr *BulkError
+	if statusErr != nil && !errors.As(statusErr, &bulkErr) {
+		return statusErr
+	}
+	skip := make(map[string]bool) // in failed batches: no news is not "not found"
+	if bulkErr != nil {
+		for _, batch := range bulkErr.Batches {
+			for _, txID := range txIDs[batch.Offset : batch.Offset+batch.Count] {
+				skip[txID] = true
+			}
+		}
+	}
+	byTxID := make(map[string]*TxStatus, len(statuses))
+	for _, status := range statuses {
+		if status != nil {
+			byTxID[status.TxID] = status
+		}
+	}
+
+	errs := []error{statusErr}
+	blockHashes := make(map[int64]string)
+	blockHash := func(height int64) (string, error) {
+		if hash, ok := blockHashes[height]; ok {
+			return hash, nil
+		}
+		block, err := t.client.GetBlockByHeight(ctx, height)
+		if err != nil {
+			return "", fmt.Errorf("block at height %d: %w", height, err)
+		}
+		blockHashes[height] = block.Hash
+		return block.Hash, nil
+	}
+
+	var calls []func()
+	var updated []*TrackedTx
+	t.mu.Lock()
+	for _, txID := range txIDs {
+		if skip[txID] {
+			continue
+		}
+		tx, err := t.store.Get(txID)
+		if errors.Is(err, ErrTransactionNotFound) {
+			continue // untracked during the poll
+		} else if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		var hash string
+		status := byTxID[txID]
+		if status != nil && status.Valid && status.Height > 0 {
+			if hash, err = blockHash(status.Height); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+
+		changes, changed := t.update(tx, status, hash, tip.Height)
+		if !changed {
+			continue
+		}
+		updated = append(updated, tx)
+		for _, callback := range changes {
+			clone := *tx
+			calls = append(calls, func() { callback(&clone) })
+		}
+	}
+	if len(updated) > 0 {
+		if err = t.store.PutAll(updated); err != nil {
+			errs = append(errs, err)
+			calls = nil // the callbacks report stored changes
+		}
+	}
+	t.mu.Unlock()
+
+	// Called outside the lock, so callbacks can Track and Untrack
+	for _, call := range calls {
+		call()
+	}
+	return errors.Join(errs...)
+}
+
+// update applies a status to the transaction and returns the callbacks to call and whether
+// the record changed (UpdatedAt is only set then).
+// The status is nil (or not valid) when the transaction is not in the mempool or the chain,
+// and hash is the hash of the active chain's block at the status height.
+func (t *TxTracker) update(tx *TrackedTx, status *TxStatus, hash string, tipHeight int64) (changes []TxTrackerFunc, changed bool) {
+	add := func(callback TxTrackerFunc) {
+		if callback != nil {
+			changes = append(changes, callback)
+		}
+	}
+	before := *tx
+
+	found := status != nil && status.Valid
+	if tx.State == TxStateConfirmed && (!found || status.Height != tx.BlockHeight || hash != tx.BlockHash) {
+		tx.State, tx.BlockHash, tx.BlockHeight, tx.Confirmations = TxStatePending, "", 0, 0
+		add(t.onReorged)
+	}
+
+	switch {
+	case !found:
+		tx.Misses++
+		if tx.Misses >= t.dropAfter {
+			tx.State = TxStateDropped
+			add(t.onDropped)
+		}
+	case status.Height <= 0:
+		tx.Misses = 0
+		if tx.State != TxStateMempool {
+			tx.State = TxStateMempool
+			add(t.onMempool)
+		}
+	default:
+		tx.Misses = 0
+		if tx.State != TxStateConfirmed {
+			tx.State, tx.BlockHash, tx.BlockHeight = TxStateConfirmed, hash, status.Height
+			add(t.onConfirmed)
+		}
+		tx.Confirmations = max(tipHeight-tx.BlockHeight+1, 1)
+		if tx.Confirmations >= t.targetDepth {
+			tx.State = TxStateFinal
+			add(t.onFinal)
+		}
+	}
+
+	if changed = *tx != before; changed {
+		tx.UpdatedAt = time.Now().UTC()
+	}
+	return changes, changed
+}
@@ -0,0 +1,95 @@
+package whatsonchain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// TxTrackerStore is a pluggable store of the transactions a TxTracker follows, keyed by txid.
+//
+// The records a poll changes are written in one PutAll, so a persistent store lets tracking
+// resume after a restart. Implementations must be safe for concurrent use and must not keep
+// or hand out the caller's pointers.
+type TxTrackerStore interface {
+	// Delete removes the transaction; removing one that is not stored is not an error
+	Delete(txID string) error
+	// Get returns the transaction, or ErrTransactionNotFound
+	Get(txID string) (*TrackedTx, error)
+	// List returns every transaction, sorted by txid
+	List() ([]*TrackedTx, error)
+	// Put adds or replaces the transaction
+	Put(tx *TrackedTx) error
+	// PutAll adds or replaces the transactions in one write
+	PutAll(txs []*TrackedTx) error
+}
+
+// MemoryTxTrackerStore is an in-memory implementation of TxTrackerStore
+type MemoryTxTrackerStore struct {
+	mu  sync.RWMutex          // protects txs
+	txs map[string]*TrackedTx // tracked transactions by txid
+}
+
+// NewMemoryTxTrackerStore creates a new, empty in-memory tracker store
+func NewMemoryTxTrackerStore() *MemoryTxTrackerStore {
+	return &MemoryTxTrackerStore{txs: make(map[string]*TrackedTx)}
+}
+
+// Delete removes the transaction
+func (m *MemoryTxTrackerStore) Delete(txID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.txs, txID)
+	return nil
+}
+
+// Get returns the transaction, or ErrTransactionNotFound
+func (m *MemoryTxTrackerStore) Get(txID string) (*TrackedTx, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	tx, ok := m.txs[txID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not tracked", ErrTransactionNotFound, txID)
+	}
+	clone := *tx
+	return &clone, nil
+}
+
+// List returns every transaction, sorted by txid
+func (m *MemoryTxTrackerStore) List() ([]*TrackedTx, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	txs := make([]*TrackedTx, 0, len(m.txs))
+	for _, tx := range m.txs {
+		clone := *tx
+		txs = append(txs, &clone)
+	}
+	slices.SortFunc(txs, func(a, b *TrackedTx) int { return strings.Compare(a.TxID, b.TxID) })
+	return txs, nil
+}
+
+// Put adds or replaces the transaction
+func (m *MemoryTxTrackerStore) Put(tx *TrackedTx) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	clone := *tx
+	m.txs[tx.TxID] = &clone
+	return nil
+}
+
+// PutAll adds or replaces the transactions
+func (m *MemoryTxTrackerStore) PutAll(txs []*TrackedTx) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, tx := range txs {
+		clone := *tx
+		m.txs[tx.TxID] = &clone
+	}
+	return nil
+}
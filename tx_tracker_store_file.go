@@ -0,0 +1,150 @@
+package whatsonchain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileTxTrackerStore is a file-backed implementation of TxTrackerStore.
+//
+// The transactions are kept in a MemoryTxTrackerStore, which serves all reads, and every change
+// rewrites the file as a JSON array. The file is replaced atomically, so an interrupted write
+// leaves the previous contents.
+type FileTxTrackerStore struct {
+	memory *MemoryTxTrackerStore
+	mu     sync.Mutex // serializes writes to the file and memory
+	path   string
+}
+
+// NewFileTxTrackerStore opens (or creates) the tracker file at path and loads its transactions
+func NewFileTxTrackerStore(path string) (*FileTxTrackerStore, error) {
+	store := &FileTxTrackerStore{memory: NewMemoryTxTrackerStore(), path: path}
+	data, err := os.ReadFile(path) //nolint:gosec // G304: the path is chosen by the caller
+	if errors.Is(err, os.ErrNotExist) {
+		return store, store.save()
+	} else if err != nil {
+		return nil, err
+	}
+
+	var txs []*TrackedTx
+	if err = json.Unmarshal(data, &txs); err != nil {
+		return nil, fmt.Errorf("tracker file %s: %w", path, err)
+	}
+	for _, tx := range txs {
+		if err = store.memory.Put(tx); err != nil {
+			return nil, err
+		}
+	}
+	return store, nil
+}
+
+// Delete removes the transaction and rewrites the file
+func (f *FileTxTrackerStore) Delete(txID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	previous, err := f.memory.Get(txID)
+	if errors.Is(err, ErrTransactionNotFound) {
+		return nil
+	}
+	if err = f.memory.Delete(txID); err != nil {
+		return err
+	}
+	if err = f.save(); err != nil {
+		_ = f.memory.Put(previous)
+		return err
+	}
+	return nil
+}
+
+// Get returns the transaction, or ErrTransactionNotFound
+func (f *FileTxTrackerStore) Get(txID string) (*TrackedTx, error) {
+	return f.memory.Get(txID)
+}
+
+// List returns every transaction, sorted by txid
+func (f *FileTxTrackerStore) List() ([]*TrackedTx, error) {
+	return f.memory.List()
+}
+
+// Put adds or replaces the transaction and rewrites the file
+func (f *FileTxTrackerStore) Put(tx *TrackedTx) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	previous, _ := f.memory.Get(tx.TxID)
+	if err := f.memory.Put(tx); err != nil {
+		return err
+	}
+	if err := f.save(); err != nil {
+		if previous != nil {
+			_ = f.memory.Put(previous)
+		} else {
+			_ = f.memory.Delete(tx.TxID)
+		}
+		return err
+	}
+	return nil
+}
+
+// PutAll adds or replaces the transactions and rewrites the file once
+func (f *FileTxTrackerStore) PutAll(txs []*TrackedTx) error {
+	if len(txs) == 0 {
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	previous := make([]*TrackedTx, len(txs))
+	for i, tx := range txs {
+		previous[i], _ = f.memory.Get(tx.TxID)
+	}
+	if err := f.memory.PutAll(txs); err != nil {
+		return err
+	}
+	if err := f.save(); err != nil {
+		for i, tx := range txs {
+			if previous[i] != nil {
+				_ = f.memory.Put(previous[i])
+			} else {
+				_ = f.memory.Delete(tx.TxID)
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// save writes every transaction to a temporary file and renames it over the tracker file
+func (f *FileTxTrackerStore) save() error {
+	txs, err := f.memory.List()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(txs)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), f.path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+	return err
+}
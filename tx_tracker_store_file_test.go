@@ -0,0 +1,63 @@
+package whatsonchain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFileTxTrackerStore tests the file-backed tracker store
+func TestFileTxTrackerStore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("contract", func(t *testing.T) {
+		t.Parallel()
+		store, err := NewFileTxTrackerStore(filepath.Join(t.TempDir(), "txs.json"))
+		require.NoError(t, err)
+		testTxTrackerStore(t, store)
+	})
+
+	t.Run("reopen", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "txs.json")
+		store, err := NewFileTxTrackerStore(path)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(&TrackedTx{BlockHash: "abc", State: TxStateConfirmed, TxID: testTxID1}))
+		require.NoError(t, store.Put(&TrackedTx{State: TxStateMempool, TxID: testTxID2}))
+		require.NoError(t, store.Delete(testTxID2))
+
+		reopened, err := NewFileTxTrackerStore(path)
+		require.NoError(t, err)
+		txs, err := reopened.List()
+		require.NoError(t, err)
+		require.Len(t, txs, 1)
+		assert.Equal(t, testTxID1, txs[0].TxID)
+		assert.Equal(t, "abc", txs[0].BlockHash)
+
+		entries, err := os.ReadDir(filepath.Dir(path))
+		require.NoError(t, err)
+		assert.Len(t, entries, 1, "no temporary files left behind")
+	})
+
+	t.Run("new file", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "txs.json")
+		_, err := NewFileTxTrackerStore(path)
+		require.NoError(t, err)
+		data, err := os.ReadFile(path) //nolint:gosec // test file
+		require.NoError(t, err)
+		assert.JSONEq(t, `[]`, string(data))
+	})
+
+	t.Run("corrupt file", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "txs.json")
+		require.NoError(t, os.WriteFile(path, []byte("{not json"), 0o600))
+		_, err := NewFileTxTrackerStore(path)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), path)
+	})
+}
@@ -0,0 +1,64 @@
+package whatsonchain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testTxTrackerStore runs the TxTrackerStore contract against a store
+func testTxTrackerStore(t *testing.T, store TxTrackerStore) {
+	t.Helper()
+
+	_, err := store.Get(testTxID1)
+	require.ErrorIs(t, err, ErrTransactionNotFound)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	tx := &TrackedTx{AddedAt: now, State: TxStatePending, TxID: testTxID1, UpdatedAt: now}
+	require.NoError(t, store.Put(tx))
+	require.NoError(t, store.Put(&TrackedTx{State: TxStateMempool, TxID: testTxID2}))
+
+	tx.State = TxStateDropped // the store keeps its own copy
+	got, err := store.Get(testTxID1)
+	require.NoError(t, err)
+	assert.Equal(t, TxStatePending, got.State)
+	assert.True(t, now.Equal(got.AddedAt))
+
+	got.State = TxStateFinal
+	again, err := store.Get(testTxID1)
+	require.NoError(t, err)
+	assert.Equal(t, TxStatePending, again.State, "the caller gets a copy")
+
+	require.NoError(t, store.Put(&TrackedTx{BlockHeight: 100, State: TxStateConfirmed, TxID: testTxID1}))
+	txs, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	assert.Equal(t, testTxID2, txs[0].TxID, "sorted by txid")
+	assert.Equal(t, TxStateConfirmed, txs[1].State)
+	assert.Equal(t, int64(100), txs[1].BlockHeight)
+
+	batch := []*TrackedTx{{State: TxStateFinal, TxID: testTxID1}, {State: TxStateDropped, TxID: testTxID2}}
+	require.NoError(t, store.PutAll(batch))
+	batch[0].State = TxStatePending // the store keeps its own copies
+	txs, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	assert.Equal(t, TxStateDropped, txs[0].State)
+	assert.Equal(t, TxStateFinal, txs[1].State)
+	require.NoError(t, store.PutAll(nil))
+
+	require.NoError(t, store.Delete(testTxID2))
+	require.NoError(t, store.Delete(testTxID2), "deleting a missing transaction is not an error")
+	txs, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+}
+
+// TestMemoryTxTrackerStore tests the in-memory tracker store
+func TestMemoryTxTrackerStore(t *testing.T) {
+	t.Parallel()
+
+	testTxTrackerStore(t, NewMemoryTxTrackerStore())
+}
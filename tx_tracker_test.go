@@ -0,0 +1,388 @@
+package whatsonchain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockHTTPTxTracker answers the chain tips, block by height and bulk status endpoints from state
+// that tests change between polls
+type mockHTTPTxTracker struct {
+	blocks   map[int64]string // block hash by height
+	failTxs  bool             // answer the status requests with HTTP 500
+	heights  map[string]int64 // tx height by txid (0 = mempool, missing = unknown)
+	mu       sync.Mutex
+	tip      int64
+	tipCalls int
+}
+
+// newMockHTTPTxTracker returns a mockHTTPTxTracker at tip height 100
+func newMockHTTPTxTracker() *mockHTTPTxTracker {
+	return &mockHTTPTxTracker{blocks: make(map[int64]string), heights: make(map[string]int64), tip: 100}
+}
+
+// set changes the mock state
+func (m *mockHTTPTxTracker) set(fn func(m *mockHTTPTxTracker)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	fn(m)
+}
+
+// Do is a mock http request
+func (m *mockHTTPTxTracker) Do(req *http.Request) (*http.Response, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	respond := func(statusCode int, body any) (*http.Response, error) {
+		data, _ := json.Marshal(body)
+		return &http.Response{StatusCode: statusCode, Body: io.NopCloser(strings.NewReader(string(data)))}, nil
+	}
+
+	path := req.URL.Path
+	switch {
+	case strings.HasSuffix(path, "/chain/tips"):
+		m.tipCalls++
+		return respond(http.StatusOK, []*ChainTip{
+			{Height: m.tip, Hash: fmt.Sprintf("tip-%d", m.tip), Status: chainTipStatusActive},
+		})
+	case strings.Contains(path, "/block/height/"):
+		var height int64
+		_, _ = fmt.Sscanf(path[strings.LastIndex(path, "/")+1:], "%d", &height)
+		hash, ok := m.blocks[height]
+		if !ok {
+			hash = fmt.Sprintf("block-%d", height)
+		}
+		return respond(http.StatusOK, &BlockInfo{Hash: hash, Height: height})
+	case strings.HasSuffix(path, "/txs/status"):
+		if m.failTxs {
+			return respond(http.StatusInternalServerError, nil)
+		}
+		var hashes TxHashes
+		if err := json.NewDecoder(req.Body).Decode(&hashes); err != nil {
+			return nil, err
+		}
+		statuses := make(TxStatusList, 0, len(hashes.TxIDs))
+		for _, txID := range hashes.TxIDs {
+			height, ok := m.heights[txID]
+			statuses = append(statuses, &TxStatus{TxID: txID, Valid: ok, Height: height})
+		}
+		return respond(http.StatusOK, statuses)
+	}
+	return respond(http.StatusNotFound, nil)
+}
+
+// txTrackerRecorder records the callbacks of a TxTracker
+type txTrackerRecorder struct {
+	calls []string
+	mu    sync.Mutex
+}
+
+// options returns tracker options recording every callback
+func (r *txTrackerRecorder) options(opts TxTrackerOptions) *TxTrackerOptions {
+	record := func(name string) TxTrackerFunc {
+		return func(tx *TrackedTx) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.calls = append(r.calls, fmt.Sprintf("%s:%s", name, tx.TxID[:4]))
+		}
+	}
+	opts.OnConfirmed = record("confirmed")
+	opts.OnDropped = record("dropped")
+	opts.OnFinal = record("final")
+	opts.OnMempool = record("mempool")
+	opts.OnReorged = record("reorged")
+	return &opts
+}
+
+// take returns and clears the recorded calls
+func (r *txTrackerRecorder) take() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	calls := r.calls
+	r.calls = nil
+	return calls
+}
+
+// TestNewTxTracker tests the tracker defaults
+func TestNewTxTracker(t *testing.T) {
+	t.Parallel()
+
+	tracker := NewTxTracker(newCachedMockClient(newMockHTTPTxTracker()), nil)
+	assert.Equal(t, defaultTxTrackerDropAfter, tracker.dropAfter)
+	assert.Equal(t, defaultTxTrackerInterval, tracker.interval)
+	assert.Equal(t, defaultTxTrackerTargetDepth, tracker.targetDepth)
+	assert.IsType(t, &MemoryTxTrackerStore{}, tracker.store)
+}
+
+// TestTxTracker_Track tests tracking and untracking transactions
+func TestTxTracker_Track(t *testing.T) {
+	t.Parallel()
+
+	tracker := NewTxTracker(newCachedMockClient(newMockHTTPTxTracker()), nil)
+	require.NoError(t, tracker.Track(testTxID2, testTxID1))
+	requireInvalidInput(t, tracker.Track(testTxID1, testTxIDInvalid), "txids[1]")
+
+	txs, err := tracker.Tracked()
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	assert.Equal(t, testTxID2, txs[0].TxID)
+	assert.Equal(t, TxStatePending, txs[0].State)
+	assert.False(t, txs[0].AddedAt.IsZero())
+
+	require.NoError(t, tracker.Untrack(testTxID2))
+	txs, err = tracker.Tracked()
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	assert.Equal(t, testTxID1, txs[0].TxID)
+}
+
+// TestTxTracker_Poll tests a transaction from the mempool to the target depth
+func TestTxTracker_Poll(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := newMockHTTPTxTracker()
+	recorder := &txTrackerRecorder{}
+	tracker := NewTxTracker(newCachedMockClient(mock), recorder.options(TxTrackerOptions{TargetDepth: 3}))
+	require.NoError(t, tracker.Track(testTxID1))
+
+	t.Run("not seen yet", func(t *testing.T) {
+		require.NoError(t, tracker.Poll(ctx))
+		assert.Empty(t, recorder.take())
+	})
+
+	t.Run("mempool", func(t *testing.T) {
+		mock.set(func(m *mockHTTPTxTracker) { m.heights[testTxID1] = 0 })
+		require.NoError(t, tracker.Poll(ctx))
+		assert.Equal(t, []string{"mempool:" + testTxID1[:4]}, recorder.take())
+
+		require.NoError(t, tracker.Poll(ctx))
+		assert.Empty(t, recorder.take(), "no change")
+	})
+
+	t.Run("confirmed", func(t *testing.T) {
+		mock.set(func(m *mockHTTPTxTracker) {
+			m.tip = 101
+			m.heights[testTxID1] = 101
+		})
+		require.NoError(t, tracker.Poll(ctx))
+		assert.Equal(t, []string{"confirmed:" + testTxID1[:4]}, recorder.take())
+
+		tx, err := tracker.store.Get(testTxID1)
+		require.NoError(t, err)
+		assert.Equal(t, TxStateConfirmed, tx.State)
+		assert.Equal(t, "block-101", tx.BlockHash)
+		assert.Equal(t, int64(101), tx.BlockHeight)
+		assert.Equal(t, int64(1), tx.Confirmations)
+	})
+
+	t.Run("final", func(t *testing.T) {
+		mock.set(func(m *mockHTTPTxTracker) { m.tip = 103 })
+		require.NoError(t, tracker.Poll(ctx))
+		assert.Equal(t, []string{"final:" + testTxID1[:4]}, recorder.take())
+
+		tx, err := tracker.store.Get(testTxID1)
+		require.NoError(t, err)
+		assert.Equal(t, TxStateFinal, tx.State)
+		assert.Equal(t, int64(3), tx.Confirmations)
+	})
+
+	t.Run("final transactions are not polled", func(t *testing.T) {
+		mock.set(func(m *mockHTTPTxTracker) { m.tipCalls = 0 })
+		require.NoError(t, tracker.Poll(ctx))
+		assert.Empty(t, recorder.take())
+		assert.Zero(t, mock.tipCalls)
+	})
+}
+
+// countingTxTrackerStore is a MemoryTxTrackerStore counting its writes
+type countingTxTrackerStore struct {
+	*MemoryTxTrackerStore
+	mu     sync.Mutex
+	writes int
+}
+
+// Put counts the write
+func (c *countingTxTrackerStore) Put(tx *TrackedTx) error {
+	c.mu.Lock()
+	c.writes++
+	c.mu.Unlock()
+	return c.MemoryTxTrackerStore.Put(tx)
+}
+
+// PutAll counts the write
+func (c *countingTxTrackerStore) PutAll(txs []*TrackedTx) error {
+	c.mu.Lock()
+	c.writes++
+	c.mu.Unlock()
+	return c.MemoryTxTrackerStore.PutAll(txs)
+}
+
+// take returns and clears the write count
+func (c *countingTxTrackerStore) take() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	writes := c.writes
+	c.writes = 0
+	return writes
+}
+
+// TestTxTracker_PollWrites tests a poll writes its changes once and an idle poll writes nothing
+func TestTxTracker_PollWrites(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := newMockHTTPTxTracker()
+	store := &countingTxTrackerStore{MemoryTxTrackerStore: NewMemoryTxTrackerStore()}
+	tracker := NewTxTracker(newCachedMockClient(mock), &TxTrackerOptions{Store: store})
+	require.NoError(t, tracker.Track(testTxID1, testTxID2))
+	store.take()
+
+	mock.set(func(m *mockHTTPTxTracker) {
+		m.heights[testTxID1] = 0
+		m.heights[testTxID2] = 0
+	})
+	require.NoError(t, tracker.Poll(ctx))
+	assert.Equal(t, 1, store.take(), "both changes in one write")
+	before, err := tracker.Tracked()
+	require.NoError(t, err)
+
+	require.NoError(t, tracker.Poll(ctx))
+	assert.Zero(t, store.take(), "nothing changed")
+	after, err := tracker.Tracked()
+	require.NoError(t, err)
+	assert.Equal(t, before, after, "UpdatedAt is kept")
+
+	mock.set(func(m *mockHTTPTxTracker) { m.heights[testTxID1] = 100 })
+	require.NoError(t, tracker.Poll(ctx))
+	assert.Equal(t, 1, store.take())
+	after, err = tracker.Tracked()
+	require.NoError(t, err)
+	assert.Equal(t, before[0], after[0], "the unchanged transaction is kept") // testTxID2 sorts first
+	assert.Equal(t, TxStateConfirmed, after[1].State)
+	assert.False(t, after[1].UpdatedAt.Before(before[1].UpdatedAt))
+}
+
+// TestTxTracker_Reorg tests transactions whose block leaves the active chain
+func TestTxTracker_Reorg(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := newMockHTTPTxTracker()
+	mock.heights[testTxID1] = 100
+	mock.heights[testTxID2] = 100
+	recorder := &txTrackerRecorder{}
+	tracker := NewTxTracker(newCachedMockClient(mock), recorder.options(TxTrackerOptions{}))
+	require.NoError(t, tracker.Track(testTxID1, testTxID2))
+
+	require.NoError(t, tracker.Poll(ctx))
+	assert.Equal(t, []string{"confirmed:" + testTxID2[:4], "confirmed:" + testTxID1[:4]}, recorder.take())
+
+	// Block 100 is replaced: txid 1 is in the new block 100, txid 2 is back in the mempool
+	mock.set(func(m *mockHTTPTxTracker) {
+		m.blocks[100] = "block-100b"
+		m.heights[testTxID2] = 0
+	})
+	require.NoError(t, tracker.Poll(ctx))
+	assert.Equal(t, []string{
+		"reorged:" + testTxID2[:4], "mempool:" + testTxID2[:4],
+		"reorged:" + testTxID1[:4], "confirmed:" + testTxID1[:4],
+	}, recorder.take())
+
+	tx, err := tracker.store.Get(testTxID1)
+	require.NoError(t, err)
+	assert.Equal(t, "block-100b", tx.BlockHash)
+	tx, err = tracker.store.Get(testTxID2)
+	require.NoError(t, err)
+	assert.Equal(t, TxStateMempool, tx.State)
+	assert.Empty(t, tx.BlockHash)
+	assert.Zero(t, tx.Confirmations)
+}
+
+// TestTxTracker_Dropped tests transactions missing for DropAfter polls are dropped
+func TestTxTracker_Dropped(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := newMockHTTPTxTracker()
+	mock.heights[testTxID1] = 0
+	recorder := &txTrackerRecorder{}
+	tracker := NewTxTracker(newCachedMockClient(mock), recorder.options(TxTrackerOptions{DropAfter: 2}))
+	require.NoError(t, tracker.Track(testTxID1))
+
+	require.NoError(t, tracker.Poll(ctx))
+	assert.Equal(t, []string{"mempool:" + testTxID1[:4]}, recorder.take())
+
+	mock.set(func(m *mockHTTPTxTracker) { delete(m.heights, testTxID1) })
+	require.NoError(t, tracker.Poll(ctx))
+	assert.Empty(t, recorder.take())
+	require.NoError(t, tracker.Poll(ctx))
+	assert.Equal(t, []string{"dropped:" + testTxID1[:4]}, recorder.take())
+
+	tx, err := tracker.store.Get(testTxID1)
+	require.NoError(t, err)
+	assert.Equal(t, TxStateDropped, tx.State)
+	assert.Equal(t, 2, tx.Misses)
+}
+
+// TestTxTracker_PollFailure tests failed status batches leave transactions as they are
+func TestTxTracker_PollFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := newMockHTTPTxTracker()
+	mock.failTxs = true
+	tracker := NewTxTracker(newCachedMockClient(mock), &TxTrackerOptions{DropAfter: 1})
+	require.NoError(t, tracker.Track(testTxID1))
+
+	err := tracker.Poll(ctx)
+	var bulkErr *BulkError
+	require.ErrorAs(t, err, &bulkErr)
+
+	tx, err := tracker.store.Get(testTxID1)
+	require.NoError(t, err)
+	assert.Equal(t, TxStatePending, tx.State, "a failed batch is not a miss")
+	assert.Zero(t, tx.Misses)
+}
+
+// TestTxTracker_Run tests Run polls until the context is canceled
+func TestTxTracker_Run(t *testing.T) {
+	t.Parallel()
+
+	mock := newMockHTTPTxTracker()
+	mock.heights[testTxID1] = 0
+	confirmed := make(chan *TrackedTx, 1)
+	tracker := NewTxTracker(newCachedMockClient(mock), &TxTrackerOptions{
+		Interval:    10 * time.Millisecond,
+		OnConfirmed: func(tx *TrackedTx) { confirmed <- tx },
+	})
+	require.NoError(t, tracker.Track(testTxID1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- tracker.Run(ctx)
+	}()
+
+	mock.set(func(m *mockHTTPTxTracker) { m.heights[testTxID1] = 99 })
+	select {
+	case tx := <-confirmed:
+		assert.Equal(t, testTxID1, tx.TxID)
+		assert.Equal(t, int64(2), tx.Confirmations)
+	case <-time.After(time.Second):
+		require.Fail(t, "not confirmed")
+	}
+
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+}
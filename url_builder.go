@@ -8,15 +8,16 @@ import (
 // buildURL constructs a URL with the chain and network prefix
 // This centralizes URL construction to avoid repetition across all API methods
 func (c *Client) buildURL(path string, args ...any) string {
-	// Read both chain and network under a single lock to prevent
+	// Read the base URL, chain and network under a single lock to prevent
 	// mismatched values if SetChain/SetNetwork is called concurrently
 	c.optionsMu.RLock()
+	base := c.options.baseURL()
 	chain := c.options.chain
 	network := c.options.network
 	c.optionsMu.RUnlock()
 
-	// Build the base URL with chain and network
-	baseURL := fmt.Sprintf("%s%s/%s", apiEndpointBase, chain, network)
+	// Build the base URL with chain and network (failover rewrites the primary base URL, see endpointPool)
+	baseURL := fmt.Sprintf("%s%s/%s", base, chain, network)
 
 	// If args are provided, escape string arguments and format the path
 	if len(args) > 0 {
@@ -0,0 +1,272 @@
+package whatsonchain
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// InvalidInputError is returned, before any request is made, when an argument is malformed
+// (see WithInputValidation).
+//
+// It satisfies errors.Is(err, ErrInvalidInput) and can be inspected with errors.As.
+type InvalidInputError struct {
+	Field  string `json:"field"`  // the argument or request field, e.g. "txid" or "utxos[1].txid"
+	Reason string `json:"reason"` // why the value is invalid
+	Value  string `json:"value"`  // the offending value
+}
+
+// Error returns the error message, e.g. `invalid input: txid "abc": must be 64 hex characters`
+func (e *InvalidInputError) Error() string {
+	return fmt.Sprintf("%s: %s %q: %s", ErrInvalidInput, e.Field, e.Value, e.Reason)
+}
+
+// Unwrap returns ErrInvalidInput so errors.Is(err, ErrInvalidInput) is true
+func (e *InvalidInputError) Unwrap() error {
+	return ErrInvalidInput
+}
+
+// WithInputValidation turns the client-side checks of hashes, addresses and outpoints on or off
+// (on by default). When off, malformed values are sent to the API as-is.
+func WithInputValidation(enabled bool) ClientOption {
+	return func(c *clientOptions) {
+		c.skipInputValidation = !enabled
+	}
+}
+
+// validating returns true if input validation is on
+func (c *Client) validating() bool {
+	c.optionsMu.RLock()
+	defer c.optionsMu.RUnlock()
+	return !c.options.skipInputValidation
+}
+
+// validateHash checks a txid, block hash or script hash is 64 hex characters
+func (c *Client) validateHash(field, hash string) error {
+	if !c.validating() {
+		return nil
+	}
+	return checkHash(field, hash)
+}
+
+// validateHashes checks every hash in a list (see validateHash), naming the field by index
+func (c *Client) validateHashes(field string, hashes []string) error {
+	if !c.validating() {
+		return nil
+	}
+	for i, hash := range hashes {
+		if err := checkHash(fmt.Sprintf("%s[%d]", field, i), hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateBlockHashOrHeight checks a block hash (see validateHash) or a block height
+func (c *Client) validateBlockHashOrHeight(field, value string) error {
+	if !c.validating() {
+		return nil
+	}
+	if _, err := strconv.ParseUint(value, 10, 32); err == nil {
+		return nil
+	}
+	if err := checkHash(field, value); err != nil {
+		return &InvalidInputError{Field: field, Reason: "must be a block height or 64 hex characters", Value: value}
+	}
+	return nil
+}
+
+// validateIndex checks an output index is not negative
+func (c *Client) validateIndex(field string, index int) error {
+	if !c.validating() || index >= 0 {
+		return nil
+	}
+	return &InvalidInputError{Field: field, Reason: "must not be negative", Value: strconv.Itoa(index)}
+}
+
+// validateAddress checks an address is valid for the client's chain and network
+func (c *Client) validateAddress(field, address string) error {
+	if !c.validating() {
+		return nil
+	}
+	return checkAddress(field, address, c.Chain(), c.Network())
+}
+
+// validateAddresses checks every address in a list (see validateAddress), naming the field by index
+func (c *Client) validateAddresses(field string, addresses []string) error {
+	if !c.validating() {
+		return nil
+	}
+	chain, network := c.Chain(), c.Network()
+	for i, address := range addresses {
+		if err := checkAddress(fmt.Sprintf("%s[%d]", field, i), address, chain, network); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateOutpoint checks an outpoint is a txid and an output index separated by "_" or "."
+func (c *Client) validateOutpoint(field, outpoint string) error {
+	if !c.validating() {
+		return nil
+	}
+	txid, vout, ok := strings.Cut(outpoint, "_")
+	if !ok {
+		txid, vout, ok = strings.Cut(outpoint, ".")
+	}
+	if !ok {
+		return &InvalidInputError{Field: field, Reason: "must be txid_vout or txid.vout", Value: outpoint}
+	}
+	if checkHash(field, txid) != nil {
+		return &InvalidInputError{Field: field, Reason: "must start with a txid of 64 hex characters", Value: outpoint}
+	}
+	if _, err := strconv.ParseUint(vout, 10, 32); err != nil {
+		return &InvalidInputError{Field: field, Reason: "must end with an output index", Value: outpoint}
+	}
+	return nil
+}
+
+// validateOutputRequests checks the txids and output indexes of a BulkRawTransactionOutputData request
+func (c *Client) validateOutputRequests(txIDs []BulkRawOutputTxID) error {
+	for i, tx := range txIDs {
+		if err := c.validateHash(fmt.Sprintf("txids[%d].txid", i), tx.TxID); err != nil {
+			return err
+		}
+		for j, vout := range tx.Vouts {
+			if err := c.validateIndex(fmt.Sprintf("txids[%d].vouts[%d]", i, j), vout); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateUTXOs checks the txids and output indexes of a BulkSpentOutputs request
+func (c *Client) validateUTXOs(utxos []BulkSpentUTXO) error {
+	for i, utxo := range utxos {
+		if err := c.validateHash(fmt.Sprintf("utxos[%d].txid", i), utxo.TxID); err != nil {
+			return err
+		}
+		if err := c.validateIndex(fmt.Sprintf("utxos[%d].vout", i), utxo.Vout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkHash checks a hash is 64 hex characters
+func checkHash(field, hash string) error {
+	if len(hash) != hashHexLength {
+		return &InvalidInputError{Field: field, Reason: "must be 64 hex characters", Value: hash}
+	}
+	if _, err := hex.DecodeString(hash); err != nil {
+		return &InvalidInputError{Field: field, Reason: "must be 64 hex characters", Value: hash}
+	}
+	return nil
+}
+
+// checkAddress checks a Base58Check address (or, on BTC, a SegWit address) is for the network
+func checkAddress(field, address string, chain ChainType, network NetworkType) error {
+	if chain == ChainBTC && checkSegwitAddress(address, network) {
+		return nil
+	}
+	if err := ValidateAddress(address, network); err != nil {
+		reason := fmt.Sprintf("must be a valid %s address for the %s network", strings.ToUpper(string(chain)), network)
+		return &InvalidInputError{Field: field, Reason: reason, Value: address}
+	}
+	return nil
+}
+
+const (
+	// bech32Charset is the Bech32 alphabet
+	bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+	// bech32Const and bech32mConst are the checksum constants of Bech32 (BIP-173, witness version 0)
+	// and Bech32m (BIP-350, witness versions 1 to 16)
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+)
+
+// checkSegwitAddress returns true if the address is a valid BTC SegWit address (BIP-173/BIP-350)
+// for the network: "bc1..." on mainnet, "tb1..." on testnet and STN
+func checkSegwitAddress(address string, network NetworkType) bool {
+	if len(address) > 90 || (strings.ToLower(address) != address && strings.ToUpper(address) != address) {
+		return false
+	}
+	address = strings.ToLower(address)
+	hrp := "bc"
+	if network != NetworkMain {
+		hrp = "tb"
+	}
+	if !strings.HasPrefix(address, hrp+"1") || len(address) < len(hrp)+1+1+6 {
+		return false
+	}
+
+	data := make([]byte, 0, len(address)-len(hrp)-1)
+	for i := len(hrp) + 1; i < len(address); i++ {
+		value := strings.IndexByte(bech32Charset, address[i])
+		if value < 0 {
+			return false
+		}
+		data = append(data, byte(value))
+	}
+
+	// The checksum covers the expanded human-readable part and the data
+	values := make([]byte, 0, 2*len(hrp)+1+len(data))
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, hrp[i]>>5)
+	}
+	values = append(values, 0)
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, hrp[i]&31)
+	}
+	checksum := bech32Polymod(append(values, data...))
+
+	version := data[0]
+	program, ok := convertBits(data[1:len(data)-6], 5, 8)
+	switch {
+	case !ok || version > 16 || len(program) < 2 || len(program) > 40:
+		return false
+	case version == 0:
+		return checksum == bech32Const && (len(program) == 20 || len(program) == 32)
+	default:
+		return checksum == bech32mConst
+	}
+}
+
+// bech32Polymod computes the Bech32 checksum polynomial
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	checksum := uint32(1)
+	for _, value := range values {
+		top := checksum >> 25
+		checksum = (checksum&0x1ffffff)<<5 ^ uint32(value)
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				checksum ^= generator[i]
+			}
+		}
+	}
+	return checksum
+}
+
+// convertBits regroups from groups of fromBits to groups of toBits, without padding;
+// it returns false if the leftover bits are not zero padding
+func convertBits(data []byte, fromBits, toBits uint) ([]byte, bool) {
+	var accumulator, bits uint
+	converted := make([]byte, 0, len(data)*int(fromBits)/int(toBits))
+	for _, value := range data {
+		accumulator = accumulator<<fromBits | uint(value)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			converted = append(converted, byte(accumulator>>bits&(1<<toBits-1)))
+		}
+	}
+	if bits >= fromBits || accumulator&(1<<bits-1) != 0 {
+		return nil, false
+	}
+	return converted, true
+}
@@ -0,0 +1,259 @@
+package whatsonchain
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// requireInvalidInput checks err is an *InvalidInputError for the field
+func requireInvalidInput(t *testing.T, err error, field string) {
+	t.Helper()
+
+	require.ErrorIs(t, err, ErrInvalidInput)
+	var inputErr *InvalidInputError
+	require.ErrorAs(t, err, &inputErr)
+	assert.Equal(t, field, inputErr.Field)
+}
+
+// TestClient_InputValidation tests malformed arguments are rejected before any request is made
+func TestClient_InputValidation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := &mockHTTPCacheable{}
+	client := newCachedMockClient(mock)
+
+	tests := []struct {
+		name  string
+		field string
+		call  func() error
+	}{
+		{"GetTxByHash short", "txid", func() error { _, err := client.GetTxByHash(ctx, "abc"); return err }},
+		{"GetTxByHash not hex", "txid", func() error { _, err := client.GetTxByHash(ctx, testTxIDInvalid); return err }},
+		{"GetRawTransactionOutputData index", "vOutIndex", func() error {
+			_, err := client.GetRawTransactionOutputData(ctx, testTxID1, -1)
+			return err
+		}},
+		{"GetSpentOutput", "txid", func() error { _, err := client.GetSpentOutput(ctx, "", 0); return err }},
+		{"GetBlockByHash", "hash", func() error { _, err := client.GetBlockByHash(ctx, "000000000019d6"); return err }},
+		{"GetHeaderByHash", "hash", func() error { _, err := client.GetHeaderByHash(ctx, "-1"); return err }},
+		{"GetScriptHistory", "scriptHash", func() error { _, err := client.GetScriptHistory(ctx, testAddress1); return err }},
+		{"AddressInfo", "address", func() error { _, err := client.AddressInfo(ctx, "not-an-address"); return err }},
+		{"AddressInfo testnet", "address", func() error {
+			_, err := client.AddressInfo(ctx, "mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn")
+			return err
+		}},
+		{"GetOneSatOrdinalByOutpoint", "outpoint", func() error {
+			_, err := client.GetOneSatOrdinalByOutpoint(ctx, testTxID1)
+			return err
+		}},
+		{"GetOneSatOrdinalByOrigin", "origin", func() error {
+			_, err := client.GetOneSatOrdinalByOrigin(ctx, testTxID1+"_x")
+			return err
+		}},
+		{"BulkBalance", "addresses[1]", func() error {
+			_, err := client.BulkBalance(ctx, &AddressList{Addresses: []string{testAddress1, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMJ"}})
+			return err
+		}},
+		{"BulkScriptConfirmedHistory", "scripts[0]", func() error {
+			_, err := client.BulkScriptConfirmedHistory(ctx, &ScriptsList{Scripts: []string{"zz"}})
+			return err
+		}},
+		{"BulkTransactionStatus", "txids[1]", func() error {
+			_, err := client.BulkTransactionStatus(ctx, &TxHashes{TxIDs: []string{testTxID1, testTxIDInvalid}})
+			return err
+		}},
+		{"BulkRawTransactionOutputData", "txids[0].vouts[1]", func() error {
+			_, err := client.BulkRawTransactionOutputData(ctx, &BulkRawOutputRequest{TxIDs: []BulkRawOutputTxID{{TxID: testTxID1, Vouts: []int{0, -2}}}})
+			return err
+		}},
+		{"BulkSpentOutputs", "utxos[1].txid", func() error {
+			_, err := client.BulkSpentOutputs(ctx, &BulkSpentOutputRequest{UTXOs: []BulkSpentUTXO{{TxID: testTxID1}, {TxID: "abc"}}})
+			return err
+		}},
+		{"BulkSpentOutputs vout", "utxos[0].vout", func() error {
+			_, err := client.BulkSpentOutputs(ctx, &BulkSpentOutputRequest{UTXOs: []BulkSpentUTXO{{TxID: testTxID1, Vout: -1}}})
+			return err
+		}},
+		{"BulkTransactionDetailsProcessor", "txids[22]", func() error {
+			txIDs := make([]string, 25)
+			for i := range txIDs {
+				txIDs[i] = testTxID1
+			}
+			txIDs[22] = "abc"
+			_, err := client.BulkTransactionDetailsProcessor(ctx, &TxHashes{TxIDs: txIDs})
+			return err
+		}},
+		{"BlockTransactionDetails", "hash", func() error {
+			for _, err := range client.BlockTransactionDetails(ctx, "abc", nil) {
+				return err
+			}
+			return nil
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requireInvalidInput(t, test.call(), test.field)
+		})
+	}
+	assert.Zero(t, mock.calls.Load())
+}
+
+// TestClient_InputValidation_Valid tests well-formed arguments are sent to the API
+func TestClient_InputValidation_Valid(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := &mockHTTPCacheable{bodies: map[string]string{
+		"/info":                                   `{"isvalid":true}`,
+		"/" + testBlockHashGenesis + "/header":    `{"hash":"` + testBlockHashGenesis + `"}`,
+		"/tx/hash/" + strings.ToUpper(testTxID1):  `{"txid":"` + testTxID1 + `"}`,
+		"/token/1satordinals/" + testTxID1 + "_0": `{"id":1}`,
+		"/token/1satordinals/" + testTxID1 + ".1": `{"id":2}`,
+	}}
+	client := newCachedMockClient(mock)
+
+	_, err := client.AddressInfo(ctx, testAddress1)
+	require.NoError(t, err)
+	_, err = client.AddressInfo(ctx, "3P14159f73E4gFr7JterCCQh9QjiTjiZrG")
+	require.NoError(t, err)
+	_, err = client.GetTxByHash(ctx, strings.ToUpper(testTxID1))
+	require.NoError(t, err)
+	_, err = client.GetHeaderByHash(ctx, testBlockHashGenesis)
+	require.NoError(t, err)
+	_, err = client.GetHeaderByHash(ctx, "0")
+	require.ErrorIs(t, err, ErrBlockNotFound) // a height is sent as-is
+	_, err = client.GetOneSatOrdinalByOutpoint(ctx, testTxID1+"_0")
+	require.NoError(t, err)
+	_, err = client.GetOneSatOrdinalByOutpoint(ctx, testTxID1+".1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), mock.calls.Load())
+}
+
+// TestClient_InputValidation_Network tests addresses are checked against the client's chain and network
+func TestClient_InputValidation_Network(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := &mockHTTPCacheable{bodies: map[string]string{"/info": `{"isvalid":true}`}}
+
+	testnet := newCachedMockClient(mock, WithNetwork(NetworkStn))
+	_, err := testnet.AddressInfo(ctx, "mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn")
+	require.NoError(t, err)
+	_, err = testnet.AddressInfo(ctx, testAddress1)
+	requireInvalidInput(t, err, "address")
+	assert.Contains(t, err.Error(), "must be a valid BSV address for the stn network")
+
+	// SegWit addresses are only valid on BTC
+	segwit := "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"
+	bsv := newCachedMockClient(mock)
+	_, err = bsv.AddressInfo(ctx, segwit)
+	requireInvalidInput(t, err, "address")
+
+	btc := newCachedMockClient(mock, WithChain(ChainBTC))
+	_, err = btc.AddressInfo(ctx, segwit)
+	require.NoError(t, err)
+	_, err = btc.AddressInfo(ctx, testAddress1)
+	require.NoError(t, err)
+	_, err = btc.BulkAddressConfirmedBalance(ctx, &AddressList{Addresses: []string{testAddress1, "tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7"}})
+	requireInvalidInput(t, err, "addresses[1]")
+	assert.Equal(t, int64(3), mock.calls.Load())
+}
+
+// TestWithInputValidation tests validation is on by default, and turning it off sends malformed values to the API
+func TestWithInputValidation(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockHTTPCacheable{}
+	_, err := newCachedMockClient(mock).GetTxByHash(context.Background(), "abc")
+	requireInvalidInput(t, err, "txid")
+	assert.Zero(t, mock.calls.Load())
+
+	client := newCachedMockClient(mock, WithInputValidation(false))
+	_, err = client.GetTxByHash(context.Background(), "abc")
+	require.ErrorIs(t, err, ErrTransactionNotFound)
+	require.NotErrorIs(t, err, ErrInvalidInput)
+	_, err = client.AddressInfo(context.Background(), "not-an-address")
+	require.ErrorIs(t, err, ErrAddressNotFound)
+	assert.Equal(t, int64(2), mock.calls.Load())
+}
+
+// TestInvalidInputError tests the error message and unwrapping
+func TestInvalidInputError(t *testing.T) {
+	t.Parallel()
+
+	err := error(&InvalidInputError{Field: "txid", Reason: "must be 64 hex characters", Value: "abc"})
+	assert.Equal(t, `invalid input: txid "abc": must be 64 hex characters`, err.Error())
+	require.ErrorIs(t, err, ErrInvalidInput)
+}
+
+// TestCheckSegwitAddress tests the BIP-173 and BIP-350 address vectors
+func TestCheckSegwitAddress(t *testing.T) {
+	t.Parallel()
+
+	for _, address := range []string{
+		"BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4",
+		"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4",
+		"bc1pw508d6qejxtdg4y5r3zarvary0c5xw7kw508d6qejxtdg4y5r3zarvary0c5xw7kt5nd6y",
+		"BC1SW50QGDZ25J",
+		"bc1zw508d6qejxtdg4y5r3zarvaryvaxxpcs",
+	} {
+		assert.True(t, checkSegwitAddress(address, NetworkMain), address)
+		assert.False(t, checkSegwitAddress(address, NetworkTest), address)
+	}
+
+	for _, address := range []string{
+		"tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7",
+		"tb1pqqqqp399et2xygdj5xreqhjjvcmzhxw4aywxecjdzew6hylgvsesf3hn0c",
+	} {
+		assert.True(t, checkSegwitAddress(address, NetworkTest), address)
+		assert.True(t, checkSegwitAddress(address, NetworkStn), address)
+		assert.False(t, checkSegwitAddress(address, NetworkMain), address)
+	}
+
+	for name, address := range map[string]string{
+		"empty":                "",
+		"checksum":             "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t5",
+		"mixed case":           "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kV8f3t4",
+		"invalid character":    "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3tb",
+		"base58":               testAddress1,
+		"v0 with bech32m":      "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kemeawh",
+		"v1 with bech32":       "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqh2y7hd",
+		"invalid program size": "BC1QR508D6QEJXTDG4Y5R3ZARVARYV98GJ9P",
+		"witness version 17":   "BC13W508D6QEJXTDG4Y5R3ZARVARY0C5XW7KN40WF2",
+		"non-zero padding":     "bc1zw508d6qejxtdg4y5r3zarvaryvqyzf3du",
+		"short program":        "bc1pw5dgrnzv",
+		"too long":             "bc1" + strings.Repeat("q", 88),
+		"separator only":       "bc1",
+		"checksum too short":   "bc1qqqqqq",
+	} {
+		assert.False(t, checkSegwitAddress(address, NetworkMain), name)
+	}
+}
+
+// TestValidateOutpoint tests the outpoint syntax
+func TestValidateOutpoint(t *testing.T) {
+	t.Parallel()
+
+	client := newCachedMockClient(&mockHTTPCacheable{}).(*Client)
+	require.NoError(t, client.validateOutpoint("outpoint", testTxID1+"_0"))
+	require.NoError(t, client.validateOutpoint("outpoint", testTxID1+".4294967295"))
+
+	for _, outpoint := range []string{
+		"",
+		testTxID1,
+		testTxID1 + "_",
+		testTxID1 + "_-1",
+		testTxID1 + "_4294967296",
+		testTxID1 + ":0",
+		"abc_0",
+		testTxIDInvalid + "_0",
+	} {
+		requireInvalidInput(t, client.validateOutpoint("outpoint", outpoint), "outpoint")
+	}
+}
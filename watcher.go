@@ -0,0 +1,357 @@
+package whatsonchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WatchEventType is the kind of change a Watcher reports
+type WatchEventType string
+
+const (
+	// WatchEventReceived is when funds arrive: a new UTXO (script hashes) or a higher total balance (addresses)
+	WatchEventReceived WatchEventType = "received"
+
+	// WatchEventSpent is when funds leave: a UTXO is gone (script hashes) or a lower total balance (addresses)
+	WatchEventSpent WatchEventType = "spent"
+
+	// WatchEventConfirmed is when funds are mined: an unconfirmed UTXO is confirmed (script hashes)
+	// or unconfirmed balance moves to the confirmed balance (addresses)
+	WatchEventConfirmed WatchEventType = "confirmed"
+
+	// WatchEventBalanceChanged is when the confirmed or unconfirmed balance changes
+	WatchEventBalanceChanged WatchEventType = "balance_changed"
+
+	// WatchEventError is when a poll (or the lookup of one item) fails
+	WatchEventError WatchEventType = "error"
+)
+
+const (
+	// defaultWatchInterval is the default time between Watcher polls
+	defaultWatchInterval = 10 * time.Second
+
+	// defaultWatchBuffer is the default size of the Watcher event channel
+	defaultWatchBuffer = 100
+)
+
+// WatchEvent is a change reported by a Watcher.
+// The first poll of an item sets its baseline and reports nothing.
+type WatchEvent struct {
+	Type       WatchEventType  `json:"type"`
+	Address    string          `json:"address,omitempty"`     // the watched address (address events)
+	ScriptHash string          `json:"script_hash,omitempty"` // the watched script hash (script events)
+	Amount     int64           `json:"amount,omitempty"`      // satoshis received, spent or confirmed
+	UTXO       *HistoryRecord  `json:"utxo,omitempty"`        // the UTXO received, spent or confirmed (script events)
+	Balance    *AddressBalance `json:"balance,omitempty"`     // the balance after the change
+	Previous   *AddressBalance `json:"previous,omitempty"`    // the balance before the change
+	Err        error           `json:"-"`                     // why the poll or lookup failed (WatchEventError)
+}
+
+// WatcherOptions are the options for NewWatcher
+type WatcherOptions struct {
+	Buffer   int           // size of the event channel (default 100)
+	Interval time.Duration // time between polls in Run (default 10s)
+}
+
+// Watcher polls a set of addresses and script hashes through the bulk endpoints (under the client's
+// rate limit) and reports the differences between polls as WatchEvents.
+//
+// Addresses are polled with BulkAddressUnconfirmedBalanceProcessor. Script hashes are polled with
+// BulkScriptUnconfirmedUTXOsProcessor and BulkScriptConfirmedUTXOsProcessor, so a UTXO that is no longer
+// unconfirmed can be told apart as confirmed or spent. Items can be added and removed at any time.
+type Watcher struct {
+	addresses map[string]*AddressBalance // watched addresses and their last balance (nil = not polled)
+	client    ClientInterface
+	events    chan *WatchEvent
+	interval  time.Duration
+	mu        sync.Mutex                           // protects addresses and scripts
+	pollMu    sync.Mutex                           // serializes Poll
+	scripts   map[string]map[string]*HistoryRecord // watched script hashes and their last UTXOs by outpoint (nil = not polled)
+}
+
+// NewWatcher creates a watcher polling through the client
+func NewWatcher(client ClientInterface, opts *WatcherOptions) *Watcher {
+	buffer, interval := defaultWatchBuffer, defaultWatchInterval
+	if opts != nil {
+		if opts.Buffer > 0 {
+			buffer = opts.Buffer
+		}
+		if opts.Interval > 0 {
+			interval = opts.Interval
+		}
+	}
+	return &Watcher{
+		addresses: make(map[string]*AddressBalance),
+		client:    client,
+		events:    make(chan *WatchEvent, buffer),
+		interval:  interval,
+		scripts:   make(map[string]map[string]*HistoryRecord),
+	}
+}
+
+// AddAddresses starts watching addresses (already watched ones are ignored).
+// It returns an *InvalidInputError, and adds none, if an address is not valid for the client's chain and network.
+func (w *Watcher) AddAddresses(addresses ...string) error {
+	chain, network := w.client.Chain(), w.client.Network()
+	for i, address := range addresses {
+		if err := checkAddress(fmt.Sprintf("addresses[%d]", i), address, chain, network); err != nil {
+			return err
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, address := range addresses {
+		if _, ok := w.addresses[address]; !ok {
+			w.addresses[address] = nil
+		}
+	}
+	return nil
+}
+
+// RemoveAddresses stops watching addresses
+func (w *Watcher) RemoveAddresses(addresses ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, address := range addresses {
+		delete(w.addresses, address)
+	}
+}
+
+// AddScriptHashes starts watching script hashes (already watched ones are ignored).
+// It returns an *InvalidInputError, and adds none, if a script hash is not 64 hex characters.
+func (w *Watcher) AddScriptHashes(scriptHashes ...string) error {
+	for i, scriptHash := range scriptHashes {
+		if err := checkHash(fmt.Sprintf("scriptHashes[%d]", i), scriptHash); err != nil {
+			return err
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, scriptHash := range scriptHashes {
+		if _, ok := w.scripts[scriptHash]; !ok {
+			w.scripts[scriptHash] = nil
+		}
+	}
+	return nil
+}
+
+// RemoveScriptHashes stops watching script hashes
+func (w *Watcher) RemoveScriptHashes(scriptHashes ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, scriptHash := range scriptHashes {
+		delete(w.scripts, scriptHash)
+	}
+}
+
+// Watched returns the watched addresses and script hashes, sorted
+func (w *Watcher) Watched() (addresses, scriptHashes []string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return sortedKeys(w.addresses), sortedKeys(w.scripts)
+}
+
+// Events returns the channel Run sends events to. It is closed when Run returns.
+func (w *Watcher) Events() <-chan *WatchEvent {
+	return w.events
+}
+
+// Run polls every interval until the context is canceled, sending the events to Events (waiting while the
+// channel is full). A failed poll is sent as a WatchEventError and the next poll goes ahead as usual.
+// Run returns the context's error and closes the event channel, so it can only be called once.
+func (w *Watcher) Run(ctx context.Context) error {
+	defer close(w.events)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		events, err := w.Poll(ctx)
+		if err != nil && ctx.Err() == nil {
+			events = append(events, &WatchEvent{Type: WatchEventError, Err: err})
+		}
+		for _, event := range events {
+			select {
+			case w.events <- event:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Poll fetches every watched item once, updates the snapshots and returns the changes since the last poll
+// (addresses first, then script hashes, each sorted). Items whose lookup failed keep their last snapshot.
+// The error reports failed requests (usually a *BulkError); the events of the other items are still returned.
+func (w *Watcher) Poll(ctx context.Context) ([]*WatchEvent, error) {
+	w.pollMu.Lock()
+	defer w.pollMu.Unlock()
+
+	addresses, scriptHashes := w.Watched()
+
+	var balances AddressBalances
+	var addressErr error
+	if len(addresses) > 0 {
+		balances, addressErr = w.client.BulkAddressUnconfirmedBalanceProcessor(ctx, &AddressList{Addresses: addresses})
+	}
+
+	var unconfirmed, confirmed BulkScriptUnspentResponse
+	var unconfirmedErr, confirmedErr error
+	if len(scriptHashes) > 0 {
+		list := &ScriptsList{Scripts: scriptHashes}
+		unconfirmed, unconfirmedErr = w.client.BulkScriptUnconfirmedUTXOsProcessor(ctx, list)
+		confirmed, confirmedErr = w.client.BulkScriptConfirmedUTXOsProcessor(ctx, list)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	events := w.diffAddresses(balances)
+	events = append(events, w.diffScripts(unconfirmed, confirmed)...)
+	return events, errors.Join(addressErr, unconfirmedErr, confirmedErr)
+}
+
+// diffAddresses updates the address balances and returns their changes
+func (w *Watcher) diffAddresses(records AddressBalances) []*WatchEvent {
+	slices.SortFunc(records, func(a, b *AddressBalanceRecord) int { return strings.Compare(a.Address, b.Address) })
+
+	var events []*WatchEvent
+	for _, record := range records {
+		previous, watched := w.addresses[record.Address]
+		switch {
+		case !watched:
+			continue // removed during the poll
+		case record.Error != "":
+			events = append(events, &WatchEvent{
+				Type: WatchEventError, Address: record.Address, Err: fmt.Errorf("%w: %s", ErrRequestFailed, record.Error),
+			})
+			continue
+		case record.Balance == nil:
+			continue
+		}
+
+		balance := *record.Balance
+		w.addresses[record.Address] = &balance
+		if previous == nil || balance == *previous {
+			continue
+		}
+
+		newEvent := func(eventType WatchEventType, amount int64) *WatchEvent {
+			return &WatchEvent{Type: eventType, Address: record.Address, Amount: amount, Balance: &balance, Previous: previous}
+		}
+		confirmedDelta := balance.Confirmed - previous.Confirmed
+		unconfirmedDelta := balance.Unconfirmed - previous.Unconfirmed
+		if total := confirmedDelta + unconfirmedDelta; total > 0 {
+			events = append(events, newEvent(WatchEventReceived, total))
+		} else if total < 0 {
+			events = append(events, newEvent(WatchEventSpent, -total))
+		}
+		if confirmedDelta > 0 && unconfirmedDelta < 0 {
+			events = append(events, newEvent(WatchEventConfirmed, min(confirmedDelta, -unconfirmedDelta)))
+		}
+		events = append(events, newEvent(WatchEventBalanceChanged, 0))
+	}
+	return events
+}
+
+// diffScripts updates the script UTXOs and returns their changes.
+// Script hashes missing from either response are skipped, as their UTXO set is incomplete.
+func (w *Watcher) diffScripts(unconfirmed, confirmed BulkScriptUnspentResponse) []*WatchEvent {
+	var events []*WatchEvent
+	errored := make(map[string]bool)
+	utxos := make(map[string]map[string]*HistoryRecord)
+	responses := make(map[string]int)
+	for _, response := range []BulkScriptUnspentResponse{unconfirmed, confirmed} {
+		for _, record := range response {
+			if _, watched := w.scripts[record.Script]; !watched || errored[record.Script] {
+				continue
+			}
+			if record.Error != "" {
+				errored[record.Script] = true
+				events = append(events, &WatchEvent{
+					Type: WatchEventError, ScriptHash: record.Script, Err: fmt.Errorf("%w: %s", ErrRequestFailed, record.Error),
+				})
+				continue
+			}
+			if utxos[record.Script] == nil {
+				utxos[record.Script] = make(map[string]*HistoryRecord)
+			}
+			for _, utxo := range record.Utxos {
+				// A UTXO mined between the two requests is in both (the confirmed one comes last)
+				utxos[record.Script][fmt.Sprintf("%s_%d", utxo.TxHash, utxo.TxPos)] = utxo
+			}
+			responses[record.Script]++
+		}
+	}
+
+	for _, scriptHash := range sortedKeys(utxos) {
+		if errored[scriptHash] || responses[scriptHash] < 2 {
+			continue
+		}
+		current, previous := utxos[scriptHash], w.scripts[scriptHash]
+		w.scripts[scriptHash] = current
+		if previous == nil {
+			continue
+		}
+
+		var changes []*WatchEvent
+		for _, outpoint := range sortedKeys(current) {
+			utxo := current[outpoint]
+			if before, ok := previous[outpoint]; !ok {
+				changes = append(changes, &WatchEvent{Type: WatchEventReceived, ScriptHash: scriptHash, Amount: utxo.Value, UTXO: utxo})
+			} else if before.Height <= 0 && utxo.Height > 0 {
+				changes = append(changes, &WatchEvent{Type: WatchEventConfirmed, ScriptHash: scriptHash, Amount: utxo.Value, UTXO: utxo})
+			}
+		}
+		for _, outpoint := range sortedKeys(previous) {
+			if _, ok := current[outpoint]; !ok {
+				utxo := previous[outpoint]
+				changes = append(changes, &WatchEvent{Type: WatchEventSpent, ScriptHash: scriptHash, Amount: utxo.Value, UTXO: utxo})
+			}
+		}
+
+		balance, before := utxoBalance(current), utxoBalance(previous)
+		if *balance != *before {
+			changes = append(changes, &WatchEvent{Type: WatchEventBalanceChanged, ScriptHash: scriptHash, Balance: balance, Previous: before})
+		}
+		for _, change := range changes {
+			change.Balance, change.Previous = balance, before
+		}
+		events = append(events, changes...)
+	}
+	return events
+}
+
+// utxoBalance sums UTXOs into a confirmed and unconfirmed balance
+func utxoBalance(utxos map[string]*HistoryRecord) *AddressBalance {
+	balance := &AddressBalance{}
+	for _, utxo := range utxos {
+		if utxo.Height > 0 {
+			balance.Confirmed += utxo.Value
+		} else {
+			balance.Unconfirmed += utxo.Value
+		}
+	}
+	return balance
+}
+
+// sortedKeys returns the keys of a map, sorted
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
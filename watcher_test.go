@@ -0,0 +1,334 @@
+package whatsonchain
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockHTTPWatch answers the bulk balance and UTXO endpoints from state that tests change between polls
+type mockHTTPWatch struct {
+	balances    map[string]*AddressBalance
+	confirmed   map[string][]*HistoryRecord
+	errors      map[string]string // per-item error messages
+	fail        bool              // answer every request with HTTP 500
+	mu          sync.Mutex
+	unconfirmed map[string][]*HistoryRecord
+}
+
+// newMockHTTPWatch returns an empty mockHTTPWatch
+func newMockHTTPWatch() *mockHTTPWatch {
+	return &mockHTTPWatch{
+		balances:    make(map[string]*AddressBalance),
+		confirmed:   make(map[string][]*HistoryRecord),
+		errors:      make(map[string]string),
+		unconfirmed: make(map[string][]*HistoryRecord),
+	}
+}
+
+// set changes the mock state
+func (m *mockHTTPWatch) set(fn func(m *mockHTTPWatch)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	fn(m)
+}
+
+// Do is a mock http request
+func (m *mockHTTPWatch) Do(req *http.Request) (*http.Response, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.fail {
+		return &http.Response{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}
+	var request struct {
+		Addresses []string `json:"addresses"`
+		Scripts   []string `json:"scripts"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		return nil, err
+	}
+
+	var records []any
+	switch {
+	case strings.HasSuffix(req.URL.Path, "/addresses/unconfirmed/balance"):
+		for _, address := range request.Addresses {
+			records = append(records, &AddressBalanceRecord{
+				Address: address, Balance: m.balances[address], Error: m.errors[address],
+			})
+		}
+	case strings.HasSuffix(req.URL.Path, "/scripts/unconfirmed/unspent"),
+		strings.HasSuffix(req.URL.Path, "/scripts/confirmed/unspent"):
+		utxos := m.unconfirmed
+		if strings.HasSuffix(req.URL.Path, "/scripts/confirmed/unspent") {
+			utxos = m.confirmed
+		}
+		for _, script := range request.Scripts {
+			records = append(records, &BulkScriptResponseRecord{Script: script, Utxos: utxos[script], Error: m.errors[script]})
+		}
+	default:
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}
+	body, _ := json.Marshal(records)
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(string(body)))}, nil
+}
+
+// eventTypes returns the types of the events
+func eventTypes(events []*WatchEvent) []WatchEventType {
+	types := make([]WatchEventType, 0, len(events))
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+// TestNewWatcher tests the watcher defaults and options
+func TestNewWatcher(t *testing.T) {
+	t.Parallel()
+
+	client := newCachedMockClient(newMockHTTPWatch())
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+		watcher := NewWatcher(client, nil)
+		assert.Equal(t, defaultWatchInterval, watcher.interval)
+		assert.Equal(t, defaultWatchBuffer, cap(watcher.events))
+	})
+
+	t.Run("options", func(t *testing.T) {
+		t.Parallel()
+		watcher := NewWatcher(client, &WatcherOptions{Buffer: 5, Interval: time.Minute})
+		assert.Equal(t, time.Minute, watcher.interval)
+		assert.Equal(t, 5, cap(watcher.events))
+	})
+}
+
+// TestWatcher_AddRemove tests adding and removing watched items
+func TestWatcher_AddRemove(t *testing.T) {
+	t.Parallel()
+
+	watcher := NewWatcher(newCachedMockClient(newMockHTTPWatch()), nil)
+
+	require.NoError(t, watcher.AddAddresses(testAddress2, testAddress1, testAddress1))
+	require.NoError(t, watcher.AddScriptHashes(testScriptHash1))
+	addresses, scriptHashes := watcher.Watched()
+	assert.Equal(t, []string{testAddress2, testAddress1}, addresses)
+	assert.Equal(t, []string{testScriptHash1}, scriptHashes)
+
+	requireInvalidInput(t, watcher.AddAddresses(testAddress3, "not-an-address"), "addresses[1]")
+	requireInvalidInput(t, watcher.AddScriptHashes("abc"), "scriptHashes[0]")
+	addresses, _ = watcher.Watched()
+	assert.Len(t, addresses, 2)
+
+	watcher.RemoveAddresses(testAddress1, testAddress3)
+	watcher.RemoveScriptHashes(testScriptHash1)
+	addresses, scriptHashes = watcher.Watched()
+	assert.Equal(t, []string{testAddress2}, addresses)
+	assert.Empty(t, scriptHashes)
+}
+
+// TestWatcher_PollAddresses tests balance changes become events
+func TestWatcher_PollAddresses(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := newMockHTTPWatch()
+	mock.balances[testAddress1] = &AddressBalance{Confirmed: 1000}
+	mock.balances[testAddress2] = &AddressBalance{Confirmed: 500}
+	watcher := NewWatcher(newCachedMockClient(mock), nil)
+	require.NoError(t, watcher.AddAddresses(testAddress1, testAddress2))
+
+	events, err := watcher.Poll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, events, "the first poll is the baseline")
+
+	t.Run("received", func(t *testing.T) {
+		mock.set(func(m *mockHTTPWatch) { m.balances[testAddress1] = &AddressBalance{Confirmed: 1000, Unconfirmed: 250} })
+		events, err = watcher.Poll(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []WatchEventType{WatchEventReceived, WatchEventBalanceChanged}, eventTypes(events))
+		assert.Equal(t, testAddress1, events[0].Address)
+		assert.Equal(t, int64(250), events[0].Amount)
+		assert.Equal(t, &AddressBalance{Confirmed: 1000}, events[1].Previous)
+		assert.Equal(t, &AddressBalance{Confirmed: 1000, Unconfirmed: 250}, events[1].Balance)
+	})
+
+	t.Run("confirmed", func(t *testing.T) {
+		mock.set(func(m *mockHTTPWatch) { m.balances[testAddress1] = &AddressBalance{Confirmed: 1250} })
+		events, err = watcher.Poll(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []WatchEventType{WatchEventConfirmed, WatchEventBalanceChanged}, eventTypes(events))
+		assert.Equal(t, int64(250), events[0].Amount)
+	})
+
+	t.Run("spent", func(t *testing.T) {
+		mock.set(func(m *mockHTTPWatch) { m.balances[testAddress2] = &AddressBalance{Confirmed: 500, Unconfirmed: -200} })
+		events, err = watcher.Poll(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []WatchEventType{WatchEventSpent, WatchEventBalanceChanged}, eventTypes(events))
+		assert.Equal(t, testAddress2, events[0].Address)
+		assert.Equal(t, int64(200), events[0].Amount)
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		events, err = watcher.Poll(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("item error", func(t *testing.T) {
+		mock.set(func(m *mockHTTPWatch) { m.errors[testAddress1] = "lookup failed" })
+		events, err = watcher.Poll(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []WatchEventType{WatchEventError}, eventTypes(events))
+		assert.Equal(t, testAddress1, events[0].Address)
+		require.ErrorIs(t, events[0].Err, ErrRequestFailed)
+		assert.Contains(t, events[0].Err.Error(), "lookup failed")
+	})
+}
+
+// TestWatcher_PollScripts tests UTXO changes become events
+func TestWatcher_PollScripts(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	utxo1 := &HistoryRecord{TxHash: testTxID1, TxPos: 0, Value: 1000}
+	utxo2 := &HistoryRecord{TxHash: testTxID1, TxPos: 1, Value: 300}
+	mock := newMockHTTPWatch()
+	mock.unconfirmed[testScriptHash1] = []*HistoryRecord{utxo1}
+	watcher := NewWatcher(newCachedMockClient(mock), nil)
+	require.NoError(t, watcher.AddScriptHashes(testScriptHash1))
+
+	events, err := watcher.Poll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, events, "the first poll is the baseline")
+
+	t.Run("received", func(t *testing.T) {
+		mock.set(func(m *mockHTTPWatch) { m.unconfirmed[testScriptHash1] = []*HistoryRecord{utxo1, utxo2} })
+		events, err = watcher.Poll(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []WatchEventType{WatchEventReceived, WatchEventBalanceChanged}, eventTypes(events))
+		assert.Equal(t, testScriptHash1, events[0].ScriptHash)
+		assert.Equal(t, utxo2, events[0].UTXO)
+		assert.Equal(t, int64(300), events[0].Amount)
+		assert.Equal(t, &AddressBalance{Unconfirmed: 1300}, events[0].Balance)
+		assert.Equal(t, &AddressBalance{Unconfirmed: 1000}, events[0].Previous)
+	})
+
+	t.Run("confirmed", func(t *testing.T) {
+		mock.set(func(m *mockHTTPWatch) {
+			m.unconfirmed[testScriptHash1] = []*HistoryRecord{utxo2}
+			m.confirmed[testScriptHash1] = []*HistoryRecord{{TxHash: testTxID1, TxPos: 0, Value: 1000, Height: 800000}}
+		})
+		events, err = watcher.Poll(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []WatchEventType{WatchEventConfirmed, WatchEventBalanceChanged}, eventTypes(events))
+		assert.Equal(t, int64(800000), events[0].UTXO.Height)
+		assert.Equal(t, &AddressBalance{Confirmed: 1000, Unconfirmed: 300}, events[1].Balance)
+	})
+
+	t.Run("spent", func(t *testing.T) {
+		mock.set(func(m *mockHTTPWatch) { m.unconfirmed[testScriptHash1] = nil })
+		events, err = watcher.Poll(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []WatchEventType{WatchEventSpent, WatchEventBalanceChanged}, eventTypes(events))
+		assert.Equal(t, int64(1), events[0].UTXO.TxPos)
+		assert.Equal(t, int64(300), events[0].Amount)
+	})
+
+	t.Run("item error keeps the snapshot", func(t *testing.T) {
+		mock.set(func(m *mockHTTPWatch) {
+			m.errors[testScriptHash1] = "lookup failed"
+			m.confirmed[testScriptHash1] = nil
+		})
+		events, err = watcher.Poll(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []WatchEventType{WatchEventError}, eventTypes(events))
+		assert.Equal(t, testScriptHash1, events[0].ScriptHash)
+
+		mock.set(func(m *mockHTTPWatch) { delete(m.errors, testScriptHash1) })
+		events, err = watcher.Poll(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []WatchEventType{WatchEventSpent, WatchEventBalanceChanged}, eventTypes(events))
+		assert.Equal(t, int64(1000), events[0].Amount)
+	})
+}
+
+// TestWatcher_PollFailure tests a failed poll keeps the snapshots
+func TestWatcher_PollFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := newMockHTTPWatch()
+	mock.balances[testAddress1] = &AddressBalance{Confirmed: 1000}
+	watcher := NewWatcher(newCachedMockClient(mock), nil)
+	require.NoError(t, watcher.AddAddresses(testAddress1))
+	require.NoError(t, watcher.AddScriptHashes(testScriptHash1))
+
+	_, err := watcher.Poll(ctx)
+	require.NoError(t, err)
+
+	mock.set(func(m *mockHTTPWatch) {
+		m.fail = true
+		m.balances[testAddress1] = &AddressBalance{Confirmed: 1500}
+	})
+	events, err := watcher.Poll(ctx)
+	require.Error(t, err)
+	var bulkErr *BulkError
+	require.ErrorAs(t, err, &bulkErr)
+	assert.Empty(t, events)
+
+	mock.set(func(m *mockHTTPWatch) { m.fail = false })
+	events, err = watcher.Poll(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []WatchEventType{WatchEventReceived, WatchEventBalanceChanged}, eventTypes(events))
+	assert.Equal(t, int64(500), events[0].Amount)
+}
+
+// TestWatcher_Run tests Run sends events until the context is canceled
+func TestWatcher_Run(t *testing.T) {
+	t.Parallel()
+
+	mock := newMockHTTPWatch()
+	mock.balances[testAddress1] = &AddressBalance{Confirmed: 1000}
+	watcher := NewWatcher(newCachedMockClient(mock), &WatcherOptions{Interval: 10 * time.Millisecond})
+	require.NoError(t, watcher.AddAddresses(testAddress1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- watcher.Run(ctx)
+	}()
+
+	// Added while running: the next poll sets its baseline
+	mock.set(func(m *mockHTTPWatch) { m.balances[testAddress2] = &AddressBalance{Confirmed: 700} })
+	require.NoError(t, watcher.AddAddresses(testAddress2))
+	require.Eventually(t, func() bool {
+		watcher.mu.Lock()
+		defer watcher.mu.Unlock()
+		return watcher.addresses[testAddress2] != nil
+	}, time.Second, 5*time.Millisecond)
+
+	mock.set(func(m *mockHTTPWatch) { m.balances[testAddress2] = &AddressBalance{Confirmed: 700, Unconfirmed: 50} })
+	select {
+	case event := <-watcher.Events():
+		assert.Equal(t, WatchEventReceived, event.Type)
+		assert.Equal(t, testAddress2, event.Address)
+		assert.Equal(t, int64(50), event.Amount)
+	case <-time.After(time.Second):
+		require.Fail(t, "no event")
+	}
+
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+	for range watcher.Events() { //nolint:revive // drain until Run closes the channel
+	}
+}
@@ -0,0 +1,295 @@
+package whatsonchain
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // G505: SHA-1 is mandated by the WebSocket handshake (RFC 6455)
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// WebSocket opcodes (RFC 6455 section 5.2)
+const (
+	wsOpContinuation byte = 0x0
+	wsOpText         byte = 0x1
+	wsOpBinary       byte = 0x2
+	wsOpClose        byte = 0x8
+	wsOpPing         byte = 0x9
+	wsOpPong         byte = 0xA
+)
+
+const (
+	// wsAcceptGUID is appended to the handshake key to compute Sec-WebSocket-Accept
+	wsAcceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+	// wsCloseNormal is the close code for a normal closure
+	wsCloseNormal = 1000
+
+	// wsMaxControlPayload is the largest payload of a control frame
+	wsMaxControlPayload = 125
+
+	// wsMaxMessageSize is the largest message read (a large mempool transaction fits comfortably)
+	wsMaxMessageSize = 32 << 20
+)
+
+// wsConn is a minimal RFC 6455 WebSocket connection: enough of the protocol for a streaming client
+// (text and binary messages, fragmentation, ping/pong and close), with no extensions.
+type wsConn struct {
+	br      *bufio.Reader
+	client  bool // frames written by a client are masked, frames read by a client must not be
+	conn    net.Conn
+	writeMu sync.Mutex // serializes frame writes
+}
+
+// dialWebSocket opens a WebSocket connection to a ws:// or wss:// URL.
+// The context and timeout bound the dial and the handshake, not the connection that follows.
+func dialWebSocket(ctx context.Context, rawURL string, header http.Header, timeout time.Duration) (*wsConn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	host := u.Host
+	if u.Port() == "" {
+		switch u.Scheme {
+		case "ws":
+			host = net.JoinHostPort(u.Hostname(), "80")
+		case "wss":
+			host = net.JoinHostPort(u.Hostname(), "443")
+		}
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	var conn net.Conn
+	switch u.Scheme {
+	case "ws":
+		conn, err = dialer.DialContext(ctx, "tcp", host)
+	case "wss":
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12}}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", host)
+	default:
+		return nil, fmt.Errorf("%w: unsupported scheme %q", ErrWebSocketHandshake, u.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(timeout))
+	}
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Unix(1, 0)) })
+	ws, err := handshakeWebSocket(conn, u, header)
+	if !stop() {
+		err = ctx.Err() // the deadline was forced by the context
+	}
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return ws, nil
+}
+
+// handshakeWebSocket sends the opening handshake on conn and checks the server's answer
+func handshakeWebSocket(conn net.Conn, u *url.URL, header http.Header) (*wsConn, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	req := &http.Request{Method: http.MethodGet, URL: u, Host: u.Host, Header: header.Clone()}
+	if req.Header == nil {
+		req.Header = http.Header{}
+	}
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Sec-WebSocket-Key", key)
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	if err := req.Write(conn); err != nil {
+		return nil, err
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		return nil, fmt.Errorf("%w: %s", ErrWebSocketHandshake, resp.Status)
+	}
+	if resp.Header.Get("Sec-WebSocket-Accept") != wsAcceptKey(key) {
+		return nil, fmt.Errorf("%w: bad Sec-WebSocket-Accept", ErrWebSocketHandshake)
+	}
+	return &wsConn{br: br, client: true, conn: conn}, nil
+}
+
+// wsAcceptKey returns the Sec-WebSocket-Accept value for a Sec-WebSocket-Key
+func wsAcceptKey(key string) string {
+	sum := sha1.Sum([]byte(key + wsAcceptGUID)) //nolint:gosec // G401: mandated by RFC 6455
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// ReadMessage returns the next text or binary message, joining fragments.
+// Pings are answered and pongs skipped; a close frame is answered and returned as ErrWebSocketClosed.
+func (c *wsConn) ReadMessage() (opcode byte, message []byte, err error) {
+	for {
+		fin, op, payload, err := c.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch op {
+		case wsOpPing:
+			if err = c.writeFrame(wsOpPong, payload); err != nil {
+				return 0, nil, err
+			}
+			continue
+		case wsOpPong:
+			continue
+		case wsOpClose:
+			code := 1005 // no status code present
+			if len(payload) >= 2 {
+				code = int(binary.BigEndian.Uint16(payload))
+			}
+			_ = c.writeFrame(wsOpClose, payload[:min(len(payload), 2)])
+			return 0, nil, fmt.Errorf("%w: code %d", ErrWebSocketClosed, code)
+		case wsOpText, wsOpBinary:
+			if opcode != 0 {
+				return 0, nil, fmt.Errorf("%w: new message inside a fragmented one", ErrWebSocketProtocol)
+			}
+			opcode, message = op, payload
+		case wsOpContinuation:
+			if opcode == 0 {
+				return 0, nil, fmt.Errorf("%w: continuation without a message", ErrWebSocketProtocol)
+			}
+			if len(message)+len(payload) > wsMaxMessageSize {
+				return 0, nil, fmt.Errorf("%w: message larger than %d bytes", ErrWebSocketProtocol, wsMaxMessageSize)
+			}
+			message = append(message, payload...)
+		default:
+			return 0, nil, fmt.Errorf("%w: unknown opcode %#x", ErrWebSocketProtocol, op)
+		}
+
+		if fin {
+			return opcode, message, nil
+		}
+	}
+}
+
+// WriteMessage sends a text or binary message in a single frame
+func (c *wsConn) WriteMessage(opcode byte, message []byte) error {
+	return c.writeFrame(opcode, message)
+}
+
+// Close sends a normal close frame (best effort) and closes the connection
+func (c *wsConn) Close() error {
+	_ = c.conn.SetWriteDeadline(time.Now().Add(time.Second))
+	_ = c.writeFrame(wsOpClose, binary.BigEndian.AppendUint16(nil, wsCloseNormal))
+	return c.conn.Close()
+}
+
+// readFrame reads one frame and unmasks its payload
+func (c *wsConn) readFrame() (fin bool, opcode byte, payload []byte, err error) {
+	var header [2]byte
+	if _, err = io.ReadFull(c.br, header[:]); err != nil {
+		return false, 0, nil, err
+	}
+	fin, opcode = header[0]&0x80 != 0, header[0]&0x0F
+	if header[0]&0x70 != 0 {
+		return false, 0, nil, fmt.Errorf("%w: reserved bits set", ErrWebSocketProtocol)
+	}
+	masked := header[1]&0x80 != 0
+	if masked == c.client {
+		return false, 0, nil, fmt.Errorf("%w: unexpected masking", ErrWebSocketProtocol)
+	}
+
+	length := uint64(header[1] & 0x7F)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err = io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err = io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if opcode >= wsOpClose && (!fin || length > wsMaxControlPayload) {
+		return false, 0, nil, fmt.Errorf("%w: invalid control frame", ErrWebSocketProtocol)
+	}
+	if length > wsMaxMessageSize {
+		return false, 0, nil, fmt.Errorf("%w: frame larger than %d bytes", ErrWebSocketProtocol, wsMaxMessageSize)
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err = io.ReadFull(c.br, mask[:]); err != nil {
+			return false, 0, nil, err
+		}
+	}
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(c.br, payload); err != nil {
+		return false, 0, nil, err
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return fin, opcode, payload, nil
+}
+
+// writeFrame writes one final frame, masked when written by a client
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	frame := make([]byte, 0, 14+len(payload))
+	frame = append(frame, 0x80|opcode)
+
+	var maskBit byte
+	if c.client {
+		maskBit = 0x80
+	}
+	switch length := len(payload); {
+	case length < 126:
+		frame = append(frame, maskBit|byte(length))
+	case length <= 0xFFFF:
+		frame = append(frame, maskBit|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(length))
+	default:
+		frame = append(frame, maskBit|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(length))
+	}
+
+	if c.client {
+		var mask [4]byte
+		if _, err := rand.Read(mask[:]); err != nil {
+			return err
+		}
+		frame = append(frame, mask[:]...)
+		start := len(frame)
+		frame = append(frame, payload...)
+		for i := range payload {
+			frame[start+i] ^= mask[i%4]
+		}
+	} else {
+		frame = append(frame, payload...)
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_, err := c.conn.Write(frame)
+	return err
+}
@@ -0,0 +1,216 @@
+package whatsonchain
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// acceptWebSocket completes the server side of a WebSocket handshake (for the fake servers in tests)
+func acceptWebSocket(w http.ResponseWriter, req *http.Request) (*wsConn, error) {
+	if !strings.EqualFold(req.Header.Get("Upgrade"), "websocket") || req.Header.Get("Sec-WebSocket-Version") != "13" {
+		http.Error(w, "not a websocket handshake", http.StatusBadRequest)
+		return nil, ErrWebSocketHandshake
+	}
+	conn, rw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		return nil, err
+	}
+	_, err = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + wsAcceptKey(req.Header.Get("Sec-WebSocket-Key")) + "\r\n\r\n")
+	if err == nil {
+		err = rw.Flush()
+	}
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return &wsConn{br: rw.Reader, conn: conn}, nil
+}
+
+// newFakeWebSocketServer starts a WebSocket server that hands every accepted connection to the test
+func newFakeWebSocketServer(t *testing.T) (wsURL string, conns <-chan *wsConn) {
+	t.Helper()
+
+	accepted := make(chan *wsConn, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if conn, err := acceptWebSocket(w, req); err == nil {
+			accepted <- conn
+		}
+	}))
+	t.Cleanup(server.Close)
+	return "ws" + strings.TrimPrefix(server.URL, "http"), accepted
+}
+
+// dialFakeWebSocket dials the fake server and returns both ends of the connection
+func dialFakeWebSocket(t *testing.T) (client, server *wsConn) {
+	t.Helper()
+
+	wsURL, conns := newFakeWebSocketServer(t)
+	client, err := dialWebSocket(context.Background(), wsURL, nil, time.Second)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.conn.Close() })
+	select {
+	case server = <-conns:
+		t.Cleanup(func() { _ = server.conn.Close() })
+	case <-time.After(time.Second):
+		require.Fail(t, "no connection accepted")
+	}
+	return client, server
+}
+
+// TestWebSocket_Messages tests messages of every length encoding in both directions
+func TestWebSocket_Messages(t *testing.T) {
+	t.Parallel()
+
+	client, server := dialFakeWebSocket(t)
+	for _, size := range []int{0, 125, 126, 65535, 70000} {
+		message := bytes.Repeat([]byte{'x'}, size)
+
+		require.NoError(t, client.WriteMessage(wsOpText, message))
+		opcode, got, err := server.ReadMessage()
+		require.NoError(t, err)
+		assert.Equal(t, wsOpText, opcode)
+		assert.Equal(t, message, got, "client to server, %d bytes", size)
+
+		require.NoError(t, server.WriteMessage(wsOpBinary, message))
+		opcode, got, err = client.ReadMessage()
+		require.NoError(t, err)
+		assert.Equal(t, wsOpBinary, opcode)
+		assert.Equal(t, message, got, "server to client, %d bytes", size)
+	}
+}
+
+// TestWebSocket_Fragments tests fragmented messages are joined and pings in between answered
+func TestWebSocket_Fragments(t *testing.T) {
+	t.Parallel()
+
+	client, server := dialFakeWebSocket(t)
+	_, err := server.conn.Write([]byte{
+		0x01, 3, 'a', 'b', 'c', // text, not final
+		0x89, 2, 'h', 'i', // ping
+		0x80, 3, 'd', 'e', 'f', // continuation, final
+	})
+	require.NoError(t, err)
+
+	opcode, message, err := client.ReadMessage()
+	require.NoError(t, err)
+	assert.Equal(t, wsOpText, opcode)
+	assert.Equal(t, "abcdef", string(message))
+
+	fin, opcode, payload, err := server.readFrame()
+	require.NoError(t, err)
+	assert.True(t, fin)
+	assert.Equal(t, wsOpPong, opcode)
+	assert.Equal(t, "hi", string(payload))
+}
+
+// TestWebSocket_Close tests a close frame is answered and returned as ErrWebSocketClosed
+func TestWebSocket_Close(t *testing.T) {
+	t.Parallel()
+
+	client, server := dialFakeWebSocket(t)
+	require.NoError(t, server.writeFrame(wsOpClose, []byte{0x03, 0xE9})) // 1001 going away
+
+	_, _, err := client.ReadMessage()
+	require.ErrorIs(t, err, ErrWebSocketClosed)
+	assert.Contains(t, err.Error(), "1001")
+
+	_, opcode, payload, err := server.readFrame()
+	require.NoError(t, err)
+	assert.Equal(t, wsOpClose, opcode)
+	assert.Equal(t, []byte{0x03, 0xE9}, payload)
+}
+
+// TestWebSocket_ProtocolErrors tests frames breaking the protocol are rejected
+func TestWebSocket_ProtocolErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		frame []byte
+	}{
+		{"masked server frame", []byte{0x81, 0x81, 1, 2, 3, 4, 'a'}},
+		{"reserved bits", []byte{0xC1, 1, 'a'}},
+		{"unknown opcode", []byte{0x83, 1, 'a'}},
+		{"continuation without a message", []byte{0x80, 1, 'a'}},
+		{"fragmented control frame", []byte{0x09, 1, 'a'}},
+		{"oversized frame", []byte{0x82, 127, 0xFF, 0, 0, 0, 0, 0, 0, 0}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			client, server := dialFakeWebSocket(t)
+			_, err := server.conn.Write(test.frame)
+			require.NoError(t, err)
+			_, _, err = client.ReadMessage()
+			require.ErrorIs(t, err, ErrWebSocketProtocol)
+		})
+	}
+}
+
+// TestDialWebSocket_Errors tests failed handshakes
+func TestDialWebSocket_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rejected", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		_, err := dialWebSocket(context.Background(), "ws"+strings.TrimPrefix(server.URL, "http"), nil, time.Second)
+		require.ErrorIs(t, err, ErrWebSocketHandshake)
+		assert.Contains(t, err.Error(), "403")
+	})
+
+	t.Run("bad accept key", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Upgrade", "websocket")
+			w.Header().Set("Connection", "Upgrade")
+			w.Header().Set("Sec-WebSocket-Accept", "wrong")
+			w.WriteHeader(http.StatusSwitchingProtocols)
+		}))
+		defer server.Close()
+
+		_, err := dialWebSocket(context.Background(), "ws"+strings.TrimPrefix(server.URL, "http"), nil, time.Second)
+		require.ErrorIs(t, err, ErrWebSocketHandshake)
+	})
+
+	t.Run("unsupported scheme", func(t *testing.T) {
+		t.Parallel()
+		_, err := dialWebSocket(context.Background(), "http://localhost", nil, time.Second)
+		require.ErrorIs(t, err, ErrWebSocketHandshake)
+	})
+
+	t.Run("canceled handshake", func(t *testing.T) {
+		t.Parallel()
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer func() { _ = listener.Close() }()
+		go func() {
+			conn, acceptErr := listener.Accept()
+			if acceptErr == nil {
+				defer func() { _ = conn.Close() }()
+				time.Sleep(time.Second) // never answers the handshake
+			}
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err = dialWebSocket(ctx, "ws://"+listener.Addr().String(), nil, 10*time.Second)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+	})
+}
@@ -63,6 +63,11 @@ func NewClient(_ context.Context, opts ...ClientOption) (ClientInterface, error)
 	if !validNetworks[options.network] {
 		return nil, ErrInvalidNetwork
 	}
+	for _, baseURL := range options.endpoints {
+		if err := validateBaseURL(baseURL); err != nil {
+			return nil, err
+		}
+	}
 
 	// Create and return the client
 	return newClientFromOptions(options), nil
@@ -90,11 +95,15 @@ func (c *Client) request(ctx context.Context, url, method string, payload []byte
 	c.lastRequest.URL = url
 	c.lastRequestMu.Unlock()
 
+	// Trace collects response metadata (headers, retry attempts) for error reporting,
+	// and carries the rate limiter so every retry waits for a token too
+	trace := &requestTrace{limiter: c.limiter}
+
 	// Start the request
 	var request *http.Request
 	var err error
 	if request, err = http.NewRequestWithContext(
-		ctx, method, url, bodyReader,
+		withRequestTrace(ctx, trace), method, url, bodyReader,
 	); err != nil {
 		return nil, 0, err
 	}
@@ -118,16 +127,49 @@ func (c *Client) request(ctx context.Context, url, method string, payload []byte
 		request.Header.Set(apiHeaderKey, apiKey)
 	}
 
-	// Fire the http request
-	var resp *http.Response
-	if resp, err = c.httpClient.Do(request); err != nil {
+	// Fire the http request through the middleware chain
+	body, statusCode, err := c.handler(request)
+
+	// Set the status under mutex
+	c.lastRequestMu.Lock()
+	c.lastRequest.StatusCode = statusCode
+	c.lastRequestMu.Unlock()
+
+	if err != nil {
+		return body, statusCode, err
+	}
+
+	// Convert unsuccessful status codes into a structured *APIError
+	return body, statusCode, checkStatusCode(request, statusCode, body, trace)
+}
+
+// doRequest is the innermost RequestHandler: it fires the request, scoring the base URL
+// and failing over to the next one when several are configured (see WithEndpoints)
+func (c *Client) doRequest(request *http.Request) ([]byte, int, error) {
+	return c.endpoints.do(request, c.doHTTP)
+}
+
+// doHTTP fires the request with the http client and reads the response body
+// with a size limit to prevent unbounded memory allocation
+func (c *Client) doHTTP(request *http.Request) ([]byte, int, error) {
+	// Wait for a token from the shared rate limiter (respects context cancellation),
+	// once per base URL tried; retries wait in the http client (see requestTrace)
+	if err := c.limiter.wait(request.Context()); err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := c.httpClient.Do(request)
+
+	// Record the response headers for error reporting
+	if trace := requestTraceFromContext(request.Context()); trace != nil && resp != nil {
+		trace.header = resp.Header
+	}
+
+	if err != nil {
 		var statusCode int
 		if resp != nil {
 			statusCode = resp.StatusCode
 		}
-		c.lastRequestMu.Lock()
-		c.lastRequest.StatusCode = statusCode
-		c.lastRequestMu.Unlock()
 		return nil, statusCode, err
 	}
 
@@ -136,12 +178,6 @@ func (c *Client) request(ctx context.Context, url, method string, payload []byte
 		_ = resp.Body.Close()
 	}()
 
-	// Set the status under mutex
-	c.lastRequestMu.Lock()
-	c.lastRequest.StatusCode = resp.StatusCode
-	c.lastRequestMu.Unlock()
-
-	// Read the body with a size limit to prevent unbounded memory allocation
 	var body []byte
 	if body, err = io.ReadAll(io.LimitReader(resp.Body, maxResponseSize)); err != nil {
 		return nil, resp.StatusCode, err
@@ -164,6 +200,18 @@ func (c *Client) RateLimit() int {
 	return c.options.rateLimit
 }
 
+// RateBurst will return the current configured rate limit burst
+func (c *Client) RateBurst() int {
+	c.optionsMu.RLock()
+	defer c.optionsMu.RUnlock()
+	return c.options.effectiveRateBurst()
+}
+
+// RateLimitQueueDepth will return the number of requests currently waiting on the rate limiter
+func (c *Client) RateLimitQueueDepth() int {
+	return c.limiter.queueDepth()
+}
+
 // Chain will return the chain
 func (c *Client) Chain() ChainType {
 	c.optionsMu.RLock()
@@ -219,6 +267,8 @@ func (c *Client) SetUserAgent(userAgent string) {
 
 // SetRateLimit sets the rate limit.
 // Values less than 1 are clamped to 1 to prevent panics in batch processors.
+// The shared rate limiter is reconfigured in place: requests already waiting keep
+// their place in line (and their wait), and the new rate applies to the next ones.
 // This method is safe for concurrent use.
 func (c *Client) SetRateLimit(rateLimit int) {
 	if rateLimit < 1 {
@@ -227,6 +277,7 @@ func (c *Client) SetRateLimit(rateLimit int) {
 	c.optionsMu.Lock()
 	defer c.optionsMu.Unlock()
 	c.options.rateLimit = rateLimit
+	c.limiter.setLimit(rateLimit, c.options.effectiveRateBurst())
 }
 
 // SetChain sets the blockchain type.
@@ -13,14 +13,25 @@ const (
 	testTxID2        = "91f68c2c598bc73812dd32d60ab67005eac498bef5f0c45b822b3c9468ba3258"
 	testTxIDInvalid  = "294cd1ebd5689fdee03509f92c32184c0f52f037d4046af250229b97e0c8f1VV"
 	testTxID2Invalid = "91f68c2c598bc73812dd32d60ab67005eac498bef5f0c45b822b3c9468ba32VV"
+	testTxIDUnknown  = "294cd1ebd5689fdee03509f92c32184c0f52f037d4046af250229b97e0c8f1ff"
+	testTxID2Unknown = "91f68c2c598bc73812dd32d60ab67005eac498bef5f0c45b822b3c9468ba32ff"
 	testAddress3     = "1KGHhLTQaPr4LErrvbAuGE62yPpDoRwrob"
+
+	// Well-formed values the mocks answer with an error, an unusable response or a 404
+	// (client-side validation is on, so routing on malformed values would never reach the mocks)
+	testAddressError    = "11111111111111111113VT49JKz"
+	testAddressInvalid  = "11111111111111111112zdQC4qC"
+	testAddressNotFound = "1111111111111111111313xyAwW"
+	testHashError       = "0000000000000000000000000000000000000000000000000000000000000500"
+	testHashInvalid     = "0000000000000000000000000000000000000000000000000000000000000400"
+	testHashNotFound    = "0000000000000000000000000000000000000000000000000000000000000404"
 )
 
 // newMockClient returns a client for mocking
 func newMockClient(httpClient HTTPInterface) ClientInterface {
 	client, _ := NewClient(
 		context.Background(),
-		WithNetwork(NetworkTest),
+		WithNetwork(NetworkMain),
 		WithAPIKey(testKey),
 		WithHTTPClient(httpClient),
 	)
@@ -32,7 +43,7 @@ func newMockClientBSV(httpClient HTTPInterface) ClientInterface {
 	client, _ := NewClient(
 		context.Background(),
 		WithChain(ChainBSV),
-		WithNetwork(NetworkTest),
+		WithNetwork(NetworkMain),
 		WithAPIKey(testKey),
 		WithHTTPClient(httpClient),
 	)
@@ -44,7 +55,7 @@ func newMockClientBTC(httpClient HTTPInterface) ClientInterface {
 	client, _ := NewClient(
 		context.Background(),
 		WithChain(ChainBTC),
-		WithNetwork(NetworkTest),
+		WithNetwork(NetworkMain),
 		WithAPIKey(testKey),
 		WithHTTPClient(httpClient),
 	)